@@ -1,19 +1,50 @@
 package routes
 
 import (
+	"context"
 	_connHandler "motorbike-rental-backend/internal/app/bluetooth-connection/handlers"
 	_connService "motorbike-rental-backend/internal/app/bluetooth-connection/services"
+	_damageHandler "motorbike-rental-backend/internal/app/damage-report/handlers"
+	_damageService "motorbike-rental-backend/internal/app/damage-report/services"
+	_deviceHandler "motorbike-rental-backend/internal/app/device/handlers"
+	_deviceService "motorbike-rental-backend/internal/app/device/services"
+	_disputeHandler "motorbike-rental-backend/internal/app/dispute/handlers"
+	_disputeService "motorbike-rental-backend/internal/app/dispute/services"
+	_fileHandler "motorbike-rental-backend/internal/app/file/handlers"
+	_invoiceHandler "motorbike-rental-backend/internal/app/invoice/handlers"
+	_invoiceService "motorbike-rental-backend/internal/app/invoice/services"
+	_maintenanceHandler "motorbike-rental-backend/internal/app/maintenance/handlers"
+	_maintenanceService "motorbike-rental-backend/internal/app/maintenance/services"
 	_mapHandler "motorbike-rental-backend/internal/app/map/handlers"
 	_mapService "motorbike-rental-backend/internal/app/map/services"
 	_motorHandler "motorbike-rental-backend/internal/app/motorbike/handlers"
 	_motorService "motorbike-rental-backend/internal/app/motorbike/services"
+	_passHandler "motorbike-rental-backend/internal/app/pass/handlers"
+	_passService "motorbike-rental-backend/internal/app/pass/services"
+	_paymentHandler "motorbike-rental-backend/internal/app/payment/handlers"
+	_paymentService "motorbike-rental-backend/internal/app/payment/services"
+	_penaltyHandler "motorbike-rental-backend/internal/app/penalty/handlers"
+	_penaltyService "motorbike-rental-backend/internal/app/penalty/services"
+	_pricingHandler "motorbike-rental-backend/internal/app/pricing/handlers"
+	_pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	_promotionHandler "motorbike-rental-backend/internal/app/promotion/handlers"
+	_promotionService "motorbike-rental-backend/internal/app/promotion/services"
+	_reservationHandler "motorbike-rental-backend/internal/app/reservation/handlers"
+	_reservationService "motorbike-rental-backend/internal/app/reservation/services"
 	_rideHandler "motorbike-rental-backend/internal/app/ride/handlers"
 	_rideService "motorbike-rental-backend/internal/app/ride/services"
 	_baseHandler "motorbike-rental-backend/internal/app/user-and-auth/handlers"
 	_baseService "motorbike-rental-backend/internal/app/user-and-auth/services"
+	_zoneHandler "motorbike-rental-backend/internal/app/zone/handlers"
+	_zoneService "motorbike-rental-backend/internal/app/zone/services"
 	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/events"
+	"motorbike-rental-backend/pkg/log"
 	"motorbike-rental-backend/pkg/router"
+	"motorbike-rental-backend/pkg/storage"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type IdareRouter struct {
@@ -30,17 +61,158 @@ func (IdareRouter) RegisterRoutes(app *app.App) {
 	authService := _baseService.NewAuthService(app.DB, app.Cfg.Server.JwtSecret, app.Cfg.Server.JwtAccessTokenExpireMinute*time.Minute, app.Cfg.Server.JwtRefreshTokenExpireHour*time.Hour)
 	authHandler := _baseHandler.NewAuthHandler(authService, userService)
 
+	uploader := storage.NewUploader(app.Storage, app.Cfg.Storage.MaxUploadSize)
+
 	motorService := _motorService.NewMotorService(app.DB)
-	motorHandler := _motorHandler.NewMotorHandler(motorService)
+	motorHandler := _motorHandler.NewMotorHandler(motorService, uploader)
+
+	maintenanceService := _maintenanceService.NewMaintenanceService(app.DB)
+	maintenanceHandler := _maintenanceHandler.NewMaintenanceHandler(maintenanceService, motorService)
+	planService := _maintenanceService.NewPlanService(app.DB)
+	planHandler := _maintenanceHandler.NewPlanHandler(planService, app.Cfg.Maintenance.DueSoonThreshold)
 
 	mapService := _mapService.NewMapService(app.DB)
 	mapHandler := _mapHandler.NewMapHandler(mapService, motorService)
 
+	reservationService := _reservationService.NewReservationService(app.DB, app.Cfg.Reservation.HoldDuration)
+	reservationHandler := _reservationHandler.NewReservationHandler(reservationService)
+
+	deviceService := _deviceService.NewDeviceService(app.DB)
+
 	connService := _connService.NewConnService(app.DB)
-	connHandler := _connHandler.NewConnHandler(connService, motorService)
+	unlockTokenService := _connService.NewUnlockTokenService(app.DB, deviceService, app.Cfg.Device.UnlockTokenTTL)
+	connHandler := _connHandler.NewConnHandler(connService, motorService, reservationService, unlockTokenService)
+
+	pricingService := _pricingService.NewPricingService(app.DB, app.Cfg.Pricing.Timezone)
+	pricingHandler := _pricingHandler.NewPricingHandler(pricingService, motorService)
+
+	paymentProvider, err := _paymentService.NewProvider(app.Cfg.Payment.Provider, app.DB)
+	if err != nil {
+		panic(err)
+	}
+	// kart sağlayıcısı ayarlanmadıysa cüzdan yükleme rotası eklenmez
+	cardGateway, err := _paymentService.NewCardGateway(app.Cfg.Payment.CardGateway)
+	if err != nil {
+		panic(err)
+	}
+	paymentService := _paymentService.NewPaymentService(app.DB, paymentProvider, cardGateway)
+	paymentHandler := _paymentHandler.NewPaymentHandler(paymentService)
+
+	passService := _passService.NewPassService(app.DB, paymentService)
+	passHandler := _passHandler.NewPassHandler(passService)
+
+	invoiceService := _invoiceService.NewInvoiceService(app.DB, app.Cfg.Invoice.VATRate, app.Cfg.Pricing.Timezone)
+
+	promotionService := _promotionService.NewPromotionService(app.DB)
+	promotionHandler := _promotionHandler.NewPromotionHandler(promotionService)
+
+	zoneService := _zoneService.NewZoneService(app.DB)
+	zoneHandler := _zoneHandler.NewZoneHandler(zoneService)
+
+	penaltyService := _penaltyService.NewPenaltyService(app.DB, paymentService, zoneService, app.Cfg.Penalty.GracePeriod)
+
+	rideService := _rideService.NewRideService(app.DB, app.Cfg.Ride.MaxPause)
+	rideHandler := _rideHandler.NewRideHandler(rideService, motorService, connHandler, pricingService, zoneService, paymentService, invoiceService, promotionService, passService, penaltyService, uploader, app.Cfg.Payment.RideHoldAmount, app.Cfg.Penalty.BlockThreshold)
+	invoiceHandler := _invoiceHandler.NewInvoiceHandler(invoiceService, rideService)
+
+	disputeService := _disputeService.NewDisputeService(app.DB)
+	disputeHandler := _disputeHandler.NewDisputeHandler(disputeService, rideService, paymentService, uploader)
+
+	damageService := _damageService.NewDamageReportService(app.DB)
+	damageHandler := _damageHandler.NewDamageReportHandler(damageService, maintenanceService, uploader)
+
+	penaltyHandler := _penaltyHandler.NewPenaltyHandler(penaltyService, rideService)
+
+	commandTransport, err := _deviceService.NewTransport(app.Cfg.Device.Transport, app.Cfg.Device.SimulatedDelay)
+	if err != nil {
+		panic(err)
+	}
+	commandService := _deviceService.NewCommandService(app.DB, commandTransport, app.Events, app.Cfg.Device.CommandTimeout)
+	commandHandler := _deviceHandler.NewCommandHandler(commandService, rideService)
+
+	telemetryService := _deviceService.NewTelemetryService(app.DB, app.Events)
+	deviceHandler := _deviceHandler.NewDeviceHandler(deviceService, telemetryService)
+	deviceAPIHandler := _deviceHandler.NewDeviceAPIHandler(deviceService, telemetryService, commandService)
+
+	// cihaz kilit bildirdiğinde bitirme adımındaki sürüş bir sonraki adıma geçer
+	app.Events.Subscribe(events.BikeLocked, func(ctx context.Context, event events.Event) error {
+		return rideService.SyncWithLock(ctx, event.MotorbikeID, true)
+	})
+	app.Events.Subscribe(events.BikeUnlocked, func(ctx context.Context, event events.Event) error {
+		return rideService.SyncWithLock(ctx, event.MotorbikeID, false)
+	})
+
+	// süresi dolan rezervasyonları serbest bırakır
+	app.AddJob("reservation-expirer", app.Cfg.Reservation.ExpireInterval, func(ctx context.Context) error {
+		count, err := reservationService.ExpireReservations(ctx, time.Now())
+		if err == nil && count > 0 {
+			l := log.GetLogger("")
+			l.Info("Süresi dolan rezervasyonlar serbest bırakıldı", zap.Int("count", count))
+		}
+		return err
+	})
 
-	rideService := _rideService.NewRideService(app.DB)
-	rideHandler := _rideHandler.NewRideHandler(rideService, motorService, connHandler)
+	// azami süreyi aşan molaları bitirip sürüşü tam ücrete döndürür
+	app.AddJob("ride-pause-resumer", app.Cfg.Ride.PauseCheckInterval, func(ctx context.Context) error {
+		count, err := rideService.AutoResumePauses(ctx, time.Now())
+		if err == nil && count > 0 {
+			l := log.GetLogger("")
+			l.Info("Azami süreyi aşan molalar bitirildi", zap.Int("count", count))
+		}
+		return err
+	})
+
+	// kilitlenmeden, fotoğrafsız veya park yasağı olan yerde bırakılan sürüşler için ceza keser
+	app.AddJob("penalty-issuer", app.Cfg.Penalty.CheckInterval, func(ctx context.Context) error {
+		count, err := penaltyService.IssueAutomaticFines(ctx, time.Now())
+		if count > 0 {
+			l := log.GetLogger("")
+			l.Info("Otomatik cezalar kesildi", zap.Int("count", count))
+		}
+		return err
+	})
+
+	// süresi içinde cihazdan yanıt gelmeyen kilit komutlarını zaman aşımına uğratır
+	app.AddJob("device-command-timeout", app.Cfg.Device.CommandCheckInterval, func(ctx context.Context) error {
+		count, err := commandService.TimeoutCommands(ctx, time.Now())
+		if err == nil && count > 0 {
+			l := log.GetLogger("")
+			l.Info("Yanıtsız cihaz komutları zaman aşımına uğradı", zap.Int("count", count))
+		}
+		return err
+	})
+
+	// app çöktüğü için heartbeat göndermeyen bluetooth bağlantılarını kapatır, motor durumunu düzeltir
+	app.AddJob("connection-sweeper", app.Cfg.Connection.SweepInterval, func(ctx context.Context) error {
+		findings, err := connService.SweepStaleConnections(ctx, time.Now(), app.Cfg.Connection.HeartbeatTimeout)
+		if findings != nil && len(*findings) > 0 {
+			l := log.GetLogger("")
+			for _, finding := range *findings {
+				l.Info("Heartbeat gelmeyen bağlantı kapatıldı",
+					zap.Uint("connection_id", finding.ConnectionID),
+					zap.Uint("motorbike_id", finding.MotorbikeID),
+					zap.Time("last_heartbeat_at", finding.LastHeartbeatAt),
+					zap.String("motor_status_before", string(finding.MotorStatusBefore)),
+					zap.String("motor_status_after", string(finding.MotorStatusAfter)))
+			}
+		}
+		return err
+	})
+
+	// kullanımı bakım planının aralığını aşan motorlar için iş emri açar, motor 'maintenance' durumuna geçer
+	app.AddJob("maintenance-scheduler", app.Cfg.Maintenance.CheckInterval, func(ctx context.Context) error {
+		workOrders, err := planService.RunScheduler(ctx)
+		if workOrders != nil && len(*workOrders) > 0 {
+			l := log.GetLogger("")
+			for _, workOrder := range *workOrders {
+				l.Info("Periyodik bakım iş emri açıldı",
+					zap.Int64("work_order_id", workOrder.ID),
+					zap.Uint("motorbike_id", workOrder.MotorbikeID),
+					zap.Uint("plan_id", *workOrder.PlanID))
+			}
+		}
+		return err
+	})
 
 	api := app.FiberApp.Group("/api")
 
@@ -51,6 +223,20 @@ func (IdareRouter) RegisterRoutes(app *app.App) {
 	// admin panel login
 	router.Post(api, "/auth/admin/login", authHandler.LoginAdminPanel)
 
+	// device api: motor cihazları kullanıcı JWT'si yerine X-Device-Serial ve X-Device-Key başlıklarıyla doğrulanır
+	// Fiber grup middleware'ini yol önekiyle eşler, önek başka bir route'un (ör. /device/:id, /devices, /device-commands) başlangıcı olmamalı
+	deviceAPI := api.Group("/device-api", deviceAPIHandler.Authenticate)
+	router.Post(deviceAPI, "/heartbeat", deviceAPIHandler.Heartbeat)
+	router.Get(deviceAPI, "/commands", deviceAPIHandler.GetPendingCommands) // DEVICE_TRANSPORT=device-api iken bekleyen komutlar
+	router.Post(deviceAPI, "/commands/:id/result", deviceAPIHandler.ReportCommandResult)
+	router.Get(deviceAPI, "/revoked-tokens", connHandler.GetRevokedTokens) // çevrimdışı doğrulamada reddedilecek kilit açma token'ları
+
+	// yerel depodaki dosyalar imzalı adreslerle indirilir, S3'te ön imzalı adres doğrudan S3'e gider
+	if localStore, ok := app.Storage.(*storage.LocalStore); ok {
+		fileHandler := _fileHandler.NewFileHandler(localStore)
+		router.Get(api, "/files/*", fileHandler.Download)
+	}
+
 	api.Use(router.JWTMiddleware(app))
 
 	router.Get(api, "/user/me", userHandler.Me)
@@ -74,13 +260,57 @@ func (IdareRouter) RegisterRoutes(app *app.App) {
 	router.Post(adminRoutes, "/motorbike", motorHandler.CreateMotor)
 	router.Put(adminRoutes, "/motorbike/:id", motorHandler.UpdateMotor)
 	router.Delete(adminRoutes, "/motorbike/:id", motorHandler.DeleteMotor)
+	router.Post(adminRoutes, "/motorbike/:id/photo", motorHandler.UploadPhoto) // multipart: photo
+	router.Delete(adminRoutes, "/motorbike/:id/photo/:photoID", motorHandler.DeletePhoto)
 	router.Get(api, "/motorbikes", motorHandler.GetAllMotors)
+	router.Get(api, "/motorbikes/nearby", motorHandler.GetNearbyMotors) // /motorbikes/:id'den önce tanımlanmalı -> /motorbikes/nearby?lat=&lng=&radius_m=&limit=
+	router.Get(api, "/motorbikes/within", motorHandler.GetMotorsInBBox) // harita alanındaki motorlar -> /motorbikes/within?min_lat=&min_lng=&max_lat=&max_lng=
 	router.Get(api, "/motorbikes/:id", motorHandler.GetMotorByID)
 	router.Get(api, "/available-motorbikes", motorHandler.GetAvailableMotors)
-	router.Get(adminRoutes, "/maintenance-motorbikes", motorHandler.GetMaintenanceMotors)
+	router.Get(adminRoutes, "/maintenance-motorbikes", maintenanceHandler.GetMaintenanceMotors) // açık iş emirleriyle birlikte
 	router.Get(adminRoutes, "/rented-motorbikes", motorHandler.GetRentedMotors)
 	router.Get(adminRoutes, "/motorbike-photos/:id", motorHandler.GetPhotosByID)
 
+	// device command operations
+	router.Post(api, "/motorbikes/:id/unlock", commandHandler.UnlockMotor) // yalnızca motorda sürüşü devam eden kullanıcı
+	router.Post(api, "/motorbikes/:id/lock", commandHandler.LockMotor)
+	router.Get(api, "/device-commands/:id", commandHandler.GetCommand) // komut durumu: pending, acked, failed, timed_out
+	router.Get(adminRoutes, "/motorbikes/:id/commands", commandHandler.GetMotorCommands)
+	router.Post(adminRoutes, "/motorbike/:id/command", commandHandler.SendCommand) // {"type": "lock" | "unlock"}
+
+	// device operations
+	router.Get(adminRoutes, "/devices", deviceHandler.GetAllDevices)   // ?state=registered|installed|retired
+	router.Get(adminRoutes, "/devices/:id", deviceHandler.GetDevice)   // kurulum geçmişiyle birlikte
+	router.Post(adminRoutes, "/device", deviceHandler.CreateDevice)    // API ve eşleştirme anahtarları yalnızca bu yanıtta döner
+	router.Put(adminRoutes, "/device/:id", deviceHandler.UpdateDevice) // MAC adresi, yazılım sürümü
+	router.Put(adminRoutes, "/device/:id/install", deviceHandler.InstallDevice)
+	router.Put(adminRoutes, "/device/:id/uninstall", deviceHandler.UninstallDevice)
+	router.Put(adminRoutes, "/device/:id/swap", deviceHandler.SwapDevice) // motordaki cihazı depodaki yeni cihazla değiştirir
+	router.Put(adminRoutes, "/device/:id/retire", deviceHandler.RetireDevice)
+	router.Put(adminRoutes, "/device/:id/rotate-key", deviceHandler.RotateAPIKey)
+	router.Get(adminRoutes, "/motorbikes/:id/devices", deviceHandler.GetMotorInstallations) // motora takılan cihazların geçmişi
+	router.Get(adminRoutes, "/motorbikes/:id/telemetry", deviceHandler.GetMotorTelemetry)   // ?limit=100
+
+	// maintenance operations: açık iş emri olan motor 'maintenance' durumundadır, son iş emri kapanınca 'available' olur
+	router.Get(adminRoutes, "/work-orders", maintenanceHandler.GetWorkOrders) // ?status=open
+	router.Get(adminRoutes, "/work-orders/:id", maintenanceHandler.GetWorkOrder)
+	router.Get(adminRoutes, "/motorbikes/:id/work-orders", maintenanceHandler.GetMotorWorkOrders)
+	router.Get(adminRoutes, "/technicians/:id/work-orders", maintenanceHandler.GetTechnicianWorkOrders) // ?status=in_progress
+	router.Post(adminRoutes, "/work-order", maintenanceHandler.OpenWorkOrder)
+	router.Put(adminRoutes, "/work-order/:id", maintenanceHandler.UpdateWorkOrder)
+	router.Put(adminRoutes, "/work-order/:id/assign", maintenanceHandler.AssignTechnician)
+	router.Put(adminRoutes, "/work-order/:id/status", maintenanceHandler.UpdateWorkOrderStatus) // {"status": "in_progress" | "waiting_parts"}
+	router.Post(adminRoutes, "/work-order/:id/part", maintenanceHandler.AddPart)
+	router.Put(adminRoutes, "/work-order/:id/complete", maintenanceHandler.CompleteWorkOrder)
+	router.Put(adminRoutes, "/work-order/:id/cancel", maintenanceHandler.CancelWorkOrder)
+
+	// maintenance plan operations: motor modeline göre km/sürüş saati aralıklı periyodik bakım
+	router.Get(adminRoutes, "/maintenance-plans", planHandler.GetPlans)
+	router.Get(adminRoutes, "/maintenance-plans/due-soon", planHandler.GetDueSoon) // ?threshold=0.9
+	router.Post(adminRoutes, "/maintenance-plan", planHandler.CreatePlan)
+	router.Put(adminRoutes, "/maintenance-plan/:id", planHandler.UpdatePlan)
+	router.Delete(adminRoutes, "/maintenance-plan/:id", planHandler.DeletePlan)
+
 	// ride operations
 	router.Get(adminRoutes, "/rides", rideHandler.GetAllRides)
 	router.Get(adminRoutes, "/rides/:id", rideHandler.GetRideByID)
@@ -94,6 +324,91 @@ func (IdareRouter) RegisterRoutes(app *app.App) {
 	router.Get(api, "/rides/user/:userID/filter", rideHandler.GetRidesByUserAndDate) // userID ye göre belirli tarihler arasında getirir -> /rides/user/:userID/filter?start_time=2024-09-01&end_time=2024-09-09
 	router.Put(api, "/ride/finish/:id", rideHandler.FinishRide)
 	router.Post(api, "/ride/:id/photo", rideHandler.AddRidePhoto)
+	router.Put(api, "/ride/:id/pause", rideHandler.PauseRide)
+	router.Put(api, "/ride/:id/resume", rideHandler.ResumeRide)
+	router.Post(api, "/ride/:id/pay", rideHandler.PayRide)                // ödemesi alınamamış sürüş için tekrar tahsilat
+	router.Post(api, "/ride/:id/track", rideHandler.AddTrackPoints)       // sürüş sırasında GPS noktaları toplu gönderilir
+	router.Get(adminRoutes, "/rides/:id/track", rideHandler.GetRideTrack) // sürüş izi GeoJSON LineString olarak
+
+	// pricing operations
+	router.Get(api, "/pricing/quote", pricingHandler.GetQuote) // motor ve süreye göre tahmini ücret -> /pricing/quote?motorbike_id=3&minutes=25
+	router.Get(adminRoutes, "/pricing/tariffs", pricingHandler.GetAllTariffs)
+	router.Get(adminRoutes, "/pricing/tariffs/:id", pricingHandler.GetTariffByID)
+	router.Post(adminRoutes, "/pricing/tariff", pricingHandler.CreateTariff)
+	router.Put(adminRoutes, "/pricing/tariff/:id", pricingHandler.UpdateTariff)
+	router.Delete(adminRoutes, "/pricing/tariff/:id", pricingHandler.DeleteTariff)
+
+	// reservation operations
+	router.Post(api, "/reservations", reservationHandler.CreateReservation)
+	router.Put(api, "/reservations/:id/cancel", reservationHandler.CancelReservation)
+	router.Get(api, "/reservations/me", reservationHandler.GetMyReservations)
+	router.Get(adminRoutes, "/reservations", reservationHandler.GetAllReservations)
+
+	// dispute operations
+	router.Post(api, "/ride/:id/dispute", disputeHandler.OpenDispute) // multipart: reason, isteğe bağlı photo
+	router.Get(api, "/rides/:id/disputes", disputeHandler.GetRideDisputes)
+	router.Get(adminRoutes, "/disputes", disputeHandler.GetAllDisputes) // ?status=open
+	router.Put(adminRoutes, "/dispute/:id/approve", disputeHandler.ApproveDispute)
+	router.Put(adminRoutes, "/dispute/:id/reject", disputeHandler.RejectDispute)
+
+	// damage report operations: aynı motor için incelenmemiş bildirimler tek grupta toplanır ve birlikte sonuçlanır
+	router.Post(api, "/motorbikes/:id/damage-reports", damageHandler.CreateReport) // multipart: category, description, isteğe bağlı ride_id ve photos
+	router.Get(adminRoutes, "/motorbikes/:id/damage-reports", damageHandler.GetMotorReports)
+	router.Get(adminRoutes, "/damage-reports", damageHandler.GetReports) // ?status=new
+	router.Get(adminRoutes, "/damage-reports/:id", damageHandler.GetReport)
+	router.Put(adminRoutes, "/damage-report/:id/triage", damageHandler.TriageReport) // iş emri açar veya açık iş emrine bağlar
+	router.Put(adminRoutes, "/damage-report/:id/dismiss", damageHandler.DismissReport)
+	router.Get(adminRoutes, "/rides/:id/adjustments", rideHandler.GetRideAdjustments)
+
+	// invoice operations
+	router.Get(api, "/rides/:id/receipt", invoiceHandler.GetRideReceipt) // ?format=pdf ile PDF olarak
+
+	// payment operations
+	router.Get(api, "/wallet/me", paymentHandler.GetMyWallet)
+	if cardGateway != nil {
+		router.Post(api, "/wallet/topup", paymentHandler.TopUpWallet)
+	}
+	router.Get(adminRoutes, "/payments", paymentHandler.GetAllPayments)
+	router.Get(adminRoutes, "/rides/:id/payments", paymentHandler.GetPaymentsByRideID)
+	router.Post(adminRoutes, "/payments/:id/refund", paymentHandler.RefundPayment)
+
+	// promotion operations
+	router.Get(api, "/promotions/me", promotionHandler.GetMyPromotions)
+	router.Post(api, "/promotions/attach", promotionHandler.AttachPromotion) // kodu kullanıcının hesabına ekler
+	router.Get(adminRoutes, "/promotions", promotionHandler.GetAllPromotions)
+	router.Get(adminRoutes, "/promotions/:id", promotionHandler.GetPromotionByID)
+	router.Post(adminRoutes, "/promotion", promotionHandler.CreatePromotion)
+	router.Put(adminRoutes, "/promotion/:id", promotionHandler.UpdatePromotion)
+	router.Delete(adminRoutes, "/promotion/:id", promotionHandler.DeletePromotion)
+
+	// pass operations
+	router.Get(api, "/passes/products", passHandler.GetActiveProducts)
+	router.Get(api, "/passes/me", passHandler.GetMyPasses)
+	router.Post(api, "/passes/buy", passHandler.PurchasePass)
+	router.Get(adminRoutes, "/passes/holders", passHandler.GetPassHolders) // paket sahipleri -> /passes/holders?product_id=2&all=true
+	router.Get(adminRoutes, "/pass-products", passHandler.GetAllProducts)
+	router.Post(adminRoutes, "/pass-product", passHandler.CreateProduct)
+	router.Put(adminRoutes, "/pass-product/:id", passHandler.UpdateProduct)
+	router.Delete(adminRoutes, "/pass-product/:id", passHandler.DeleteProduct)
+
+	// penalty operations
+	router.Get(api, "/fines/me", penaltyHandler.GetMyFines) // ?status=unpaid
+	router.Post(api, "/fines/:id/pay", penaltyHandler.PayFine)
+	router.Get(adminRoutes, "/fines", penaltyHandler.GetAllFines) // ?status=unpaid&user_id=5
+	router.Post(adminRoutes, "/fine", penaltyHandler.IssueFine)
+	router.Put(adminRoutes, "/fine/:id/waive", penaltyHandler.WaiveFine)
+	router.Get(adminRoutes, "/penalty-types", penaltyHandler.GetAllPenaltyTypes)
+	router.Post(adminRoutes, "/penalty-type", penaltyHandler.CreatePenaltyType)
+	router.Put(adminRoutes, "/penalty-type/:id", penaltyHandler.UpdatePenaltyType)
+	router.Delete(adminRoutes, "/penalty-type/:id", penaltyHandler.DeletePenaltyType)
+
+	// zone operations
+	router.Get(api, "/zones/geojson", zoneHandler.GetZonesGeoJSON) // aktif bölgeler harita için GeoJSON FeatureCollection olarak
+	router.Get(adminRoutes, "/zones", zoneHandler.GetAllZones)
+	router.Get(adminRoutes, "/zones/:id", zoneHandler.GetZoneByID)
+	router.Post(adminRoutes, "/zone", zoneHandler.CreateZone)
+	router.Put(adminRoutes, "/zone/:id", zoneHandler.UpdateZone)
+	router.Delete(adminRoutes, "/zone/:id", zoneHandler.DeleteZone)
 
 	// map operations
 	router.Post(adminRoutes, "/map", mapHandler.CreateMap)
@@ -106,15 +421,18 @@ func (IdareRouter) RegisterRoutes(app *app.App) {
 
 	// bluetooth connection operations
 	router.Get(adminRoutes, "/connections", connHandler.GetAllConnections)
+	router.Get(adminRoutes, "/connections/stale-report", connHandler.GetStaleConnectionReport) // /connections/:id'den önce tanımlı olmalı
 	router.Get(api, "/connections/:id", connHandler.GetConnByID)
 	router.Get(adminRoutes, "/connection/motorbike/:motorbikeID", connHandler.GetConnByMotorID)
 	router.Get(api, "/connection/user/:userID", connHandler.GetConnByUserID)
-	router.Post(api, "/connection/connect", connHandler.Connect) // connect
+	router.Post(api, "/connection/connect", connHandler.Connect) // connect, sürüş sırasında yeniden bağlanırken çevrimdışı kilit açma token'ı da döner (ilk token POST /ride ile gelir)
+	router.Put(api, "/connection/:id/revoke-tokens", connHandler.RevokeUnlockTokens)
+	router.Put(api, "/connection/:id/heartbeat", connHandler.Heartbeat) // CONNECTION_HEARTBEAT_TIMEOUT içinde gelmezse bağlantı kapatılır
 	router.Delete(adminRoutes, "/connection/:id", connHandler.DeleteConn)
 	// router.Post(adminRoutes, "/connection/disconnect/:id", connHandler.Disconnect) // disconnect
 }
 
 // Sürüşü bitirme işlem süreci:
-// önce kullanıcı motoru kitleyecek, (burada özel bir işlem yapılmalı, kitlendiğinde bana bilgi gelmesi gerekiyor! Burada bluetooth cihazı bana info atacak!)
-// daha sonra kullanıcı fotoğrafı yükleyecek, disconnect fonksiyonu çalışacak (motor available olacak)
-// daha sonra finishRide fonksiyonuna istek atılacak! Ve burada işlem ücreti çıkacak! Buradan payment fonksiyonuna istek atılıp para alınacak!
+// önce kullanıcı motoru kitleyecek, (cihaz kilitlendiğini /api/device-api/heartbeat veya komut sonucuyla bildirir, sürüş fotoğraf adımına geçer)
+// daha sonra kullanıcı fotoğrafı yükleyecek, disconnect fonksiyonu çalışacak (bağlantı kapanır, motor sürüş bitene kadar kullanıcıda kalır)
+// daha sonra finishRide fonksiyonuna istek atılacak! Motor burada available olacak. Ve burada işlem ücreti çıkacak ve ödeme sağlayıcısından tahsil edilecek (internal/app/payment)!
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"motorbike-rental-backend/pkg/geo"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// commandMode simüle cihazın kilit komutlarına nasıl yanıt vereceği
+type commandMode string
+
+const (
+	commandsNormal commandMode = "normal" // komutu uygular ve başarılı bildirir
+	commandsIgnore commandMode = "ignore" // yanıt vermez, komut sunucuda zaman aşımına uğrar
+	commandsFail   commandMode = "fail"   // komutu uygulayamadığını bildirir
+)
+
+const (
+	metersPerDegree = 111320.0
+	minSpeedKmh     = 12.0
+	maxSpeedKmh     = 28.0
+)
+
+type deviceCredentials struct {
+	Serial      string `json:"serial"`
+	APIKey      string `json:"api_key"`
+	MotorbikeID int    `json:"motorbike_id"`
+}
+
+func (c deviceCredentials) headers() map[string]string {
+	return map[string]string{"X-Device-Serial": c.Serial, "X-Device-Key": c.APIKey}
+}
+
+// bike tek bir simüle motor. Kilidi açıkken rastgele bir rotada ilerler, bataryası azalır;
+// her adımda heartbeat gönderir ve bekleyen kilit komutlarına yanıt verir.
+type bike struct {
+	mu     sync.Mutex
+	index  int
+	creds  deviceCredentials
+	client *apiClient
+	log    *zap.Logger
+	rnd    *rand.Rand
+
+	locked     bool
+	moving     bool
+	offline    bool
+	mode       commandMode
+	battery    float64
+	odometerKm float64
+	lat, lng   float64
+	heading    float64 // derece, 0 kuzey
+	speedKmh   float64
+	seen       map[int64]bool // ignore modunda görülen komutlar, tekrar loglanmaz
+	rideID     int            // senaryodaki start adımıyla başlatılan sürüş
+}
+
+func newBike(index int, creds deviceCredentials, info motorbikeInfo, client *apiClient, logger *zap.Logger, seed int64) *bike {
+	rnd := rand.New(rand.NewSource(seed + int64(index)))
+	return &bike{
+		index:    index,
+		creds:    creds,
+		client:   client,
+		log:      logger.With(zap.Int("bike", index), zap.Int("motorbike_id", creds.MotorbikeID)),
+		rnd:      rnd,
+		locked:   info.LockStatus != "unlocked",
+		mode:     commandsNormal,
+		battery:  60 + rnd.Float64()*40,
+		lat:      info.LocationLatitude,
+		lng:      info.LocationLongitude,
+		heading:  rnd.Float64() * 360,
+		speedKmh: minSpeedKmh + rnd.Float64()*(maxSpeedKmh-minSpeedKmh),
+		seen:     map[int64]bool{},
+	}
+}
+
+// run her interval'de bir adım ilerler, ctx iptal edilene kadar çalışır
+func (b *bike) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		b.step(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *bike) step(ctx context.Context, elapsed time.Duration) {
+	b.mu.Lock()
+	b.advance(elapsed)
+	offline := b.offline
+	hb := b.heartbeat()
+	b.mu.Unlock()
+
+	// çevrimdışı motor ilerlemeye devam eder ama sunucuyla konuşmaz
+	if offline {
+		return
+	}
+
+	if err := b.client.Heartbeat(ctx, b.creds, hb); err != nil {
+		b.log.Warn("heartbeat gönderilemedi", zap.Error(err))
+	}
+
+	commands, err := b.client.PendingCommands(ctx, b.creds)
+	if err != nil {
+		b.log.Warn("komutlar alınamadı", zap.Error(err))
+		return
+	}
+	for _, command := range commands {
+		b.handleCommand(ctx, command)
+	}
+}
+
+// advance kilidi açık ve hareket halindeki motoru rastgele bir rotada ilerletir
+func (b *bike) advance(elapsed time.Duration) {
+	if b.locked || !b.moving || b.battery <= 0 {
+		return
+	}
+
+	// ara sıra yön ve hız değişir
+	if b.rnd.Float64() < 0.2 {
+		b.heading = math.Mod(b.heading+b.rnd.NormFloat64()*45+360, 360)
+		b.speedKmh = minSpeedKmh + b.rnd.Float64()*(maxSpeedKmh-minSpeedKmh)
+	}
+
+	meters := b.speedKmh * 1000 * elapsed.Hours()
+	rad := b.heading * math.Pi / 180
+	lat := b.lat + meters*math.Cos(rad)/metersPerDegree
+	lng := b.lng + meters*math.Sin(rad)/(metersPerDegree*math.Cos(b.lat*math.Pi/180))
+
+	distance := geo.Haversine(b.lat, b.lng, lat, lng)
+	b.lat, b.lng = lat, lng
+	b.odometerKm += distance / 1000
+	b.battery = math.Max(0, b.battery-distance/1000*0.8) // km başına %0.8
+}
+
+func (b *bike) heartbeat() heartbeat {
+	battery, odometer, lat, lng := round(b.battery, 1), round(b.odometerKm, 3), b.lat, b.lng
+	return heartbeat{
+		LockStatus:   lockStatus(b.locked),
+		BatteryLevel: &battery,
+		OdometerKm:   &odometer,
+		Latitude:     &lat,
+		Longitude:    &lng,
+	}
+}
+
+func (b *bike) handleCommand(ctx context.Context, command deviceCommand) {
+	b.mu.Lock()
+	mode := b.mode
+	b.mu.Unlock()
+
+	switch mode {
+	case commandsIgnore:
+		if !b.seen[command.ID] {
+			b.seen[command.ID] = true
+			b.log.Info("komut yok sayıldı", zap.Int64("command_id", command.ID), zap.String("type", command.Type))
+		}
+		return
+	case commandsFail:
+		result := commandResult{Success: false, Error: "bikesim: simüle edilen arıza"}
+		if err := b.client.ReportResult(ctx, b.creds, command.ID, result); err != nil {
+			b.log.Warn("komut sonucu bildirilemedi", zap.Int64("command_id", command.ID), zap.Error(err))
+			return
+		}
+		b.log.Info("komut başarısız bildirildi", zap.Int64("command_id", command.ID), zap.String("type", command.Type))
+		return
+	}
+
+	b.mu.Lock()
+	b.setLocked(command.Type == "lock")
+	status := lockStatus(b.locked)
+	b.mu.Unlock()
+
+	if err := b.client.ReportResult(ctx, b.creds, command.ID, commandResult{Success: true, LockStatus: status}); err != nil {
+		b.log.Warn("komut sonucu bildirilemedi", zap.Int64("command_id", command.ID), zap.Error(err))
+		return
+	}
+	b.log.Info("komut uygulandı", zap.Int64("command_id", command.ID), zap.String("type", command.Type))
+}
+
+// setLocked kilidi değiştirir, kilit açılınca motor sürülmeye başlar, kilitlenince durur
+func (b *bike) setLocked(locked bool) {
+	b.locked = locked
+	b.moving = !locked
+}
+
+func lockStatus(locked bool) string {
+	if locked {
+		return "locked"
+	}
+	return "unlocked"
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiClient simülatörün kullandığı API çağrıları. Admin ve sürücü çağrıları JWT ile, cihaz çağrıları cihaz anahtarıyla yapılır.
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+}
+
+type apiError struct {
+	Status int
+	Body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("api hatası: %d %s", e.Status, e.Body)
+}
+
+type motorbikeInfo struct {
+	ID                int     `json:"id"`
+	LocationLatitude  float64 `json:"location_latitude"`
+	LocationLongitude float64 `json:"location_longitude"`
+	LockStatus        string  `json:"lock_status"`
+}
+
+type deviceCommand struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type heartbeat struct {
+	LockStatus   string   `json:"lock_status"`
+	BatteryLevel *float64 `json:"battery_level,omitempty"`
+	OdometerKm   *float64 `json:"odometer_km,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+}
+
+type commandResult struct {
+	Success    bool   `json:"success"`
+	LockStatus string `json:"lock_status,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newAPIClient(baseURL string) *apiClient {
+	return &apiClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Login admin olarak giriş yapar, cihaz kaydı için gerekir
+func (c *apiClient) Login(ctx context.Context, email, password string) error {
+	return c.login(ctx, "/auth/admin/login", email, password)
+}
+
+// RiderLogin test kullanıcısı olarak giriş yapar, senaryodaki sürücü eylemleri için gerekir
+func (c *apiClient) RiderLogin(ctx context.Context, email, password string) error {
+	return c.login(ctx, "/auth/login", email, password)
+}
+
+func (c *apiClient) login(ctx context.Context, path, email, password string) error {
+	var tokens struct {
+		AccessToken string `json:"access_token"`
+	}
+	body := map[string]string{"email": email, "password": password}
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &tokens); err != nil {
+		return err
+	}
+	if tokens.AccessToken == "" {
+		return fmt.Errorf("giriş yanıtında access_token yok")
+	}
+
+	c.token = tokens.AccessToken
+	return nil
+}
+
+func (c *apiClient) Motorbikes(ctx context.Context) ([]motorbikeInfo, error) {
+	var motorbikes []motorbikeInfo
+	err := c.do(ctx, http.MethodGet, "/motorbikes", c.authHeaders(), nil, &motorbikes)
+	return motorbikes, err
+}
+
+// ProvisionDevice motora simüle bir cihaz kaydeder ve API anahtarını döner
+func (c *apiClient) ProvisionDevice(ctx context.Context, serial string, motorbikeID int) (string, error) {
+	var response struct {
+		APIKey string `json:"api_key"`
+	}
+	body := map[string]interface{}{"serial": serial, "firmware_version": "bikesim", "motorbike_id": motorbikeID}
+	if err := c.do(ctx, http.MethodPost, "/device", c.authHeaders(), body, &response); err != nil {
+		return "", err
+	}
+
+	return response.APIKey, nil
+}
+
+func (c *apiClient) Heartbeat(ctx context.Context, creds deviceCredentials, hb heartbeat) error {
+	return c.do(ctx, http.MethodPost, "/device-api/heartbeat", creds.headers(), hb, nil)
+}
+
+func (c *apiClient) PendingCommands(ctx context.Context, creds deviceCredentials) ([]deviceCommand, error) {
+	var commands []deviceCommand
+	err := c.do(ctx, http.MethodGet, "/device-api/commands", creds.headers(), nil, &commands)
+	return commands, err
+}
+
+func (c *apiClient) ReportResult(ctx context.Context, creds deviceCredentials, commandID int64, result commandResult) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/device-api/commands/%d/result", commandID), creds.headers(), result, nil)
+}
+
+// Connect sürücü olarak motora bluetooth bağlantısı açar
+func (c *apiClient) Connect(ctx context.Context, motorbikeID int) error {
+	body := map[string]int{"motorbike_id": motorbikeID}
+	return c.do(ctx, http.MethodPost, "/connection/connect", c.authHeaders(), body, nil)
+}
+
+// StartRide sürüş başlatır, sürüş id'sini ve çevrimdışı kilit açma token'ı verilip verilmediğini döner
+func (c *apiClient) StartRide(ctx context.Context, motorbikeID int) (int, bool, error) {
+	var response struct {
+		RideID      int             `json:"ride_id"`
+		UnlockToken json.RawMessage `json:"unlock_token"`
+	}
+	body := map[string]int{"motorbike_id": motorbikeID}
+	if err := c.do(ctx, http.MethodPost, "/ride", c.authHeaders(), body, &response); err != nil {
+		return 0, false, err
+	}
+
+	hasToken := len(response.UnlockToken) > 0 && string(response.UnlockToken) != "null"
+	return response.RideID, hasToken, nil
+}
+
+// UnlockBike / LockBike sürücü olarak motora kilit komutu gönderir, komutu simüle cihaz uygular
+func (c *apiClient) UnlockBike(ctx context.Context, motorbikeID int) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/motorbikes/%d/unlock", motorbikeID), c.authHeaders(), nil, nil)
+}
+
+func (c *apiClient) LockBike(ctx context.Context, motorbikeID int) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/motorbikes/%d/lock", motorbikeID), c.authHeaders(), nil, nil)
+}
+
+// UploadPhoto sürüş bitiş fotoğrafı olarak üretilmiş küçük bir PNG yükler
+func (c *apiClient) UploadPhoto(ctx context.Context, rideID int) error {
+	var payload bytes.Buffer
+	form := multipart.NewWriter(&payload)
+	part, err := form.CreateFormFile("photo", fmt.Sprintf("bikesim-%d.png", rideID))
+	if err != nil {
+		return err
+	}
+	if err = png.Encode(part, placeholderPhoto()); err != nil {
+		return err
+	}
+	if err = form.Close(); err != nil {
+		return err
+	}
+
+	return c.send(ctx, http.MethodPost, fmt.Sprintf("/ride/%d/photo", rideID), c.authHeaders(), form.FormDataContentType(), &payload, nil)
+}
+
+func (c *apiClient) FinishRide(ctx context.Context, rideID int) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/ride/finish/%d", rideID), c.authHeaders(), nil, nil)
+}
+
+func (c *apiClient) authHeaders() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + c.token}
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, headers map[string]string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	return c.send(ctx, method, path, headers, "application/json", reader, out)
+}
+
+func (c *apiClient) send(ctx context.Context, method, path string, headers map[string]string, contentType string, body io.Reader, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return &apiError{Status: resp.StatusCode, Body: strings.TrimSpace(string(raw))}
+	}
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(unwrapData(raw), out)
+}
+
+// unwrapData SuccessResponse ile dönen yanıtlarda asıl veriyi "data" alanından çıkarır
+func unwrapData(raw []byte) []byte {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return raw
+	}
+	if data, ok := envelope["data"]; ok {
+		return data
+	}
+	return raw
+}
+
+// placeholderPhoto sunucunun içerik türü kontrolünden geçen tek renkli görsel
+func placeholderPhoto() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, color.RGBA{R: 40, G: 120, B: 200, A: 255})
+		}
+	}
+	return img
+}
@@ -0,0 +1,203 @@
+// bikesim donanım olmadan uçtan uca test için motor filosu simülatörü.
+//
+// Simülatör admin olarak giriş yapar, ilk N motora simüle cihaz kaydeder (anahtarlar -state dosyasında
+// saklanır, sonraki çalıştırmalarda aynı cihazlar kullanılır) ve her motor için cihaz API'si üzerinden
+// heartbeat gönderir, kilit komutlarına yanıt verir. Komutların simülatöre ulaşması için sunucu
+// DEVICE_TRANSPORT=device-api ile çalışmalıdır.
+//
+// Senaryoda sürücü eylemleri (connect, start, unlock-bike, lock-bike, photo, finish) varsa simülatör ayrıca
+// -rider-email ile test kullanıcısı olarak giriş yapar ve bağlantı, sürüş ve fotoğraf isteklerini bu kullanıcıyla atar.
+//
+//	go run ./cmd/bikesim -email admin@example.com -password secret -bikes 5
+//	go run ./cmd/bikesim -email admin@example.com -password secret -rider-email rider@example.com -rider-password secret \
+//		-scenario cmd/bikesim/scenarios/offline-mid-ride.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	apiURL := flag.String("api", "http://localhost:3003/api", "API adresi")
+	email := flag.String("email", os.Getenv("BIKESIM_ADMIN_EMAIL"), "admin e-postası")
+	password := flag.String("password", os.Getenv("BIKESIM_ADMIN_PASSWORD"), "admin parolası")
+	riderEmail := flag.String("rider-email", os.Getenv("BIKESIM_RIDER_EMAIL"), "senaryodaki sürücü eylemleri için test kullanıcısının e-postası")
+	riderPassword := flag.String("rider-password", os.Getenv("BIKESIM_RIDER_PASSWORD"), "test kullanıcısının parolası")
+	bikes := flag.Int("bikes", 0, "simüle edilecek motor sayısı (varsayılan senaryodaki sayı veya 3)")
+	scenarioPath := flag.String("scenario", "", "senaryo dosyası (JSON)")
+	interval := flag.Duration("interval", 5*time.Second, "heartbeat ve komut sorgulama aralığı")
+	statePath := flag.String("state", "bikesim-state.json", "simüle cihaz anahtarlarının saklandığı dosya")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "rastgele rota üretimi için tohum, aynı tohum aynı rotaları verir")
+	flag.Parse()
+
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+
+	if err := run(*apiURL, *email, *password, *riderEmail, *riderPassword, *bikes, *scenarioPath, *interval, *statePath, *seed, logger); err != nil {
+		logger.Fatal("simülatör durdu", zap.Error(err))
+	}
+}
+
+func run(apiURL, email, password, riderEmail, riderPassword string, bikes int, scenarioPath string, interval time.Duration, statePath string, seed int64, logger *zap.Logger) error {
+	scenario := &Scenario{Name: "serbest"}
+	if scenarioPath != "" {
+		var err error
+		if scenario, err = loadScenario(scenarioPath); err != nil {
+			return err
+		}
+	}
+
+	if bikes <= 0 {
+		bikes = scenario.Bikes
+	}
+	if bikes <= 0 {
+		bikes = 3
+	}
+	for i, step := range scenario.Steps {
+		if step.Bike != nil && (*step.Bike < 0 || *step.Bike >= bikes) {
+			return fmt.Errorf("adım %d: bike %d filoda yok (%d motor)", i, *step.Bike, bikes)
+		}
+	}
+	if scenario.needsRider() && riderEmail == "" {
+		return fmt.Errorf("senaryoda sürücü eylemleri var, -rider-email ve -rider-password verilmeli")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if scenario.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(scenario.Duration))
+		defer cancel()
+	}
+
+	client := newAPIClient(apiURL)
+	if err := client.Login(ctx, email, password); err != nil {
+		return fmt.Errorf("admin girişi: %w", err)
+	}
+
+	var rider *apiClient
+	if scenario.needsRider() {
+		rider = newAPIClient(apiURL)
+		if err := rider.RiderLogin(ctx, riderEmail, riderPassword); err != nil {
+			return fmt.Errorf("sürücü girişi: %w", err)
+		}
+	}
+
+	fleet, err := register(ctx, client, bikes, statePath, seed, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("simülasyon başladı", zap.String("scenario", scenario.Name), zap.Int("bikes", len(fleet)), zap.Int64("seed", seed))
+
+	var wg sync.WaitGroup
+	for _, b := range fleet {
+		wg.Add(1)
+		go func(b *bike) {
+			defer wg.Done()
+			b.run(ctx, interval)
+		}(b)
+	}
+	scenario.play(ctx, fleet, rider, logger)
+
+	wg.Wait()
+	logger.Info("simülasyon bitti")
+	return nil
+}
+
+// register ilk N motoru seçer, daha önce cihaz kaydedilenler önceliklidir; cihazı olmayanlara yeni cihaz kaydeder
+func register(ctx context.Context, client *apiClient, count int, statePath string, seed int64, logger *zap.Logger) ([]*bike, error) {
+	state, err := loadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	motorbikes, err := client.Motorbikes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("motorlar getirilemedi: %w", err)
+	}
+	if len(motorbikes) < count {
+		return nil, fmt.Errorf("%d motor istendi, sistemde %d motor var", count, len(motorbikes))
+	}
+
+	sort.SliceStable(motorbikes, func(i, j int) bool {
+		_, iKnown := state[motorbikes[i].ID]
+		_, jKnown := state[motorbikes[j].ID]
+		return iKnown && !jKnown
+	})
+
+	fleet := make([]*bike, 0, count)
+	for i, info := range motorbikes[:count] {
+		creds, ok := state[info.ID]
+		if !ok {
+			creds = deviceCredentials{Serial: fmt.Sprintf("SIM-%04d", info.ID), MotorbikeID: info.ID}
+			if creds.APIKey, err = client.ProvisionDevice(ctx, creds.Serial, info.ID); err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.Status == 409 {
+					return nil, fmt.Errorf("motor %d için cihaz kaydedilemedi (motorda başka cihaz var veya %s kayıtlı, -state dosyasını kontrol edin): %w", info.ID, creds.Serial, err)
+				}
+				return nil, fmt.Errorf("motor %d için cihaz kaydedilemedi: %w", info.ID, err)
+			}
+
+			state[info.ID] = creds
+			if err = saveState(statePath, state); err != nil {
+				return nil, err
+			}
+			logger.Info("simüle cihaz kaydedildi", zap.Int("motorbike_id", info.ID), zap.String("serial", creds.Serial))
+		}
+
+		fleet = append(fleet, newBike(i, creds, info, client, logger, seed))
+	}
+
+	return fleet, nil
+}
+
+func loadState(path string) (map[int]deviceCredentials, error) {
+	state := map[int]deviceCredentials{}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []deviceCredentials
+	if err = json.Unmarshal(raw, &devices); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, device := range devices {
+		state[device.MotorbikeID] = device
+	}
+
+	return state, nil
+}
+
+func saveState(path string, state map[int]deviceCredentials) error {
+	devices := make([]deviceCredentials, 0, len(state))
+	for _, device := range state {
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].MotorbikeID < devices[j].MotorbikeID })
+
+	raw, err := json.MarshalIndent(devices, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// dosyada cihaz API anahtarları var
+	return os.WriteFile(path, raw, 0600)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Scenario QA'nın tekrar oynatabileceği olay listesi. Adımlar simülasyon başladıktan "at" kadar sonra uygulanır.
+//
+//	{
+//	  "name": "offline-mid-ride",
+//	  "bikes": 1,
+//	  "duration": "10m",
+//	  "steps": [
+//	    {"at": "30s", "bike": 0, "action": "offline"},
+//	    {"at": "3m", "bike": 0, "action": "online"}
+//	  ]
+//	}
+type Scenario struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Bikes       int      `json:"bikes"`    // -bikes verilmezse kullanılır
+	Duration    Duration `json:"duration"` // boşsa Ctrl+C'ye kadar çalışır
+	Steps       []Step   `json:"steps"`
+}
+
+// Step tek bir senaryo adımı. Bike boşsa adım tüm motorlara uygulanır.
+//
+// Eylemler:
+//   - offline / online: motor sunucuyla konuşmayı keser / sürdürür (hareket etmeye devam eder)
+//   - lock / unlock: kilit elle kilitlenir / açılır, sunucu durumu heartbeat ile öğrenir
+//   - move / stop: kilidi açık motor hareket etmeye başlar / durur
+//   - battery: batarya seviyesi value olarak ayarlanır
+//   - commands: kilit komutlarına yanıt biçimi mode olarak ayarlanır (normal, ignore, fail)
+//
+// Sürücü eylemleri test kullanıcısıyla API'ye istek atar, bike zorunludur:
+//   - connect: motora bluetooth bağlantısı açılır
+//   - start: sürüş başlatılır, sürüş id'si sonraki adımlar için saklanır
+//   - unlock-bike / lock-bike: sürücü motora kilit komutu gönderir
+//   - photo: sürüş bitiş fotoğrafı yüklenir
+//   - finish: sürüş bitirilir
+type Step struct {
+	At     Duration `json:"at"`
+	Bike   *int     `json:"bike"`
+	Action string   `json:"action"`
+	Value  float64  `json:"value"`
+	Mode   string   `json:"mode"`
+}
+
+// Duration JSON'da "30s", "2m" gibi yazılan süre
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func loadScenario(path string) (*Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenario Scenario
+	if err = json.Unmarshal(raw, &scenario); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i, step := range scenario.Steps {
+		if err = step.validate(); err != nil {
+			return nil, fmt.Errorf("%s: adım %d: %w", path, i, err)
+		}
+	}
+
+	sort.SliceStable(scenario.Steps, func(i, j int) bool { return scenario.Steps[i].At < scenario.Steps[j].At })
+	return &scenario, nil
+}
+
+// riderActions test kullanıcısıyla API'ye istek atan eylemler
+var riderActions = map[string]bool{
+	"connect":     true,
+	"start":       true,
+	"unlock-bike": true,
+	"lock-bike":   true,
+	"photo":       true,
+	"finish":      true,
+}
+
+// needsRider senaryoda sürücü girişi gerektiren adım varsa true döner
+func (s *Scenario) needsRider() bool {
+	for _, step := range s.Steps {
+		if riderActions[step.Action] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Step) validate() error {
+	if riderActions[s.Action] {
+		if s.Bike == nil {
+			return fmt.Errorf("%s eylemi için bike zorunlu", s.Action)
+		}
+		return nil
+	}
+
+	switch s.Action {
+	case "offline", "online", "lock", "unlock", "move", "stop":
+		return nil
+	case "battery":
+		if s.Value < 0 || s.Value > 100 {
+			return fmt.Errorf("battery 0 ile 100 arasında olmalı")
+		}
+		return nil
+	case "commands":
+		switch commandMode(s.Mode) {
+		case commandsNormal, commandsIgnore, commandsFail:
+			return nil
+		}
+		return fmt.Errorf("geçersiz komut modu: %q", s.Mode)
+	default:
+		return fmt.Errorf("bilinmeyen eylem: %q", s.Action)
+	}
+}
+
+// play adımları zamanı geldikçe uygular. Sürücü eylemleri rider ile yapılır, senaryoda sürücü eylemi yoksa rider nil olabilir.
+func (s *Scenario) play(ctx context.Context, fleet []*bike, rider *apiClient, logger *zap.Logger) {
+	start := time.Now()
+
+	for _, step := range s.Steps {
+		wait := time.Until(start.Add(time.Duration(step.At)))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		for _, b := range fleet {
+			if step.Bike != nil && *step.Bike != b.index {
+				continue
+			}
+			if riderActions[step.Action] {
+				b.ride(ctx, rider, step)
+				continue
+			}
+			b.apply(step)
+		}
+
+		fields := []zap.Field{zap.String("action", step.Action), zap.Duration("at", time.Duration(step.At))}
+		if step.Bike != nil {
+			fields = append(fields, zap.Int("bike", *step.Bike))
+		}
+		logger.Info("senaryo adımı uygulandı", fields...)
+	}
+}
+
+func (b *bike) apply(step Step) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch step.Action {
+	case "offline":
+		b.offline = true
+	case "online":
+		b.offline = false
+	case "lock":
+		b.setLocked(true)
+	case "unlock":
+		b.setLocked(false)
+	case "move":
+		b.moving = !b.locked
+	case "stop":
+		b.moving = false
+	case "battery":
+		b.battery = step.Value
+	case "commands":
+		b.mode = commandMode(step.Mode)
+	}
+}
+
+// ride sürücü eylemini API üzerinden yapar. İstek sırasında b.mu tutulmaz, cihaz döngüsü kilit komutlarına yanıt verebilir.
+// API'nin reddettiği eylemler (ör. kilitlenmemiş motorla sürüş bitirme) senaryonun beklenen sonucu olabilir, loglanıp geçilir.
+func (b *bike) ride(ctx context.Context, rider *apiClient, step Step) {
+	b.mu.Lock()
+	rideID := b.rideID
+	b.mu.Unlock()
+
+	motorbikeID := b.creds.MotorbikeID
+	var err error
+	switch step.Action {
+	case "connect":
+		err = rider.Connect(ctx, motorbikeID)
+	case "start":
+		var hasToken bool
+		if rideID, hasToken, err = rider.StartRide(ctx, motorbikeID); err == nil {
+			b.mu.Lock()
+			b.rideID = rideID
+			b.mu.Unlock()
+			b.log.Info("sürüş başlatıldı", zap.Int("ride_id", rideID), zap.Bool("unlock_token", hasToken))
+		}
+	case "unlock-bike":
+		err = rider.UnlockBike(ctx, motorbikeID)
+	case "lock-bike":
+		err = rider.LockBike(ctx, motorbikeID)
+	case "photo", "finish":
+		if rideID == 0 {
+			b.log.Warn("motorda simülatörün başlattığı sürüş yok", zap.String("action", step.Action))
+			return
+		}
+		if step.Action == "photo" {
+			err = rider.UploadPhoto(ctx, rideID)
+		} else {
+			err = rider.FinishRide(ctx, rideID)
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		b.log.Warn("sürücü eylemi reddedildi", zap.String("action", step.Action), zap.Int("status", apiErr.Status), zap.String("body", apiErr.Body))
+		return
+	}
+	b.log.Warn("sürücü eylemi yapılamadı", zap.String("action", step.Action), zap.Error(err))
+}
@@ -5,8 +5,10 @@ import (
 	"gorm.io/gorm"
 	connService "motorbike-rental-backend/internal/app/bluetooth-connection/services"
 	"motorbike-rental-backend/internal/app/bluetooth-connection/viewmodels"
+	deviceModel "motorbike-rental-backend/internal/app/device/models"
 	motorModel "motorbike-rental-backend/internal/app/motorbike/models"
 	motorService "motorbike-rental-backend/internal/app/motorbike/services"
+	reservationService "motorbike-rental-backend/internal/app/reservation/services"
 	"motorbike-rental-backend/pkg/app"
 	"motorbike-rental-backend/pkg/errorsx"
 	"motorbike-rental-backend/pkg/utils"
@@ -15,12 +17,14 @@ import (
 )
 
 type ConnHandler struct {
-	connService  connService.IConnService
-	motorService motorService.IMotorService
+	connService        connService.IConnService
+	motorService       motorService.IMotorService
+	reservationService reservationService.IReservationService
+	tokenService       connService.IUnlockTokenService
 }
 
-func NewConnHandler(s connService.IConnService, m motorService.IMotorService) ConnHandler {
-	return ConnHandler{connService: s, motorService: m}
+func NewConnHandler(s connService.IConnService, m motorService.IMotorService, r reservationService.IReservationService, t connService.IUnlockTokenService) ConnHandler {
+	return ConnHandler{connService: s, motorService: m, reservationService: r, tokenService: t}
 }
 
 func (h ConnHandler) GetAllConnections(ctx *app.Ctx) error {
@@ -48,7 +52,9 @@ func (h ConnHandler) Connect(ctx *app.Ctx) error {
 		return errorsx.BadRequestError("Geçersiz istek!")
 	}
 
+	// bağlantı istekteki user_id'ye değil giriş yapmış kullanıcıya açılır
 	connection := connVM.ToDBModel()
+	connection.UserID = uint(ctx.GetUserID())
 	connection.ConnectedAt = time.Now()
 
 	motor, err := h.motorService.GetMotorByID(ctx.Context(), int(connVM.MotorbikeID))
@@ -59,8 +65,26 @@ func (h ConnHandler) Connect(ctx *app.Ctx) error {
 		return errorsx.InternalError(err, "Bir hata oluştu!")
 	}
 
-	// Motorbike'ın durumu 'Available' mı kontrol et
-	if motor.Status != motorModel.BikeAvailable {
+	// Motorbike'ın durumu 'Available' mı kontrol et, rezerve ise rezervasyon bu kullanıcıya ait olmalı.
+	// Kiradaysa yalnızca sürüşün sahibi yeniden bağlanabilir (bağlantısı heartbeat gelmediği için kapatılmış olabilir)
+	reconnect := false
+	switch motor.Status {
+	case motorModel.BikeAvailable:
+	case motorModel.BikeReserved:
+		if _, err = h.reservationService.GetActiveReservation(ctx.Context(), int(connection.UserID), int(connVM.MotorbikeID)); err != nil {
+			if errorsx.Is(err, gorm.ErrRecordNotFound) {
+				return errorsx.BadRequestError("Bu Motorbisiklet şu anda müsait değil!")
+			}
+			return errorsx.InternalError(err, "Bir hata oluştu!")
+		}
+	case motorModel.BikeRented:
+		if reconnect, err = h.connService.HasRideInProgress(ctx.Context(), connection.UserID, connVM.MotorbikeID); err != nil {
+			return errorsx.InternalError(err, "Bir hata oluştu!")
+		}
+		if !reconnect {
+			return errorsx.BadRequestError("Bu Motorbisiklet şu anda müsait değil!")
+		}
+	default:
 		return errorsx.BadRequestError("Bu Motorbisiklet şu anda müsait değil!")
 	}
 
@@ -68,18 +92,49 @@ func (h ConnHandler) Connect(ctx *app.Ctx) error {
 		return errorsx.InternalError(err, "Bağlantı kurulurken hata oluştu!")
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bağlantı kuruldu!"})
+	// çevrimdışı kilit açma token'ı yalnızca başlamış sürüş için verilir: sürüş başlatılırken veya sürüş sırasında yeniden bağlanınca
+	var unlockToken *viewmodels.UnlockTokenVM
+	if reconnect {
+		if unlockToken, err = h.IssueRideUnlockToken(ctx, connection.UserID, connection.MotorbikeID); err != nil {
+			return errorsx.InternalError(err, "Kilit açma token'ı oluşturulamadı!")
+		}
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bağlantı kuruldu!", "connection_id": connection.ID, "unlock_token": unlockToken})
 }
 
-// when disconnect the motor status = available. but the lock status does not change. The lock status will be checked when the user sends a photo!
-func (h ConnHandler) Disconnect(ctx *app.Ctx, id int) error {
-	/*param := ctx.Params("id")
-	id, err := strconv.Atoi(param)
+// IssueRideUnlockToken sürüş başlatıldığında kullanıcının motorla açık bağlantısı için çevrimdışı kilit açma token'ı üretir.
+// Telefon bu token'ı BLE ile motora iletir, motor internet olmadan da kilidi açabilir. Açık bağlantı veya motorda
+// takılı cihaz yoksa token verilmez (nil döner), kilit yalnızca çevrimiçi komutla açılır.
+func (h ConnHandler) IssueRideUnlockToken(ctx *app.Ctx, userID, motorbikeID uint) (*viewmodels.UnlockTokenVM, error) {
+	conn, err := h.connService.GetOpenConn(ctx.Context(), userID, motorbikeID)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	token, err := h.tokenService.IssueToken(ctx.Context(), conn)
+	if err != nil {
+		if errorsx.Is(err, connService.ErrNoDeviceForToken) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return viewmodels.NewUnlockTokenVM(*token), nil
+}
+
+// uygulama bağlantı açıkken belirli aralıklarla çağırır, heartbeat gelmeyen bağlantılar otomatik kapatılır.
+// bağlantı kapanmışsa 409 döner, uygulama yeniden bağlanmalıdır
+func (h ConnHandler) Heartbeat(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
 	if err != nil {
 		return errorsx.BadRequestError("Hatalı istek!")
-	}*/
+	}
 
-	connection, err := h.connService.GetConnByParam(ctx.Context(), "motorbike_id", id)
+	conn, err := h.connService.GetConnByParam(ctx.Context(), "id", id)
 	if err != nil {
 		if errorsx.Is(err, gorm.ErrRecordNotFound) {
 			return errorsx.NotFoundError("Böyle bir bağlantı yok!")
@@ -87,33 +142,141 @@ func (h ConnHandler) Disconnect(ctx *app.Ctx, id int) error {
 		return errorsx.InternalError(err, "Bir hata oluştu!")
 	}
 
-	// zaten bağlantı koptuysa..
-	if connection.DisconnectedAt != nil {
-		return errorsx.BadRequestError("Zaten bağlantı kopmuş!")
+	if conn.UserID != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu bağlantı size ait değil!")
+	}
+
+	if err = h.connService.Heartbeat(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, connService.ErrAlreadyDisconnected) {
+			return errorsx.ConflictError("Bağlantı kapanmış, yeniden bağlanın!")
+		}
+		return errorsx.InternalError(err, "Bir hata oluştu!")
 	}
 
-	now := time.Now()
-	connection.DisconnectedAt = &now
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bağlantı açık!"})
+}
 
-	if err = h.connService.UpdateConn(ctx.Context(), connection); err != nil {
-		return errorsx.InternalError(err, "Bağlantı kesilemedi!")
+// (adminler için) heartbeat gelmediği için kapatılan bağlantıları döner -> /connections/stale-report?start_time=2024-09-01&end_time=2024-09-09
+// tarih verilmezse son 7 gün
+func (h ConnHandler) GetStaleConnectionReport(ctx *app.Ctx) error {
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -7)
+
+	var err error
+	if startTimeStr := ctx.Query("start_time"); startTimeStr != "" {
+		if from, err = time.Parse("2006-01-02", startTimeStr); err != nil {
+			return errorsx.BadRequestError("Geçersiz start_time formatı!")
+		}
+	}
+	if endTimeStr := ctx.Query("end_time"); endTimeStr != "" {
+		if to, err = time.Parse("2006-01-02", endTimeStr); err != nil {
+			return errorsx.BadRequestError("Geçersiz end_time formatı!")
+		}
+		to = to.AddDate(0, 0, 1) // bitiş günü dahil
 	}
 
-	motor, err := h.motorService.GetMotorByID(ctx.Context(), int(connection.MotorbikeID))
+	findings, err := h.connService.GetSweepFindings(ctx.Context(), from, to)
 	if err != nil {
+		return errorsx.InternalError(err, "Rapor oluşturulamadı!")
+	}
+
+	var reconciled int
+	var findingDetails []viewmodels.ConnectionSweepFindingVM
+	for _, finding := range *findings {
+		if finding.MotorStatusBefore != finding.MotorStatusAfter {
+			reconciled++
+		}
+		findingDetails = append(findingDetails, viewmodels.ConnectionSweepFindingVM{}.ToViewModel(finding))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":                    from,
+		"to":                      to,
+		"closed_connections":      len(findingDetails),
+		"motor_status_reconciled": reconciled,
+		"findings":                findingDetails,
+	})
+}
+
+// bağlantıya verilen çevrimdışı kilit açma token'larını iptal eder, bağlantı kesildiğinde bu zaten otomatik yapılır
+func (h ConnHandler) RevokeUnlockTokens(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	conn, err := h.connService.GetConnByParam(ctx.Context(), "id", id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir bağlantı yok!")
+		}
 		return errorsx.InternalError(err, "Bir hata oluştu!")
 	}
 
-	motor.Status = motorModel.BikeAvailable
+	if conn.UserID != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu bağlantı size ait değil!")
+	}
+
+	revoked, err := h.tokenService.RevokeConnectionTokens(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Token'lar iptal edilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Token'lar iptal edildi!", "revoked": revoked})
+}
+
+// (cihaz) motor için iptal edilmiş ve süresi dolmamış token ID'lerini döner -> /device-api/revoked-tokens
+// motor çevrimiçi olduğunda bu listeyi alır ve çevrimdışı doğrulamada bu token'ları reddeder
+func (h ConnHandler) GetRevokedTokens(ctx *app.Ctx) error {
+	device := ctx.Locals("device").(*deviceModel.Device)
+
+	tokens, err := h.tokenService.GetRevokedTokens(ctx.Context(), *device.MotorbikeID)
+	if err != nil {
+		return errorsx.InternalError(err, "İptal edilen token'lar getirilemedi!")
+	}
+
+	var revokedTokens []viewmodels.RevokedTokenVM
+	for _, token := range *tokens {
+		revokedTokens = append(revokedTokens, viewmodels.RevokedTokenVM{}.ToViewModel(token))
+	}
+
+	return ctx.SuccessResponse(revokedTokens, len(revokedTokens))
+}
+
+// when disconnect the motor status = available. but the lock status does not change. The lock status will be checked when the user sends a photo!
+func (h ConnHandler) Disconnect(ctx *app.Ctx, id int) error {
+	/*param := ctx.Params("id")
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}*/
 
-	err = h.motorService.UpdateMotor(ctx.Context(), motor)
+	// bağlantının kapatılması ve motorun 'available' yapılması tek transaction içinde
+	_, err := h.connService.Disconnect(ctx.Context(), id)
 	if err != nil {
-		return errorsx.InternalError(err, "Motor status güncellenirken hata oluştu!")
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir bağlantı yok!")
+		}
+		if errorsx.Is(err, connService.ErrAlreadyDisconnected) {
+			return errorsx.BadRequestError("Zaten bağlantı kopmuş!")
+		}
+		return errorsx.InternalError(err, "Bağlantı kesilemedi!")
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bağlantı kesildi!"})
 }
 
+// DisconnectForRide sürüşün fotoğraf adımında motorun bağlantısını kapatır. Bağlantı heartbeat gelmediği için
+// zaten kapatılmışsa veya hiç kurulmadıysa hata sayılmaz.
+func (h ConnHandler) DisconnectForRide(ctx *app.Ctx, motorbikeID int) error {
+	_, err := h.connService.Disconnect(ctx.Context(), motorbikeID)
+	if errorsx.Is(err, connService.ErrAlreadyDisconnected) || errorsx.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+
+	return err
+}
+
 // why i need this func? maybe admin wanna delete history connection? idk bro! but maybe they needs to use this func!
 func (h ConnHandler) DeleteConn(ctx *app.Ctx) error {
 	param := ctx.Params("id")
@@ -8,10 +8,11 @@ import (
 
 type BluetoothConnection struct {
 	BaseModel
-	UserID         uint       `gorm:"not null"`
-	MotorbikeID    uint       `gorm:"not null"`
-	ConnectedAt    time.Time  `gorm:"not null"` // Bağlantı zamanı
-	DisconnectedAt *time.Time // Bağlantının kesildiği zaman
+	UserID          uint       `gorm:"not null"`
+	MotorbikeID     uint       `gorm:"not null"`
+	ConnectedAt     time.Time  `gorm:"not null"` // Bağlantı zamanı
+	LastHeartbeatAt *time.Time // uygulamanın son heartbeat zamanı, boşsa ConnectedAt esas alınır
+	DisconnectedAt  *time.Time // Bağlantının kesildiği zaman
 
 	User      modelUser.User       `gorm:"foreignKey:UserID"`
 	Motorbike modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
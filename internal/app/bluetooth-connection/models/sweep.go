@@ -0,0 +1,22 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+// ConnectionSweepFinding heartbeat gelmediği için otomatik kapatılan bağlantının kaydı
+type ConnectionSweepFinding struct {
+	BaseModel
+	ConnectionID      uint                       `gorm:"not null;uniqueIndex"`
+	UserID            uint                       `gorm:"not null"`
+	MotorbikeID       uint                       `gorm:"not null"`
+	LastHeartbeatAt   time.Time                  `gorm:"not null"` // bağlantıdan gelen son işaret (heartbeat yoksa bağlantı zamanı)
+	SweptAt           time.Time                  `gorm:"not null"`
+	MotorStatusBefore modelMotor.MotorBikeStatus `gorm:"type:varchar(20);not null"`
+	MotorStatusAfter  modelMotor.MotorBikeStatus `gorm:"type:varchar(20);not null"`
+}
+
+func (ConnectionSweepFinding) TableName() string {
+	return "connection_sweep_findings"
+}
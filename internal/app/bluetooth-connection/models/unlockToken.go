@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// UnlockToken bağlantı kurulurken verilen çevrimdışı kilit açma token'ının kaydı.
+// Token'ın kendisi saklanmaz; motor iptal edilen token'ları TokenID ile tanır.
+type UnlockToken struct {
+	BaseModel
+	TokenID      string     `gorm:"type:varchar(32);not null;uniqueIndex"` // token içindeki ID'nin hex gösterimi
+	ConnectionID uint       `gorm:"not null"`
+	UserID       uint       `gorm:"not null"`
+	MotorbikeID  uint       `gorm:"not null"`
+	DeviceID     uint       `gorm:"not null"` // token'ı imzalayan eşleştirme anahtarının sahibi cihaz
+	ExpiresAt    time.Time  `gorm:"not null"`
+	RevokedAt    *time.Time // bağlantı kesildiğinde veya kullanıcı istediğinde dolar
+}
+
+func (UnlockToken) TableName() string {
+	return "unlock_tokens"
+}
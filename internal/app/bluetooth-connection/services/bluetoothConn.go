@@ -2,16 +2,32 @@ package services
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"motorbike-rental-backend/internal/app/bluetooth-connection/models"
+	motorModel "motorbike-rental-backend/internal/app/motorbike/models"
+	rideModel "motorbike-rental-backend/internal/app/ride/models"
+	"time"
 )
 
+var ErrAlreadyDisconnected = errors.New("connection already disconnected")
+
+// inProgressRideStatuses motorun kullanıcıda olduğu sürüş durumları, bu durumda sürüş bitene kadar motor serbest bırakılmaz
+var inProgressRideStatuses = []rideModel.RideStatus{rideModel.RideActive, rideModel.RidePaused, rideModel.RideAwaitingLock, rideModel.RideAwaitingPhoto}
+
 type IConnService interface {
 	GetAllConnections(ctx context.Context) (*[]models.BluetoothConnection, error)
 	GetConnByParam(ctx context.Context, paramName string, paramValue int) (*models.BluetoothConnection, error)
+	GetOpenConn(ctx context.Context, userID, motorbikeID uint) (*models.BluetoothConnection, error)
+	HasRideInProgress(ctx context.Context, userID, motorbikeID uint) (bool, error)
 	CreateConn(ctx context.Context, conn *models.BluetoothConnection) error
 	DeleteConn(ctx context.Context, id int) error
 	UpdateConn(ctx context.Context, connection *models.BluetoothConnection) error
+	Disconnect(ctx context.Context, motorbikeID int) (*models.BluetoothConnection, error)
+	Heartbeat(ctx context.Context, id int) error
+	SweepStaleConnections(ctx context.Context, now time.Time, timeout time.Duration) (*[]models.ConnectionSweepFinding, error)
+	GetSweepFindings(ctx context.Context, from, to time.Time) (*[]models.ConnectionSweepFinding, error)
 }
 
 type ConnService struct {
@@ -64,3 +80,84 @@ func (s *ConnService) GetConnByParam(ctx context.Context, paramName string, para
 
 	return &connection, nil
 }
+
+// GetOpenConn kullanıcının motorla kesilmemiş son bağlantısını döner, yoksa gorm.ErrRecordNotFound
+func (s *ConnService) GetOpenConn(ctx context.Context, userID, motorbikeID uint) (*models.BluetoothConnection, error) {
+	var connection models.BluetoothConnection
+	if err := s.DB.WithContext(ctx).
+		Where("user_id = ? AND motorbike_id = ? AND disconnected_at IS NULL", userID, motorbikeID).
+		Order("id DESC").
+		First(&connection).Error; err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+// HasRideInProgress kullanıcının motorda bitmemiş bir sürüşü olup olmadığını döner
+func (s *ConnService) HasRideInProgress(ctx context.Context, userID, motorbikeID uint) (bool, error) {
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&rideModel.Ride{}).
+		Where("user_id = ? AND motorbike_id = ? AND status IN ?", userID, motorbikeID, inProgressRideStatuses).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Disconnect motorun son bağlantısını ve motoru kilitleyerek bağlantıyı kapatır, bağlantının kilit açma token'larını
+// iptal eder ve motoru 'available' yapar. Hepsi aynı transaction içinde yazılır, biri başarısız olursa hiçbiri değişmez.
+// Motorda bitmemiş sürüş varsa (fotoğraf adımı) motorun durumuna dokunulmaz, motor sürüş bitince serbest bırakılır.
+func (s *ConnService) Disconnect(ctx context.Context, motorbikeID int) (*models.BluetoothConnection, error) {
+	var connection models.BluetoothConnection
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("motorbike_id = ?", motorbikeID).
+			Order("id DESC").
+			First(&connection).Error; err != nil {
+			return err
+		}
+
+		// zaten bağlantı koptuysa..
+		if connection.DisconnectedAt != nil {
+			return ErrAlreadyDisconnected
+		}
+
+		now := time.Now()
+		connection.DisconnectedAt = &now
+		if err := tx.Model(&connection).Update("disconnected_at", now).Error; err != nil {
+			return err
+		}
+
+		// bağlantıya verilen çevrimdışı kilit açma token'ları da iptal edilir
+		if err := tx.Model(&models.UnlockToken{}).
+			Where("connection_id = ? AND revoked_at IS NULL", connection.ID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		var motor motorModel.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", connection.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		var activeRides int64
+		if err := tx.Model(&rideModel.Ride{}).
+			Where("motorbike_id = ? AND status IN ?", connection.MotorbikeID, inProgressRideStatuses).
+			Count(&activeRides).Error; err != nil {
+			return err
+		}
+		if activeRides > 0 {
+			return nil
+		}
+
+		return tx.Model(&motor).Updates(map[string]interface{}{"status": motor.ReleaseStatus(), "maintenance_pending": false}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
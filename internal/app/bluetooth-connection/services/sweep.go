@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/bluetooth-connection/models"
+	motorModel "motorbike-rental-backend/internal/app/motorbike/models"
+	rideModel "motorbike-rental-backend/internal/app/ride/models"
+	"time"
+)
+
+// Heartbeat uygulamanın bağlantının hâlâ açık olduğunu bildirmesi, kapanmış bağlantı için ErrAlreadyDisconnected döner
+func (s *ConnService) Heartbeat(ctx context.Context, id int) error {
+	result := s.DB.WithContext(ctx).Model(&models.BluetoothConnection{}).
+		Where("id = ? AND disconnected_at IS NULL", id).
+		Update("last_heartbeat_at", time.Now().UTC())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyDisconnected
+	}
+
+	return nil
+}
+
+// SweepStaleConnections timeout süresince heartbeat gelmeyen açık bağlantıları kapatır ve bulguları döner.
+// Kapatılan bağlantının token'ları iptal edilir, sürüşü olmadığı halde 'rented' kalan motor 'available' yapılır.
+// Motorda devam eden bir sürüş varsa (uygulama sürüş ortasında çöktüyse) bağlantı yine kapatılır, motorun durumuna dokunulmaz.
+func (s *ConnService) SweepStaleConnections(ctx context.Context, now time.Time, timeout time.Duration) (*[]models.ConnectionSweepFinding, error) {
+	cutoff := now.Add(-timeout)
+
+	var ids []int64
+	if err := s.DB.WithContext(ctx).Model(&models.BluetoothConnection{}).
+		Where("disconnected_at IS NULL AND COALESCE(last_heartbeat_at, connected_at) < ?", cutoff).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	findings := []models.ConnectionSweepFinding{}
+	for _, id := range ids {
+		finding, err := s.sweepConnection(ctx, id, now, cutoff)
+		if err != nil {
+			return &findings, err
+		}
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	return &findings, nil
+}
+
+// sweepConnection tek bir bağlantıyı kilitleyip hâlâ bayat mı diye tekrar kontrol eder, kapatılmadıysa nil döner
+func (s *ConnService) sweepConnection(ctx context.Context, id int64, now, cutoff time.Time) (*models.ConnectionSweepFinding, error) {
+	var finding *models.ConnectionSweepFinding
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var connection models.BluetoothConnection
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&connection).Error; err != nil {
+			return err
+		}
+
+		// kontrol ile kilit arasında heartbeat geldiyse veya bağlantı kapandıysa atla
+		lastSeen := connection.ConnectedAt
+		if connection.LastHeartbeatAt != nil {
+			lastSeen = *connection.LastHeartbeatAt
+		}
+		if connection.DisconnectedAt != nil || !lastSeen.Before(cutoff) {
+			return nil
+		}
+
+		var motor motorModel.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", connection.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		var activeRides int64
+		if err := tx.Model(&rideModel.Ride{}).
+			Where("motorbike_id = ? AND status IN ?", connection.MotorbikeID, inProgressRideStatuses).
+			Count(&activeRides).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&connection).Update("disconnected_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UnlockToken{}).
+			Where("connection_id = ? AND revoked_at IS NULL", connection.ID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		statusAfter := motor.Status
+		if motor.Status == motorModel.BikeRented && activeRides == 0 {
+			statusAfter = motor.ReleaseStatus()
+			if err := tx.Model(&motor).Updates(map[string]interface{}{"status": statusAfter, "maintenance_pending": false}).Error; err != nil {
+				return err
+			}
+		}
+
+		finding = &models.ConnectionSweepFinding{
+			ConnectionID:      uint(connection.ID),
+			UserID:            connection.UserID,
+			MotorbikeID:       connection.MotorbikeID,
+			LastHeartbeatAt:   lastSeen,
+			SweptAt:           now,
+			MotorStatusBefore: motor.Status,
+			MotorStatusAfter:  statusAfter,
+		}
+		return tx.Create(finding).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return finding, nil
+}
+
+// GetSweepFindings verilen aralıkta otomatik kapatılan bağlantıları döner
+func (s *ConnService) GetSweepFindings(ctx context.Context, from, to time.Time) (*[]models.ConnectionSweepFinding, error) {
+	var findings []models.ConnectionSweepFinding
+	if err := s.DB.WithContext(ctx).
+		Where("swept_at >= ? AND swept_at < ?", from, to).
+		Order("swept_at DESC").
+		Find(&findings).Error; err != nil {
+		return nil, err
+	}
+
+	return &findings, nil
+}
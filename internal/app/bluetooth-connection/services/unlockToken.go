@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/bluetooth-connection/models"
+	deviceService "motorbike-rental-backend/internal/app/device/services"
+	"motorbike-rental-backend/pkg/unlocktoken"
+	"time"
+)
+
+var ErrNoDeviceForToken = errors.New("motorbike has no installed device")
+
+// IssuedToken telefona dönen token ve bilgileri
+type IssuedToken struct {
+	Token     string
+	TokenID   string
+	ExpiresAt time.Time
+}
+
+type IUnlockTokenService interface {
+	IssueToken(ctx context.Context, conn *models.BluetoothConnection) (*IssuedToken, error)
+	RevokeConnectionTokens(ctx context.Context, connectionID int) (int64, error)
+	GetRevokedTokens(ctx context.Context, motorbikeID uint) (*[]models.UnlockToken, error)
+}
+
+type UnlockTokenService struct {
+	DB            *gorm.DB
+	deviceService deviceService.IDeviceService
+	ttl           time.Duration
+}
+
+func NewUnlockTokenService(db *gorm.DB, d deviceService.IDeviceService, ttl time.Duration) IUnlockTokenService {
+	return &UnlockTokenService{DB: db, deviceService: d, ttl: ttl}
+}
+
+// IssueToken bağlantı için motordaki cihazın eşleştirme anahtarıyla imzalanmış bir kilit açma token'ı üretir.
+// Motorda takılı cihaz yoksa ErrNoDeviceForToken döner.
+func (s *UnlockTokenService) IssueToken(ctx context.Context, conn *models.BluetoothConnection) (*IssuedToken, error) {
+	device, err := s.deviceService.GetDeviceByMotorID(ctx, conn.MotorbikeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoDeviceForToken
+		}
+		return nil, err
+	}
+
+	key, err := unlocktoken.DecodeKey(device.PairingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	claims := unlocktoken.Claims{
+		UserID:       uint64(conn.UserID),
+		MotorbikeID:  uint64(conn.MotorbikeID),
+		ConnectionID: uint64(conn.ID),
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(s.ttl),
+	}
+	if _, err = rand.Read(claims.TokenID[:]); err != nil {
+		return nil, err
+	}
+
+	token, err := unlocktoken.Sign(key, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	record := models.UnlockToken{
+		TokenID:      claims.TokenIDHex(),
+		ConnectionID: uint(conn.ID),
+		UserID:       conn.UserID,
+		MotorbikeID:  conn.MotorbikeID,
+		DeviceID:     uint(device.ID),
+		ExpiresAt:    claims.ExpiresAt,
+	}
+	if err = s.DB.WithContext(ctx).Create(&record).Error; err != nil {
+		return nil, err
+	}
+
+	return &IssuedToken{Token: token, TokenID: record.TokenID, ExpiresAt: record.ExpiresAt}, nil
+}
+
+// RevokeConnectionTokens bağlantının iptal edilmemiş token'larını iptal eder ve iptal edilen token sayısını döner
+func (s *UnlockTokenService) RevokeConnectionTokens(ctx context.Context, connectionID int) (int64, error) {
+	result := s.DB.WithContext(ctx).Model(&models.UnlockToken{}).
+		Where("connection_id = ? AND revoked_at IS NULL", connectionID).
+		Update("revoked_at", time.Now().UTC())
+
+	return result.RowsAffected, result.Error
+}
+
+// GetRevokedTokens motor için iptal edilmiş ama süresi henüz dolmamış token'ları döner, süresi dolanları motor zaten reddeder
+func (s *UnlockTokenService) GetRevokedTokens(ctx context.Context, motorbikeID uint) (*[]models.UnlockToken, error) {
+	var tokens []models.UnlockToken
+	if err := s.DB.WithContext(ctx).
+		Where("motorbike_id = ? AND revoked_at IS NOT NULL AND expires_at > ?", motorbikeID, time.Now().UTC()).
+		Order("revoked_at").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return &tokens, nil
+}
@@ -2,6 +2,7 @@ package viewmodels
 
 import (
 	"motorbike-rental-backend/internal/app/bluetooth-connection/models"
+	"motorbike-rental-backend/internal/app/bluetooth-connection/services"
 	motorViewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
 	userViewmodel "motorbike-rental-backend/internal/app/user-and-auth/viewmodels"
 	"time"
@@ -9,7 +10,7 @@ import (
 
 // BluetoothConnectionCreateVM is the view model for creating a new Bluetooth connection
 type BluetoothConnectionCreateVM struct {
-	UserID      uint `json:"user_id" validate:"required"`
+	UserID      uint `json:"user_id" validate:"required"` // handler'da giriş yapmış kullanıcıyla ezilir
 	MotorbikeID uint `json:"motorbike_id" validate:"required"`
 	//	ConnectedAt time.Time `json:"connected_at" validate:"required"`
 }
@@ -38,23 +39,70 @@ func (vm *BluetoothConnectionUpdateVM) ToDBModel(m models.BluetoothConnection) m
 
 // BluetoothConnectionDetailVM is the view model for retrieving detailed information about a Bluetooth connection
 type BluetoothConnectionDetailVM struct {
-	ID             uint                              `json:"id"`
-	UserID         uint                              `json:"user_id"`
-	MotorbikeID    uint                              `json:"motorbike_id"`
-	ConnectedAt    time.Time                         `json:"connected_at"`
-	DisconnectedAt *time.Time                        `json:"disconnected_at"`
-	User           userViewmodel.UserDetailVMForUser `json:"user"`
-	Motorbike      motorViewmodel.BikeDetailVM       `json:"motorbike"`
+	ID              uint                              `json:"id"`
+	UserID          uint                              `json:"user_id"`
+	MotorbikeID     uint                              `json:"motorbike_id"`
+	ConnectedAt     time.Time                         `json:"connected_at"`
+	LastHeartbeatAt *time.Time                        `json:"last_heartbeat_at"`
+	DisconnectedAt  *time.Time                        `json:"disconnected_at"`
+	User            userViewmodel.UserDetailVMForUser `json:"user"`
+	Motorbike       motorViewmodel.BikeDetailVM       `json:"motorbike"`
 }
 
 func (vm *BluetoothConnectionDetailVM) ToViewModel(m models.BluetoothConnection) BluetoothConnectionDetailVM {
 	return BluetoothConnectionDetailVM{
-		ID:             uint(m.ID),
-		UserID:         m.UserID,
-		MotorbikeID:    m.MotorbikeID,
-		ConnectedAt:    m.ConnectedAt,
-		DisconnectedAt: m.DisconnectedAt,
-		User:           userViewmodel.UserToUserDetailVMForUser(m.User),
-		Motorbike:      motorViewmodel.NewBikeDetailVM(m.Motorbike, m.Motorbike.Photos),
+		ID:              uint(m.ID),
+		UserID:          m.UserID,
+		MotorbikeID:     m.MotorbikeID,
+		ConnectedAt:     m.ConnectedAt,
+		LastHeartbeatAt: m.LastHeartbeatAt,
+		DisconnectedAt:  m.DisconnectedAt,
+		User:            userViewmodel.UserToUserDetailVMForUser(m.User),
+		Motorbike:       motorViewmodel.NewBikeDetailVM(m.Motorbike, m.Motorbike.Photos),
 	}
 }
+
+// UnlockTokenVM bağlantı kurulurken dönen çevrimdışı kilit açma token'ı
+type UnlockTokenVM struct {
+	Token     string    `json:"token"`
+	TokenID   string    `json:"token_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewUnlockTokenVM(t services.IssuedToken) *UnlockTokenVM {
+	return &UnlockTokenVM{Token: t.Token, TokenID: t.TokenID, ExpiresAt: t.ExpiresAt}
+}
+
+// RevokedTokenVM motora gönderilen iptal listesi kaydı
+type RevokedTokenVM struct {
+	TokenID   string    `json:"token_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (vm RevokedTokenVM) ToViewModel(m models.UnlockToken) RevokedTokenVM {
+	vm.TokenID = m.TokenID
+	vm.ExpiresAt = m.ExpiresAt
+	return vm
+}
+
+// ConnectionSweepFindingVM heartbeat gelmediği için kapatılan bağlantı raporu kaydı
+type ConnectionSweepFindingVM struct {
+	ConnectionID      uint      `json:"connection_id"`
+	UserID            uint      `json:"user_id"`
+	MotorbikeID       uint      `json:"motorbike_id"`
+	LastHeartbeatAt   time.Time `json:"last_heartbeat_at"`
+	SweptAt           time.Time `json:"swept_at"`
+	MotorStatusBefore string    `json:"motor_status_before"`
+	MotorStatusAfter  string    `json:"motor_status_after"`
+}
+
+func (vm ConnectionSweepFindingVM) ToViewModel(m models.ConnectionSweepFinding) ConnectionSweepFindingVM {
+	vm.ConnectionID = m.ConnectionID
+	vm.UserID = m.UserID
+	vm.MotorbikeID = m.MotorbikeID
+	vm.LastHeartbeatAt = m.LastHeartbeatAt
+	vm.SweptAt = m.SweptAt
+	vm.MotorStatusBefore = string(m.MotorStatusBefore)
+	vm.MotorStatusAfter = string(m.MotorStatusAfter)
+	return vm
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/damage-report/models"
+	damageService "motorbike-rental-backend/internal/app/damage-report/services"
+	"motorbike-rental-backend/internal/app/damage-report/viewmodels"
+	modelMaintenance "motorbike-rental-backend/internal/app/maintenance/models"
+	maintenanceService "motorbike-rental-backend/internal/app/maintenance/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/storage"
+	"motorbike-rental-backend/pkg/utils"
+	"strconv"
+	"strings"
+)
+
+// bir bildirimde yüklenebilecek en fazla fotoğraf sayısı
+const maxDamagePhotos = 5
+
+type DamageReportHandler struct {
+	damageService      damageService.IDamageReportService
+	maintenanceService maintenanceService.IMaintenanceService
+	uploader           storage.Uploader
+}
+
+func NewDamageReportHandler(s damageService.IDamageReportService, m maintenanceService.IMaintenanceService, u storage.Uploader) DamageReportHandler {
+	return DamageReportHandler{damageService: s, maintenanceService: m, uploader: u}
+}
+
+// kullanıcı motordaki hasarı bildirir -> /motorbikes/:id/damage-reports
+// (multipart: category, description, isteğe bağlı ride_id ve en fazla 5 photos)
+func (h DamageReportHandler) CreateReport(ctx *app.Ctx) error {
+	motorbikeID, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	category := models.DamageCategory(ctx.FormValue("category"))
+	if category.String() == "unknown" {
+		return errorsx.BadRequestError("Geçersiz hasar kategorisi (mirror, tyre, brake, light, lock, battery, body, other)!")
+	}
+
+	description := strings.TrimSpace(ctx.FormValue("description"))
+	if description == "" || len(description) > 1000 {
+		return errorsx.BadRequestError("Lütfen hasarı açıklayın (en fazla 1000 karakter)!")
+	}
+
+	report := models.DamageReport{
+		MotorbikeID: uint(motorbikeID),
+		UserID:      uint(ctx.GetUserID()),
+		Category:    category,
+		Description: description,
+	}
+
+	if rawRideID := ctx.FormValue("ride_id"); rawRideID != "" {
+		rideID, err := strconv.ParseUint(rawRideID, 10, 64)
+		if err != nil || rideID == 0 {
+			return errorsx.BadRequestError("Geçersiz sürüş!")
+		}
+		id := uint(rideID)
+		report.RideID = &id
+	}
+
+	// fotoğraf zorunlu değil
+	if form, err := ctx.MultipartForm(); err == nil {
+		photos := form.File["photos"]
+		if len(photos) > maxDamagePhotos {
+			return errorsx.BadRequestError(fmt.Sprintf("En fazla %d fotoğraf yüklenebilir!", maxDamagePhotos))
+		}
+
+		for _, photo := range photos {
+			photoRef, err := h.uploader.Image(ctx.Context(), "damage-reports/"+strconv.Itoa(motorbikeID), photo)
+			if err != nil {
+				if storage.IsInvalidUpload(err) {
+					return errorsx.BadRequestError("Fotoğraf JPEG, PNG veya WebP olmalı ve boyut sınırını aşmamalı!")
+				}
+				return errorsx.InternalError(err, "Fotoğraf kaydedilemedi!")
+			}
+			report.Photos = append(report.Photos, models.DamageReportPhoto{PhotoURL: photoRef})
+		}
+	}
+
+	if err = h.damageService.CreateReport(ctx.Context(), &report); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Motor bulunamadı!")
+		}
+		if errorsx.Is(err, damageService.ErrRideMismatch) {
+			return errorsx.BadRequestError("Sürüş bu motora ve kullanıcıya ait değil!")
+		}
+		return errorsx.InternalError(err, "Hasar bildirimi kaydedilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Hasar bildiriminiz alındı, teşekkürler!", "report": viewmodels.DamageReportDetailVM{}.ToViewModel(report)})
+}
+
+// (adminler için) hasar bildirimlerini motor bazında gruplanmış olarak döner -> /damage-reports?status=new
+func (h DamageReportHandler) GetReports(ctx *app.Ctx) error {
+	reports, err := h.damageService.GetReports(ctx.Context(), ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "Hasar bildirimleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toGroups(*reports), len(*reports))
+}
+
+// (adminler için) bildirimi grubuyla birlikte döner, grubun sonraki bir bildirimi istenirse grubun tamamı döner
+func (h DamageReportHandler) GetReport(ctx *app.Ctx) error {
+	report, err := h.getGroup(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.SuccessResponse(viewmodels.DamageReportGroupVM{}.ToViewModel(*report), 1)
+}
+
+// (adminler için) motorun hasar bildirimi geçmişini döner -> /motorbikes/:id/damage-reports
+func (h DamageReportHandler) GetMotorReports(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	reports, err := h.damageService.GetReportsByMotorID(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Hasar bildirimleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toGroups(*reports), len(*reports))
+}
+
+// (adminler için) bildirim grubunu bakıma yönlendirir: motorun açık iş emrine bağlar veya yeni iş emri açar.
+// Yeni iş emri açılınca motor 'maintenance' durumuna geçer ve kiralanamaz, motor kiradaysa sürüş bitince geçer.
+func (h DamageReportHandler) TriageReport(ctx *app.Ctx) error {
+	var vm viewmodels.DamageReportTriageVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	report, err := h.getGroup(ctx)
+	if err != nil {
+		return err
+	}
+	if report.Status != models.DamageReportNew {
+		return errorsx.ConflictError("Hasar bildirimi zaten incelenmiş!")
+	}
+
+	workOrder := &modelMaintenance.WorkOrder{
+		Priority:         modelMaintenance.Priority(vm.Priority),
+		TechnicianID:     vm.TechnicianID,
+		ExpectedReturnAt: vm.ExpectedReturnAt,
+	}
+	if vm.WorkOrderID != nil {
+		if workOrder, err = h.getOpenWorkOrder(ctx, int(*vm.WorkOrderID), report.MotorbikeID); err != nil {
+			return err
+		}
+	}
+
+	deferred, err := h.damageService.TriageReport(ctx.Context(), int(report.ID), workOrder, uint(ctx.GetUserID()))
+	if err != nil {
+		switch {
+		case errorsx.Is(err, maintenanceService.ErrMotorbikeInUse):
+			return errorsx.ConflictError("Motor rezerve, rezervasyon bitince iş emri açılabilir!")
+		case errorsx.Is(err, maintenanceService.ErrTechnicianNotFound):
+			return errorsx.NotFoundError("Teknisyen bulunamadı!")
+		case errorsx.Is(err, maintenanceService.ErrWorkOrderClosed):
+			return errorsx.ConflictError("İş emri kapanmış!")
+		case errorsx.Is(err, damageService.ErrWorkOrderMismatch):
+			return errorsx.BadRequestError("İş emri bildirilen motora ait değil!")
+		}
+		return reportError(err, "Hasar bildirimi bakıma yönlendirilemedi!")
+	}
+
+	info := "Hasar bildirimi bakıma yönlendirildi!"
+	if deferred {
+		info = "Hasar bildirimi bakıma yönlendirildi, motor kirada, sürüş bitince bakıma alınacak!"
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": info, "work_order_id": workOrder.ID})
+}
+
+// (adminler için) bildirim grubunu hasar bulunamadı olarak kapatır
+func (h DamageReportHandler) DismissReport(ctx *app.Ctx) error {
+	var vm viewmodels.DamageReportDismissVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.damageService.DismissReport(ctx.Context(), id, uint(ctx.GetUserID()), strings.TrimSpace(vm.Note)); err != nil {
+		return reportError(err, "Hasar bildirimi kapatılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Hasar bildirimi kapatıldı!"})
+}
+
+// getGroup istenen bildirimin grubunun ilk bildirimini grubuyla birlikte döner
+func (h DamageReportHandler) getGroup(ctx *app.Ctx) (*models.DamageReport, error) {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return nil, errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	report, err := h.damageService.GetReportByID(ctx.Context(), id)
+	if err == nil && report.DuplicateOfID != nil {
+		report, err = h.damageService.GetReportByID(ctx.Context(), int(*report.DuplicateOfID))
+	}
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorsx.NotFoundError("Hasar bildirimi bulunamadı!")
+		}
+		return nil, errorsx.InternalError(err, "Hasar bildirimi getirilirken hata oluştu!")
+	}
+
+	return report, nil
+}
+
+func (h DamageReportHandler) getOpenWorkOrder(ctx *app.Ctx, id int, motorbikeID uint) (*modelMaintenance.WorkOrder, error) {
+	workOrder, err := h.maintenanceService.GetWorkOrderByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorsx.NotFoundError("İş emri bulunamadı!")
+		}
+		return nil, errorsx.InternalError(err, "İş emri getirilirken hata oluştu!")
+	}
+	if workOrder.MotorbikeID != motorbikeID {
+		return nil, errorsx.BadRequestError("İş emri bildirilen motora ait değil!")
+	}
+	if !workOrder.Status.IsOpen() {
+		return nil, errorsx.ConflictError("İş emri kapanmış!")
+	}
+
+	return workOrder, nil
+}
+
+// reportError hasar bildirimi işlemlerinin hatalarını HTTP hatalarına çevirir
+func reportError(err error, msg string) error {
+	switch {
+	case errorsx.Is(err, gorm.ErrRecordNotFound):
+		return errorsx.NotFoundError("Hasar bildirimi bulunamadı!")
+	case errorsx.Is(err, damageService.ErrReportClosed):
+		return errorsx.ConflictError("Hasar bildirimi zaten incelenmiş!")
+	default:
+		return errorsx.InternalError(err, msg)
+	}
+}
+
+func toGroups(reports []models.DamageReport) []viewmodels.DamageReportGroupVM {
+	var groups []viewmodels.DamageReportGroupVM
+	for _, report := range reports {
+		groups = append(groups, viewmodels.DamageReportGroupVM{}.ToViewModel(report))
+	}
+	return groups
+}
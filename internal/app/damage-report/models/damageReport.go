@@ -0,0 +1,125 @@
+package models
+
+import (
+	modelMaintenance "motorbike-rental-backend/internal/app/maintenance/models"
+	"time"
+)
+
+type DamageCategory string
+
+const (
+	DamageMirror  DamageCategory = "mirror"
+	DamageTyre    DamageCategory = "tyre"
+	DamageBrake   DamageCategory = "brake"
+	DamageLight   DamageCategory = "light"
+	DamageLock    DamageCategory = "lock"
+	DamageBattery DamageCategory = "battery"
+	DamageBody    DamageCategory = "body" // kaporta, sele, gidon vb.
+	DamageOther   DamageCategory = "other"
+)
+
+type DamageReportStatus string
+
+const (
+	DamageReportNew       DamageReportStatus = "new"       // admin henüz incelemedi
+	DamageReportTriaged   DamageReportStatus = "triaged"   // bakım iş emrine bağlandı
+	DamageReportDismissed DamageReportStatus = "dismissed" // hasar yok veya tekrar bildirim
+)
+
+// DamageReport kullanıcının motorda gördüğü hasarın bildirimi. Aynı motor için incelenmemiş bildirimler
+// gruplanır: ilk bildirim grubun başıdır, sonrakiler DuplicateOfID ile ona bağlanır ve grup birlikte sonuçlanır.
+type DamageReport struct {
+	BaseModel
+	MotorbikeID   uint               `gorm:"not null"`
+	UserID        uint               `gorm:"not null"`
+	RideID        *uint              // bildirim bir sürüş sırasında/sonunda yapıldıysa
+	Category      DamageCategory     `gorm:"type:varchar(20);not null"`
+	Description   string             `gorm:"type:varchar(1000);not null"`
+	Status        DamageReportStatus `gorm:"type:varchar(20);not null"`
+	DuplicateOfID *uint              // grubun ilk bildirimi, ilk bildirimde boş
+	WorkOrderID   *uint              // bildirimin bağlandığı bakım iş emri
+	AdminNote     string             `gorm:"type:varchar(500)"`
+	ReviewedBy    *uint
+	ReviewedAt    *time.Time
+
+	Photos     []DamageReportPhoto `gorm:"foreignKey:DamageReportID"`
+	Duplicates []DamageReport      `gorm:"foreignKey:DuplicateOfID"`
+}
+
+type DamageReportPhoto struct {
+	BaseModel
+	DamageReportID uint   `gorm:"not null"`
+	PhotoURL       string `gorm:"type:varchar(255);not null"`
+}
+
+func (DamageReport) TableName() string {
+	return "damage_reports"
+}
+
+func (DamageReportPhoto) TableName() string {
+	return "damage_report_photos"
+}
+
+// GroupID bildirimin ait olduğu grubun ilk bildirimi
+func (r DamageReport) GroupID() int64 {
+	if r.DuplicateOfID != nil {
+		return int64(*r.DuplicateOfID)
+	}
+	return r.ID
+}
+
+// IssueType hasar kategorisinin bakım iş emrindeki arıza türü
+func (c DamageCategory) IssueType() modelMaintenance.IssueType {
+	switch c {
+	case DamageTyre:
+		return modelMaintenance.IssueTyre
+	case DamageBrake:
+		return modelMaintenance.IssueBrake
+	case DamageLight:
+		return modelMaintenance.IssueElectrical
+	case DamageLock:
+		return modelMaintenance.IssueLock
+	case DamageBattery:
+		return modelMaintenance.IssueBattery
+	case DamageMirror, DamageBody:
+		return modelMaintenance.IssueBody
+	default:
+		return modelMaintenance.IssueOther
+	}
+}
+
+func (c DamageCategory) String() string {
+	switch c {
+	case DamageMirror:
+		return "mirror"
+	case DamageTyre:
+		return "tyre"
+	case DamageBrake:
+		return "brake"
+	case DamageLight:
+		return "light"
+	case DamageLock:
+		return "lock"
+	case DamageBattery:
+		return "battery"
+	case DamageBody:
+		return "body"
+	case DamageOther:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+func (s DamageReportStatus) String() string {
+	switch s {
+	case DamageReportNew:
+		return "new"
+	case DamageReportTriaged:
+		return "triaged"
+	case DamageReportDismissed:
+		return "dismissed"
+	default:
+		return "unknown"
+	}
+}
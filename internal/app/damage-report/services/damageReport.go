@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/damage-report/models"
+	modelMaintenance "motorbike-rental-backend/internal/app/maintenance/models"
+	maintenanceService "motorbike-rental-backend/internal/app/maintenance/services"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRideMismatch      = errors.New("ride does not belong to this user and motorbike")
+	ErrReportClosed      = errors.New("damage report is already reviewed")
+	ErrWorkOrderMismatch = errors.New("work order belongs to another motorbike")
+)
+
+type IDamageReportService interface {
+	GetReports(ctx context.Context, status string) (*[]models.DamageReport, error)
+	GetReportByID(ctx context.Context, id int) (*models.DamageReport, error)
+	GetReportsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DamageReport, error)
+	CreateReport(ctx context.Context, report *models.DamageReport) error
+	TriageReport(ctx context.Context, id int, workOrder *modelMaintenance.WorkOrder, adminID uint) (bool, error)
+	DismissReport(ctx context.Context, id int, adminID uint, note string) error
+}
+
+type DamageReportService struct {
+	DB *gorm.DB
+}
+
+func NewDamageReportService(db *gorm.DB) IDamageReportService {
+	return &DamageReportService{DB: db}
+}
+
+// GetReports grupların ilk bildirimlerini gruptaki diğer bildirimlerle birlikte eskiden yeniye döner, status boşsa tümü
+func (s *DamageReportService) GetReports(ctx context.Context, status string) (*[]models.DamageReport, error) {
+	query := s.withGroup(ctx).Where("duplicate_of_id IS NULL")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var reports []models.DamageReport
+	if err := query.Order("id").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return &reports, nil
+}
+
+func (s *DamageReportService) GetReportByID(ctx context.Context, id int) (*models.DamageReport, error) {
+	var report models.DamageReport
+	if err := s.withGroup(ctx).Where("id = ?", id).First(&report).Error; err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetReportsByMotorID motorun hasar bildirimi gruplarını yeniden eskiye döner
+func (s *DamageReportService) GetReportsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DamageReport, error) {
+	var reports []models.DamageReport
+	if err := s.withGroup(ctx).
+		Where("motorbike_id = ? AND duplicate_of_id IS NULL", motorbikeID).
+		Order("id DESC").
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return &reports, nil
+}
+
+// CreateReport bildirimi fotoğraflarıyla kaydeder. Motorun incelenmemiş bir bildirim grubu varsa bildirim o gruba eklenir.
+// Aynı motora aynı anda gelen bildirimlerin tek grupta toplanması için motor satırı kilitlenir.
+func (s *DamageReportService) CreateReport(ctx context.Context, report *models.DamageReport) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var motor modelMotor.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", report.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		if report.RideID != nil {
+			var ride modelRide.Ride
+			if err := tx.Where("id = ?", *report.RideID).First(&ride).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrRideMismatch
+				}
+				return err
+			}
+			if ride.UserID != report.UserID || ride.MotorbikeID != report.MotorbikeID {
+				return ErrRideMismatch
+			}
+		}
+
+		var group models.DamageReport
+		err := tx.Where("motorbike_id = ? AND status = ? AND duplicate_of_id IS NULL", report.MotorbikeID, models.DamageReportNew).
+			Order("id").
+			First(&group).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			groupID := uint(group.ID)
+			report.DuplicateOfID = &groupID
+		}
+
+		report.Status = models.DamageReportNew
+		return tx.Create(report).Error
+	})
+}
+
+// TriageReport bildirimin grubunu bakıma yönlendirir. workOrder.ID verilmişse grup motorun açık iş emrine bağlanır,
+// verilmemişse grubun bildirimlerinden yeni iş emri açılır. Grup kilidi, iş emri ve bağlantı tek transaction içinde
+// yazılır, bildirim başka bir istekte kapatılmışsa iş emri de açılmaz. Motor kiradaysa iş emri yine açılır,
+// motor sürüş bitince bakıma alınır; bu durumda true döner.
+func (s *DamageReportService) TriageReport(ctx context.Context, id int, workOrder *modelMaintenance.WorkOrder, adminID uint) (bool, error) {
+	deferred := false
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		group, motor, err := lockGroup(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if workOrder.ID != 0 {
+			// kilit sırası iş emri kapatılırkenki ile aynı: önce motor, sonra iş emri
+			if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", workOrder.ID).First(workOrder).Error; err != nil {
+				return err
+			}
+			if workOrder.MotorbikeID != group.MotorbikeID {
+				return ErrWorkOrderMismatch
+			}
+			if !workOrder.Status.IsOpen() {
+				return maintenanceService.ErrWorkOrderClosed
+			}
+		} else {
+			if err = tx.Where("duplicate_of_id = ?", group.ID).Order("id").Find(&group.Duplicates).Error; err != nil {
+				return err
+			}
+
+			workOrder.MotorbikeID = group.MotorbikeID
+			workOrder.IssueType = group.Category.IssueType()
+			workOrder.Description = workOrderDescription(*group)
+			workOrder.ReportedBy = &adminID
+			if err = maintenanceService.OpenWorkOrderTx(tx, workOrder); err != nil {
+				return err
+			}
+			deferred = motor.Status == modelMotor.BikeRented
+		}
+
+		return updateGroup(tx, group, map[string]interface{}{
+			"status":        models.DamageReportTriaged,
+			"work_order_id": workOrder.ID,
+			"reviewed_by":   adminID,
+		})
+	})
+
+	return deferred, err
+}
+
+// DismissReport bildirimin grubunu hasar bulunamadı olarak kapatır
+func (s *DamageReportService) DismissReport(ctx context.Context, id int, adminID uint, note string) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		group, _, err := lockGroup(tx, id)
+		if err != nil {
+			return err
+		}
+
+		return updateGroup(tx, group, map[string]interface{}{
+			"status":      models.DamageReportDismissed,
+			"admin_note":  note,
+			"reviewed_by": adminID,
+		})
+	})
+}
+
+// lockGroup bildirimin motorunu ve grubun ilk bildirimini kilitler, grup incelenmişse ErrReportClosed döner.
+// Kilit sırası bildirim eklenirkenki ile aynı: önce motor, sonra grup.
+func lockGroup(tx *gorm.DB, id int) (*models.DamageReport, *modelMotor.Motorbike, error) {
+	var report models.DamageReport
+	if err := tx.Where("id = ?", id).First(&report).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var motor modelMotor.Motorbike
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", report.MotorbikeID).First(&motor).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var group models.DamageReport
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", report.GroupID()).First(&group).Error; err != nil {
+		return nil, nil, err
+	}
+	if group.Status != models.DamageReportNew {
+		return nil, nil, ErrReportClosed
+	}
+
+	return &group, &motor, nil
+}
+
+// updateGroup gruptaki incelenmemiş tüm bildirimleri birlikte günceller
+func updateGroup(tx *gorm.DB, group *models.DamageReport, updates map[string]interface{}) error {
+	updates["reviewed_at"] = time.Now().UTC()
+	return tx.Model(&models.DamageReport{}).
+		Where("(id = ? OR duplicate_of_id = ?) AND status = ?", group.ID, group.ID, models.DamageReportNew).
+		Updates(updates).Error
+}
+
+// workOrderDescription gruptaki bildirimleri iş emri açıklamasında toplar
+func workOrderDescription(report models.DamageReport) string {
+	lines := []string{fmt.Sprintf("Hasar bildirimi #%d (%d bildirim)", report.ID, 1+len(report.Duplicates))}
+	lines = append(lines, fmt.Sprintf("- [%s] %s", report.Category, report.Description))
+	for _, duplicate := range report.Duplicates {
+		lines = append(lines, fmt.Sprintf("- [%s] %s", duplicate.Category, duplicate.Description))
+	}
+
+	description := []rune(strings.Join(lines, "\n"))
+	if len(description) > 1000 {
+		description = append(description[:997], []rune("...")...)
+	}
+	return string(description)
+}
+
+func (s *DamageReportService) withGroup(ctx context.Context) *gorm.DB {
+	return s.DB.WithContext(ctx).
+		Preload("Photos").
+		Preload("Duplicates", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Duplicates.Photos")
+}
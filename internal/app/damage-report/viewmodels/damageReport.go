@@ -0,0 +1,75 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/damage-report/models"
+	"motorbike-rental-backend/pkg/storage"
+	"time"
+)
+
+// Hasar bildirimini bakım iş emrine bağlamak için view model. work_order_id verilirse motorun açık iş emrine
+// bağlanır, verilmezse bildirimden yeni iş emri açılır ve motor bakıma alınır.
+type DamageReportTriageVM struct {
+	WorkOrderID      *uint      `json:"work_order_id" validate:"omitempty,gt=0"`
+	Priority         string     `json:"priority" validate:"required_without=WorkOrderID,omitempty,oneof=low medium high critical"`
+	TechnicianID     *uint      `json:"technician_id" validate:"omitempty,gt=0"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at"`
+}
+
+// Hasar bildirimini kapatmak için view model
+type DamageReportDismissVM struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
+
+// Hasar bildirimi detayları için view model
+type DamageReportDetailVM struct {
+	ID            int64      `json:"id"`
+	MotorbikeID   uint       `json:"motorbike_id"`
+	UserID        uint       `json:"user_id"`
+	RideID        *uint      `json:"ride_id"`
+	Category      string     `json:"category"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	DuplicateOfID *uint      `json:"duplicate_of_id"`
+	WorkOrderID   *uint      `json:"work_order_id"`
+	AdminNote     string     `json:"admin_note"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	Photos        []string   `json:"photos"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (vm DamageReportDetailVM) ToViewModel(m models.DamageReport) DamageReportDetailVM {
+	vm.ID = m.ID
+	vm.MotorbikeID = m.MotorbikeID
+	vm.UserID = m.UserID
+	vm.RideID = m.RideID
+	vm.Category = m.Category.String()
+	vm.Description = m.Description
+	vm.Status = m.Status.String()
+	vm.DuplicateOfID = m.DuplicateOfID
+	vm.WorkOrderID = m.WorkOrderID
+	vm.AdminNote = m.AdminNote
+	vm.ReviewedAt = m.ReviewedAt
+	vm.Photos = []string{}
+	for _, photo := range m.Photos {
+		vm.Photos = append(vm.Photos, storage.URL(photo.PhotoURL))
+	}
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
+
+// Hasar bildirimi grubu için view model: grubun ilk bildirimi ve aynı motor için sonradan gelen bildirimler
+type DamageReportGroupVM struct {
+	DamageReportDetailVM
+	ReportCount int                    `json:"report_count"`
+	Duplicates  []DamageReportDetailVM `json:"duplicates"`
+}
+
+func (vm DamageReportGroupVM) ToViewModel(m models.DamageReport) DamageReportGroupVM {
+	vm.DamageReportDetailVM = DamageReportDetailVM{}.ToViewModel(m)
+	vm.ReportCount = 1 + len(m.Duplicates)
+	vm.Duplicates = []DamageReportDetailVM{}
+	for _, duplicate := range m.Duplicates {
+		vm.Duplicates = append(vm.Duplicates, DamageReportDetailVM{}.ToViewModel(duplicate))
+	}
+	return vm
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/device/models"
+	deviceService "motorbike-rental-backend/internal/app/device/services"
+	"motorbike-rental-backend/internal/app/device/viewmodels"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type CommandHandler struct {
+	commandService deviceService.ICommandService
+	rideService    rideService.IRideService
+}
+
+func NewCommandHandler(s deviceService.ICommandService, r rideService.IRideService) CommandHandler {
+	return CommandHandler{commandService: s, rideService: r}
+}
+
+// kullanıcı sürüşü devam eden motorun kilidini açar -> /motorbikes/:id/unlock
+func (h CommandHandler) UnlockMotor(ctx *app.Ctx) error {
+	return h.sendRiderCommand(ctx, models.CommandUnlock)
+}
+
+// kullanıcı sürüşü devam eden motoru kilitler -> /motorbikes/:id/lock
+func (h CommandHandler) LockMotor(ctx *app.Ctx) error {
+	return h.sendRiderCommand(ctx, models.CommandLock)
+}
+
+// (adminler için) sürüş kontrolü yapmadan motora komut gönderir -> /motorbike/:id/command
+func (h CommandHandler) SendCommand(ctx *app.Ctx) error {
+	var vm viewmodels.CommandCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	return h.sendCommand(ctx, id, models.CommandType(vm.Type))
+}
+
+// komutun durumunu döner, uygulama komut 'pending' olduğu sürece bu endpoint'i sorgular -> /device-commands/:id
+func (h CommandHandler) GetCommand(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	command, err := h.commandService.GetCommandByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Komut bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Komut getirilirken hata oluştu!")
+	}
+
+	if command.RequestedBy != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu komut size ait değil!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(viewmodels.CommandDetailVM{}.ToViewModel(*command))
+}
+
+// (adminler için) motora gönderilen komutları döner -> /motorbikes/:id/commands
+func (h CommandHandler) GetMotorCommands(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	commands, err := h.commandService.GetCommandsByMotorID(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Komutlar getirilemedi!")
+	}
+
+	var commandDetails []viewmodels.CommandDetailVM
+	for _, command := range *commands {
+		commandDetails = append(commandDetails, viewmodels.CommandDetailVM{}.ToViewModel(command))
+	}
+
+	return ctx.SuccessResponse(commandDetails, len(commandDetails))
+}
+
+// sendRiderCommand motorun devam eden sürüşünün giriş yapan kullanıcıya ait olduğunu kontrol edip komutu gönderir
+func (h CommandHandler) sendRiderCommand(ctx *app.Ctx, commandType models.CommandType) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	ride, err := h.rideService.GetRideInProgressByMotorID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.ForbiddenError("Bu motorda devam eden bir sürüşünüz yok!")
+		}
+		return errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	if ride.UserID != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu motorda devam eden bir sürüşünüz yok!")
+	}
+
+	return h.sendCommand(ctx, id, commandType)
+}
+
+// sendCommand komutu gönderir; motorun kilit durumu cihaz yanıt verdiğinde değişeceği için 202 döner
+func (h CommandHandler) sendCommand(ctx *app.Ctx, motorbikeID int, commandType models.CommandType) error {
+	command := models.DeviceCommand{
+		MotorbikeID: uint(motorbikeID),
+		Type:        commandType,
+		RequestedBy: uint(ctx.GetUserID()),
+	}
+
+	if err := h.commandService.SendCommand(ctx.Context(), &command); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Motor bulunamadı!")
+		}
+		if errorsx.Is(err, deviceService.ErrCommandPending) {
+			return errorsx.ConflictError("Motora gönderilen önceki komut henüz sonuçlanmadı!")
+		}
+		if errorsx.Is(err, deviceService.ErrCommandSendFailed) {
+			return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Motora ulaşılamadı, lütfen tekrar deneyin!", "command": viewmodels.CommandDetailVM{}.ToViewModel(command)})
+		}
+		return errorsx.InternalError(err, "Komut gönderilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{"info": "Komut motora gönderildi!", "command": viewmodels.CommandDetailVM{}.ToViewModel(command)})
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/device/models"
+	deviceService "motorbike-rental-backend/internal/app/device/services"
+	"motorbike-rental-backend/internal/app/device/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type DeviceHandler struct {
+	deviceService    deviceService.IDeviceService
+	telemetryService deviceService.ITelemetryService
+}
+
+func NewDeviceHandler(d deviceService.IDeviceService, t deviceService.ITelemetryService) DeviceHandler {
+	return DeviceHandler{deviceService: d, telemetryService: t}
+}
+
+// (adminler için) cihazları döner, state ile filtrelenebilir -> /devices?state=registered
+func (h DeviceHandler) GetAllDevices(ctx *app.Ctx) error {
+	state := ctx.Query("state")
+	if state != "" && models.DeviceState(state).String() == "unknown" {
+		return errorsx.BadRequestError("Geçersiz cihaz durumu!")
+	}
+
+	devices, err := h.deviceService.GetAllDevices(ctx.Context(), state)
+	if err != nil {
+		return errorsx.InternalError(err, "Cihazlar getirilemedi!")
+	}
+
+	var deviceDetails []viewmodels.DeviceDetailVM
+	for _, device := range *devices {
+		deviceDetails = append(deviceDetails, viewmodels.DeviceDetailVM{}.ToViewModel(device))
+	}
+
+	return ctx.SuccessResponse(deviceDetails, len(deviceDetails))
+}
+
+// (adminler için) cihazı kurulum geçmişiyle birlikte döner
+func (h DeviceHandler) GetDevice(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	device, err := h.deviceService.GetDeviceByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Cihaz bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Cihaz getirilirken hata oluştu!")
+	}
+
+	installations, err := h.deviceService.GetInstallationsByDeviceID(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Kurulum geçmişi getirilemedi!")
+	}
+
+	var history []viewmodels.InstallationDetailVM
+	for _, installation := range *installations {
+		history = append(history, viewmodels.InstallationDetailVM{}.ToViewModel(installation))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"device": viewmodels.DeviceDetailVM{}.ToViewModel(*device), "installations": history})
+}
+
+// (adminler için) motora takılmış cihazların geçmişini döner -> /motorbikes/:id/devices
+func (h DeviceHandler) GetMotorInstallations(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	installations, err := h.deviceService.GetInstallationsByMotorID(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Kurulum geçmişi getirilemedi!")
+	}
+
+	var history []viewmodels.InstallationDetailVM
+	for _, installation := range *installations {
+		history = append(history, viewmodels.InstallationDetailVM{}.ToViewModel(installation))
+	}
+
+	return ctx.SuccessResponse(history, len(history))
+}
+
+// cihazı kaydeder, API ve eşleştirme anahtarlarını döner; anahtarlar bir daha gösterilmez
+func (h DeviceHandler) CreateDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	device := vm.ToDBModel()
+	credentials, err := h.deviceService.ProvisionDevice(ctx.Context(), &device, uint(ctx.GetUserID()))
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Motor bulunamadı!")
+		}
+		if errorsx.Is(err, deviceService.ErrSerialTaken) {
+			return errorsx.ConflictError("Bu seri numarasıyla bir cihaz zaten kayıtlı!")
+		}
+		if errorsx.Is(err, deviceService.ErrMotorbikeHasDevice) {
+			return errorsx.ConflictError("Bu motora zaten bir cihaz takılı!")
+		}
+		return errorsx.InternalError(err, "Cihaz kaydedilirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"info":        "Cihaz kaydedildi!",
+		"device":      viewmodels.DeviceDetailVM{}.ToViewModel(device),
+		"api_key":     credentials.APIKey,
+		"pairing_key": credentials.PairingKey,
+	})
+}
+
+// (adminler için) cihazın MAC adresini ve yazılım sürümünü günceller
+func (h DeviceHandler) UpdateDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	device, err := h.deviceService.GetDeviceByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Cihaz bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Cihaz getirilirken hata oluştu!")
+	}
+
+	updated := vm.ToDBModel(*device)
+	if err = h.deviceService.UpdateDevice(ctx.Context(), &updated); err != nil {
+		return errorsx.InternalError(err, "Cihaz güncellenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Cihaz güncellendi!"})
+}
+
+// (adminler için) depodaki cihazı motora takar
+func (h DeviceHandler) InstallDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceInstallVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.deviceService.InstallDevice(ctx.Context(), id, vm.MotorbikeID, uint(ctx.GetUserID())); err != nil {
+		return registryError(err, "Cihaz motora takılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Cihaz motora takıldı!"})
+}
+
+// (adminler için) cihazı motordan söker ve depoya alır
+func (h DeviceHandler) UninstallDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceRemoveVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.deviceService.UninstallDevice(ctx.Context(), id, uint(ctx.GetUserID()), vm.Reason); err != nil {
+		return registryError(err, "Cihaz motordan sökülemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Cihaz motordan söküldü!"})
+}
+
+// (adminler için) motordaki cihazı depodaki başka bir cihazla değiştirir
+func (h DeviceHandler) SwapDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceSwapVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if vm.NewDeviceID == id {
+		return errorsx.BadRequestError("Cihaz kendisiyle değiştirilemez!")
+	}
+
+	if err = h.deviceService.SwapDevice(ctx.Context(), id, vm.NewDeviceID, uint(ctx.GetUserID()), vm.Reason); err != nil {
+		return registryError(err, "Cihaz değiştirilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Cihaz değiştirildi!"})
+}
+
+// (adminler için) cihazı kullanımdan kaldırır, takılıysa motordan sökülür
+func (h DeviceHandler) RetireDevice(ctx *app.Ctx) error {
+	var vm viewmodels.DeviceRemoveVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.deviceService.RetireDevice(ctx.Context(), id, uint(ctx.GetUserID()), vm.Reason); err != nil {
+		return registryError(err, "Cihaz kullanımdan kaldırılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Cihaz kullanımdan kaldırıldı!"})
+}
+
+// cihaza yeni API anahtarı verir, eski anahtar geçersiz olur
+func (h DeviceHandler) RotateAPIKey(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	apiKey, err := h.deviceService.RotateAPIKey(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Cihaz bulunamadı!")
+		}
+		if errorsx.Is(err, deviceService.ErrDeviceRetired) {
+			return errorsx.ConflictError("Cihaz kullanımdan kaldırılmış!")
+		}
+		return errorsx.InternalError(err, "API anahtarı yenilenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "API anahtarı yenilendi!", "api_key": apiKey})
+}
+
+// (adminler için) motorun son telemetri kayıtlarını döner -> /motorbikes/:id/telemetry?limit=100
+func (h DeviceHandler) GetMotorTelemetry(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	limit := ctx.QueryInt("limit", 100)
+	if limit <= 0 || limit > 1000 {
+		return errorsx.BadRequestError("limit 1 ile 1000 arasında olmalı!")
+	}
+
+	telemetry, err := h.telemetryService.GetTelemetryByMotorID(ctx.Context(), id, limit)
+	if err != nil {
+		return errorsx.InternalError(err, "Telemetri kayıtları getirilemedi!")
+	}
+
+	var telemetryDetails []viewmodels.TelemetryDetailVM
+	for _, record := range *telemetry {
+		telemetryDetails = append(telemetryDetails, viewmodels.TelemetryDetailVM{}.ToViewModel(record))
+	}
+
+	return ctx.SuccessResponse(telemetryDetails, len(telemetryDetails))
+}
+
+// registryError kurulum işlemlerinin hatalarını HTTP hatalarına çevirir
+func registryError(err error, msg string) error {
+	switch {
+	case errorsx.Is(err, gorm.ErrRecordNotFound):
+		return errorsx.NotFoundError("Cihaz veya motor bulunamadı!")
+	case errorsx.Is(err, deviceService.ErrDeviceNotInStock):
+		return errorsx.ConflictError("Cihaz depoda değil!")
+	case errorsx.Is(err, deviceService.ErrDeviceNotInstalled):
+		return errorsx.ConflictError("Cihaz bir motora takılı değil!")
+	case errorsx.Is(err, deviceService.ErrDeviceRetired):
+		return errorsx.ConflictError("Cihaz zaten kullanımdan kaldırılmış!")
+	case errorsx.Is(err, deviceService.ErrMotorbikeHasDevice):
+		return errorsx.ConflictError("Bu motora zaten bir cihaz takılı!")
+	default:
+		return errorsx.InternalError(err, msg)
+	}
+}
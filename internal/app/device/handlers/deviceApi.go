@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/device/models"
+	deviceService "motorbike-rental-backend/internal/app/device/services"
+	"motorbike-rental-backend/internal/app/device/viewmodels"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+// DeviceAPIHandler motor cihazlarının kullandığı endpoint'ler. Kullanıcı JWT'si yerine
+// X-Device-Serial ve X-Device-Key başlıklarıyla cihaz kimlik doğrulaması yapılır.
+type DeviceAPIHandler struct {
+	deviceService    deviceService.IDeviceService
+	telemetryService deviceService.ITelemetryService
+	commandService   deviceService.ICommandService
+}
+
+func NewDeviceAPIHandler(d deviceService.IDeviceService, t deviceService.ITelemetryService, c deviceService.ICommandService) DeviceAPIHandler {
+	return DeviceAPIHandler{deviceService: d, telemetryService: t, commandService: c}
+}
+
+// Authenticate cihaz API'si için kimlik doğrulama middleware'i, doğrulanan cihaz "device" local'ine yazılır
+func (h DeviceAPIHandler) Authenticate(c *fiber.Ctx) error {
+	serial, apiKey := c.Get("X-Device-Serial"), c.Get("X-Device-Key")
+	if serial == "" || apiKey == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Cihaz kimlik bilgileri eksik!"})
+	}
+
+	device, err := h.deviceService.Authenticate(c.UserContext(), serial, apiKey)
+	if err != nil {
+		if errorsx.Is(err, deviceService.ErrInvalidDeviceCredentials) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Cihaz kimlik bilgileri hatalı!"})
+		}
+		if errorsx.Is(err, deviceService.ErrDeviceNotAssigned) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Cihaz bir motora takılı değil!"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Cihaz doğrulanamadı!"})
+	}
+
+	c.Locals("device", device)
+	return c.Next()
+}
+
+// cihaz kilit, batarya, kilometre ve konum bilgisini gönderir -> /device-api/heartbeat
+func (h DeviceAPIHandler) Heartbeat(ctx *app.Ctx) error {
+	var vm viewmodels.HeartbeatVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	telemetry := vm.ToDBModel()
+	if err := h.telemetryService.RecordTelemetry(ctx.Context(), currentDevice(ctx), &telemetry); err != nil {
+		return errorsx.InternalError(err, "Telemetri kaydedilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Telemetri kaydedildi!"})
+}
+
+// cihaz kendisine gönderilen ve henüz sonuçlanmamış komutları alır -> /device-api/commands
+func (h DeviceAPIHandler) GetPendingCommands(ctx *app.Ctx) error {
+	commands, err := h.commandService.GetPendingCommands(ctx.Context(), *currentDevice(ctx).MotorbikeID)
+	if err != nil {
+		return errorsx.InternalError(err, "Komutlar getirilemedi!")
+	}
+
+	var commandDetails []viewmodels.CommandDetailVM
+	for _, command := range *commands {
+		commandDetails = append(commandDetails, viewmodels.CommandDetailVM{}.ToViewModel(command))
+	}
+
+	return ctx.SuccessResponse(commandDetails, len(commandDetails))
+}
+
+// cihaz komutun sonucunu bildirir -> /device-api/commands/:id/result
+func (h DeviceAPIHandler) ReportCommandResult(ctx *app.Ctx) error {
+	var vm viewmodels.CommandResultVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	command, err := h.commandService.GetCommandByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Komut bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Komut getirilirken hata oluştu!")
+	}
+
+	if command.MotorbikeID != *currentDevice(ctx).MotorbikeID {
+		return errorsx.ForbiddenError("Bu komut bu cihaza ait değil!")
+	}
+
+	result := deviceService.CommandResult{
+		CommandID:  command.ID,
+		Success:    vm.Success,
+		LockStatus: modelMotor.LockStatus(vm.LockStatus),
+		Error:      vm.Error,
+	}
+
+	if err = h.commandService.ReportResult(ctx.Context(), result); err != nil {
+		return errorsx.InternalError(err, "Komut sonucu kaydedilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Komut sonucu kaydedildi!"})
+}
+
+func currentDevice(ctx *app.Ctx) *models.Device {
+	return ctx.Locals("device").(*models.Device)
+}
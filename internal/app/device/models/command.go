@@ -0,0 +1,76 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+type CommandType string
+
+const (
+	CommandUnlock CommandType = "unlock"
+	CommandLock   CommandType = "lock"
+)
+
+type CommandStatus string
+
+const (
+	CommandPending  CommandStatus = "pending"   // cihaza gönderildi, yanıt bekleniyor
+	CommandAcked    CommandStatus = "acked"     // cihaz komutu uyguladığını bildirdi
+	CommandFailed   CommandStatus = "failed"    // gönderilemedi veya cihaz uygulayamadığını bildirdi
+	CommandTimedOut CommandStatus = "timed_out" // süresi içinde yanıt gelmedi
+)
+
+// DeviceCommand motorun kilit modülüne gönderilen komut. Motorun LockStatus alanı yalnızca
+// cihaz komutu uyguladığını bildirdiğinde değişir. Bir motorun aynı anda tek bekleyen komutu olabilir.
+type DeviceCommand struct {
+	BaseModel
+	MotorbikeID uint          `gorm:"not null"`
+	Type        CommandType   `gorm:"type:varchar(20);not null"`
+	Status      CommandStatus `gorm:"type:varchar(20);not null"`
+	Transport   string        `gorm:"type:varchar(20);not null"` // komutun gönderildiği kanal
+	RequestedBy uint          `gorm:"not null"`
+	Error       string        `gorm:"type:varchar(255)"`
+	ExpiresAt   time.Time     `gorm:"not null"` // bu zamana kadar yanıt gelmezse 'timed_out' olur
+	AckedAt     *time.Time
+
+	Motorbike modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
+}
+
+func (DeviceCommand) TableName() string {
+	return "device_commands"
+}
+
+// TargetLockStatus komut başarıyla uygulandığında motorun alacağı kilit durumu
+func (c DeviceCommand) TargetLockStatus() modelMotor.LockStatus {
+	if c.Type == CommandLock {
+		return modelMotor.Locked
+	}
+	return modelMotor.Unlocked
+}
+
+func (t CommandType) String() string {
+	switch t {
+	case CommandUnlock:
+		return "unlock"
+	case CommandLock:
+		return "lock"
+	default:
+		return "unknown"
+	}
+}
+
+func (s CommandStatus) String() string {
+	switch s {
+	case CommandPending:
+		return "pending"
+	case CommandAcked:
+		return "acked"
+	case CommandFailed:
+		return "failed"
+	case CommandTimedOut:
+		return "timed_out"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+type DeviceState string
+
+const (
+	DeviceRegistered DeviceState = "registered" // kayıtlı, bir motora takılı değil (depoda)
+	DeviceInstalled  DeviceState = "installed"  // bir motora takılı
+	DeviceRetired    DeviceState = "retired"    // kullanımdan kaldırıldı, kimlik bilgileri geçersiz
+)
+
+// Device motora takılı kilit/IoT modülü. Cihaz API'sine seri numarası ve anahtarıyla erişir;
+// anahtarın yalnızca SHA-256 özeti saklanır, anahtarın kendisi oluşturulurken bir kez gösterilir.
+// PairingKey cihaza üretimde yazılan BLE eşleştirme anahtarıdır, sunucu çevrimdışı kilit açma
+// token'larını bu anahtarla imzaladığı için özet olarak değil kendisi saklanır ve API'de dönmez.
+type Device struct {
+	BaseModel
+	Serial          string      `gorm:"type:varchar(64);not null;uniqueIndex"`
+	MACAddress      string      `gorm:"type:varchar(17)"`
+	FirmwareVersion string      `gorm:"type:varchar(32)"`
+	PairingKey      string      `gorm:"type:varchar(64);not null"`
+	State           DeviceState `gorm:"type:varchar(20);not null"`
+	MotorbikeID     *uint       // cihazın takılı olduğu motor, yalnızca 'installed' durumunda dolu
+	APIKeyHash      string      `gorm:"type:varchar(64);not null"`
+	LastSeenAt      *time.Time  // son heartbeat zamanı
+
+	Motorbike *modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
+}
+
+// DeviceInstallation cihazın hangi motora ne zaman takılıp söküldüğünün kaydı
+type DeviceInstallation struct {
+	BaseModel
+	DeviceID    uint       `gorm:"not null"`
+	MotorbikeID uint       `gorm:"not null"`
+	InstalledAt time.Time  `gorm:"not null"`
+	InstalledBy *uint      // boşsa kayıt geçmiş verilerden oluşturulmuştur
+	RemovedAt   *time.Time // boşsa cihaz hâlâ bu motorda
+	RemovedBy   *uint
+	Reason      string `gorm:"type:varchar(255)"` // sökülme nedeni
+
+	Device Device `gorm:"foreignKey:DeviceID"`
+}
+
+func (Device) TableName() string {
+	return "devices"
+}
+
+func (DeviceInstallation) TableName() string {
+	return "device_installations"
+}
+
+func (s DeviceState) String() string {
+	switch s {
+	case DeviceRegistered:
+		return "registered"
+	case DeviceInstalled:
+		return "installed"
+	case DeviceRetired:
+		return "retired"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+// Telemetry cihazın gönderdiği heartbeat kaydı, motorun kilit, batarya, kilometre ve konum geçmişi için saklanır.
+// Cihazın göndermediği alanlar boş kalır.
+type Telemetry struct {
+	BaseModel
+	MotorbikeID  uint                  `gorm:"not null"`
+	DeviceID     uint                  `gorm:"not null"`
+	LockStatus   modelMotor.LockStatus `gorm:"type:varchar(10)"`
+	BatteryLevel *float64              // batarya/yakıt seviyesi (%)
+	OdometerKm   *float64
+	Latitude     *float64
+	Longitude    *float64
+	RecordedAt   time.Time `gorm:"not null"` // cihazın ölçüm zamanı
+}
+
+func (Telemetry) TableName() string {
+	return "motorbike_telemetry"
+}
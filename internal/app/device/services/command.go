@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/device/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/pkg/events"
+	"time"
+)
+
+var (
+	ErrCommandPending    = errors.New("motorbike already has a pending command")
+	ErrCommandSendFailed = errors.New("command could not be sent to device")
+)
+
+type ICommandService interface {
+	SendCommand(ctx context.Context, command *models.DeviceCommand) error
+	ReportResult(ctx context.Context, result CommandResult) error
+	GetCommandByID(ctx context.Context, id int) (*models.DeviceCommand, error)
+	GetCommandsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DeviceCommand, error)
+	GetPendingCommands(ctx context.Context, motorbikeID uint) (*[]models.DeviceCommand, error)
+	TimeoutCommands(ctx context.Context, now time.Time) (int, error)
+}
+
+type CommandService struct {
+	DB        *gorm.DB
+	transport CommandTransport
+	events    *events.Bus
+	timeout   time.Duration // cihazın komuta yanıt vermesi için beklenen süre
+}
+
+// NewCommandService servisi oluşturur ve cihaz yanıtlarını almak için kendisini kanala kaydeder
+func NewCommandService(db *gorm.DB, transport CommandTransport, bus *events.Bus, timeout time.Duration) ICommandService {
+	s := &CommandService{DB: db, transport: transport, events: bus, timeout: timeout}
+	transport.OnResult(s.ReportResult)
+	return s
+}
+
+// SendCommand komutu 'pending' olarak kaydeder ve cihaza iletir. Motorun bekleyen bir komutu varsa
+// ErrCommandPending döner; komut iletilemezse 'failed' yapılır ve ErrCommandSendFailed döner.
+func (s *CommandService) SendCommand(ctx context.Context, command *models.DeviceCommand) error {
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var motor modelMotor.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", command.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		var pending int64
+		if err := tx.Model(&models.DeviceCommand{}).
+			Where("motorbike_id = ? AND status = ?", command.MotorbikeID, models.CommandPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return ErrCommandPending
+		}
+
+		command.Status = models.CommandPending
+		command.Transport = s.transport.Name()
+		command.ExpiresAt = time.Now().UTC().Add(s.timeout)
+		return tx.Create(command).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.transport.Send(ctx, *command); err != nil {
+		command.Status = models.CommandFailed
+		command.Error = err.Error()
+		_ = s.DB.WithContext(ctx).Model(command).
+			Where("status = ?", models.CommandPending).
+			Updates(map[string]interface{}{"status": command.Status, "error": command.Error}).Error
+		return ErrCommandSendFailed
+	}
+
+	return nil
+}
+
+// ReportResult cihazın yanıtını işler. Başarılı yanıtta motorun kilit durumu cihazın bildirdiği duruma çekilir;
+// zaman aşımına uğramış bir komuta geç gelen yanıtta da kilit durumu güncellenir, çünkü motorun gerçek durumu budur.
+func (s *CommandService) ReportResult(ctx context.Context, result CommandResult) error {
+	var command models.DeviceCommand
+	var previousLock, lockStatus modelMotor.LockStatus
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", result.CommandID).First(&command).Error; err != nil {
+			return err
+		}
+
+		// telemetri ve komut gönderimiyle aynı sırada kilitlenir (önce motor, sonra komut), böylece birbirlerini beklemezler
+		var motor modelMotor.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", command.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", command.ID).First(&command).Error; err != nil {
+			return err
+		}
+
+		if result.Success {
+			previousLock = motor.LockStatus
+
+			lockStatus = result.LockStatus
+			if lockStatus == "" {
+				lockStatus = command.TargetLockStatus()
+			}
+			if err := tx.Model(&motor).Update("lock_status", lockStatus).Error; err != nil {
+				return err
+			}
+		}
+
+		if command.Status != models.CommandPending {
+			return nil
+		}
+
+		updates := map[string]interface{}{"status": models.CommandFailed, "error": result.Error}
+		if result.Success {
+			updates = map[string]interface{}{"status": models.CommandAcked, "acked_at": time.Now().UTC()}
+		}
+		return tx.Model(&command).Updates(updates).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Success && lockStatus != previousLock {
+		publishLockEvent(ctx, s.events, command.MotorbikeID, lockStatus)
+	}
+
+	return nil
+}
+
+func (s *CommandService) GetCommandByID(ctx context.Context, id int) (*models.DeviceCommand, error) {
+	var command models.DeviceCommand
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&command).Error; err != nil {
+		return nil, err
+	}
+
+	return &command, nil
+}
+
+func (s *CommandService) GetCommandsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DeviceCommand, error) {
+	var commands []models.DeviceCommand
+	if err := s.DB.WithContext(ctx).Where("motorbike_id = ?", motorbikeID).Order("id DESC").Find(&commands).Error; err != nil {
+		return nil, err
+	}
+
+	return &commands, nil
+}
+
+// GetPendingCommands motorun yanıt bekleyen komutlarını döner, cihaz API'si üzerinden komut alan cihazlar için
+func (s *CommandService) GetPendingCommands(ctx context.Context, motorbikeID uint) (*[]models.DeviceCommand, error) {
+	var commands []models.DeviceCommand
+	if err := s.DB.WithContext(ctx).
+		Where("motorbike_id = ? AND status = ? AND expires_at > ?", motorbikeID, models.CommandPending, time.Now().UTC()).
+		Order("id").Find(&commands).Error; err != nil {
+		return nil, err
+	}
+
+	return &commands, nil
+}
+
+// TimeoutCommands süresi içinde yanıt gelmeyen komutları 'timed_out' yapar, motorun kilit durumu değişmez
+func (s *CommandService) TimeoutCommands(ctx context.Context, now time.Time) (int, error) {
+	result := s.DB.WithContext(ctx).Model(&models.DeviceCommand{}).
+		Where("status = ? AND expires_at < ?", models.CommandPending, now).
+		Updates(map[string]interface{}{"status": models.CommandTimedOut, "error": "cihazdan yanıt gelmedi"})
+
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/device/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+var (
+	ErrInvalidDeviceCredentials = errors.New("invalid device credentials")
+	ErrDeviceNotAssigned        = errors.New("device is not assigned to a motorbike")
+	ErrMotorbikeHasDevice       = errors.New("motorbike already has a device")
+	ErrSerialTaken              = errors.New("device serial already registered")
+	ErrDeviceNotInStock         = errors.New("device is not in stock")
+	ErrDeviceNotInstalled       = errors.New("device is not installed")
+	ErrDeviceRetired            = errors.New("device is retired")
+)
+
+// DeviceCredentials cihaz kaydedilirken üretilen ve yalnızca bir kez gösterilen anahtarlar
+type DeviceCredentials struct {
+	APIKey     string
+	PairingKey string
+}
+
+type IDeviceService interface {
+	GetAllDevices(ctx context.Context, state string) (*[]models.Device, error)
+	GetDeviceByID(ctx context.Context, id int) (*models.Device, error)
+	GetDeviceByMotorID(ctx context.Context, motorbikeID uint) (*models.Device, error)
+	GetInstallationsByDeviceID(ctx context.Context, deviceID int) (*[]models.DeviceInstallation, error)
+	GetInstallationsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DeviceInstallation, error)
+	ProvisionDevice(ctx context.Context, device *models.Device, adminID uint) (*DeviceCredentials, error)
+	UpdateDevice(ctx context.Context, device *models.Device) error
+	InstallDevice(ctx context.Context, id int, motorbikeID uint, adminID uint) error
+	UninstallDevice(ctx context.Context, id int, adminID uint, reason string) error
+	SwapDevice(ctx context.Context, id int, newDeviceID int, adminID uint, reason string) error
+	RetireDevice(ctx context.Context, id int, adminID uint, reason string) error
+	RotateAPIKey(ctx context.Context, id int) (string, error)
+	Authenticate(ctx context.Context, serial, apiKey string) (*models.Device, error)
+}
+
+type DeviceService struct {
+	DB *gorm.DB
+}
+
+func NewDeviceService(db *gorm.DB) IDeviceService {
+	return &DeviceService{DB: db}
+}
+
+// GetAllDevices cihazları döner, state boşsa tümü
+func (s *DeviceService) GetAllDevices(ctx context.Context, state string) (*[]models.Device, error) {
+	query := s.DB.WithContext(ctx)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	var devices []models.Device
+	if err := query.Order("id").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return &devices, nil
+}
+
+func (s *DeviceService) GetDeviceByID(ctx context.Context, id int) (*models.Device, error) {
+	var device models.Device
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&device).Error; err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+// GetDeviceByMotorID motora takılı cihazı döner
+func (s *DeviceService) GetDeviceByMotorID(ctx context.Context, motorbikeID uint) (*models.Device, error) {
+	var device models.Device
+	if err := s.DB.WithContext(ctx).Where("motorbike_id = ? AND state = ?", motorbikeID, models.DeviceInstalled).First(&device).Error; err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func (s *DeviceService) GetInstallationsByDeviceID(ctx context.Context, deviceID int) (*[]models.DeviceInstallation, error) {
+	var installations []models.DeviceInstallation
+	if err := s.DB.WithContext(ctx).Preload("Device").
+		Where("device_id = ?", deviceID).Order("installed_at DESC").
+		Find(&installations).Error; err != nil {
+		return nil, err
+	}
+
+	return &installations, nil
+}
+
+func (s *DeviceService) GetInstallationsByMotorID(ctx context.Context, motorbikeID int) (*[]models.DeviceInstallation, error) {
+	var installations []models.DeviceInstallation
+	if err := s.DB.WithContext(ctx).Preload("Device").
+		Where("motorbike_id = ?", motorbikeID).Order("installed_at DESC").
+		Find(&installations).Error; err != nil {
+		return nil, err
+	}
+
+	return &installations, nil
+}
+
+// ProvisionDevice cihazı yeni API ve eşleştirme anahtarlarıyla kaydeder, motor verilmişse cihazı motora takar.
+// Anahtarlar yalnızca burada döner; eşleştirme anahtarı üretim sırasında cihaza yazılır.
+func (s *DeviceService) ProvisionDevice(ctx context.Context, device *models.Device, adminID uint) (*DeviceCredentials, error) {
+	apiKey, err := newRandomKey()
+	if err != nil {
+		return nil, err
+	}
+	pairingKey, err := newRandomKey()
+	if err != nil {
+		return nil, err
+	}
+
+	motorbikeID := device.MotorbikeID
+	device.MotorbikeID = nil
+	device.State = models.DeviceRegistered
+	device.APIKeyHash = hashAPIKey(apiKey)
+	device.PairingKey = pairingKey
+
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Unscoped().Model(&models.Device{}).Where("serial = ?", device.Serial).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrSerialTaken
+		}
+
+		if err := tx.Create(device).Error; err != nil {
+			return err
+		}
+
+		if motorbikeID == nil {
+			return nil
+		}
+		return install(tx, device, *motorbikeID, adminID, time.Now().UTC())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceCredentials{APIKey: apiKey, PairingKey: pairingKey}, nil
+}
+
+// UpdateDevice yalnızca donanım bilgilerini (MAC adresi, yazılım sürümü) günceller
+func (s *DeviceService) UpdateDevice(ctx context.Context, device *models.Device) error {
+	return s.DB.WithContext(ctx).Model(device).
+		Select("mac_address", "firmware_version").
+		Updates(device).Error
+}
+
+// InstallDevice depodaki cihazı motora takar, motorda başka bir cihaz varsa ErrMotorbikeHasDevice döner
+func (s *DeviceService) InstallDevice(ctx context.Context, id int, motorbikeID uint, adminID uint) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		device, err := lockDevice(tx, id)
+		if err != nil {
+			return err
+		}
+		if device.State != models.DeviceRegistered {
+			return ErrDeviceNotInStock
+		}
+
+		return install(tx, device, motorbikeID, adminID, time.Now().UTC())
+	})
+}
+
+// UninstallDevice cihazı motordan söker ve depoya alır
+func (s *DeviceService) UninstallDevice(ctx context.Context, id int, adminID uint, reason string) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		device, err := lockDevice(tx, id)
+		if err != nil {
+			return err
+		}
+		if device.State != models.DeviceInstalled {
+			return ErrDeviceNotInstalled
+		}
+
+		return uninstall(tx, device, models.DeviceRegistered, adminID, reason, time.Now().UTC())
+	})
+}
+
+// SwapDevice motordaki cihazı söküp yerine depodaki yeni cihazı takar, ikisi aynı transaction içinde yapılır
+func (s *DeviceService) SwapDevice(ctx context.Context, id int, newDeviceID int, adminID uint, reason string) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		device, err := lockDevice(tx, id)
+		if err != nil {
+			return err
+		}
+		if device.State != models.DeviceInstalled {
+			return ErrDeviceNotInstalled
+		}
+
+		newDevice, err := lockDevice(tx, newDeviceID)
+		if err != nil {
+			return err
+		}
+		if newDevice.State != models.DeviceRegistered {
+			return ErrDeviceNotInStock
+		}
+
+		now := time.Now().UTC()
+		motorbikeID := *device.MotorbikeID
+		if err = uninstall(tx, device, models.DeviceRegistered, adminID, reason, now); err != nil {
+			return err
+		}
+
+		return install(tx, newDevice, motorbikeID, adminID, now)
+	})
+}
+
+// RetireDevice cihazı kullanımdan kaldırır, takılıysa motordan sökülür; kimlik bilgileri artık kabul edilmez
+func (s *DeviceService) RetireDevice(ctx context.Context, id int, adminID uint, reason string) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		device, err := lockDevice(tx, id)
+		if err != nil {
+			return err
+		}
+
+		switch device.State {
+		case models.DeviceRetired:
+			return ErrDeviceRetired
+		case models.DeviceInstalled:
+			return uninstall(tx, device, models.DeviceRetired, adminID, reason, time.Now().UTC())
+		default:
+			return tx.Model(device).Update("state", models.DeviceRetired).Error
+		}
+	})
+}
+
+// RotateAPIKey cihaza yeni bir API anahtarı verir, eski anahtar hemen geçersiz olur
+func (s *DeviceService) RotateAPIKey(ctx context.Context, id int) (string, error) {
+	device, err := s.GetDeviceByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if device.State == models.DeviceRetired {
+		return "", ErrDeviceRetired
+	}
+
+	apiKey, err := newRandomKey()
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.DB.WithContext(ctx).Model(device).Update("api_key_hash", hashAPIKey(apiKey)).Error; err != nil {
+		return "", err
+	}
+
+	return apiKey, nil
+}
+
+// Authenticate seri numarası ve anahtarı doğrular. Kullanımdan kaldırılan cihazlar kabul edilmez,
+// cihaz bir motora takılı değilse ErrDeviceNotAssigned döner.
+func (s *DeviceService) Authenticate(ctx context.Context, serial, apiKey string) (*models.Device, error) {
+	var device models.Device
+	if err := s.DB.WithContext(ctx).Where("serial = ?", serial).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidDeviceCredentials
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(device.APIKeyHash), []byte(hashAPIKey(apiKey))) != 1 || device.State == models.DeviceRetired {
+		return nil, ErrInvalidDeviceCredentials
+	}
+
+	if device.State != models.DeviceInstalled || device.MotorbikeID == nil {
+		return nil, ErrDeviceNotAssigned
+	}
+
+	return &device, nil
+}
+
+func lockDevice(tx *gorm.DB, id int) (*models.Device, error) {
+	var device models.Device
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&device).Error; err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+// install cihazı motora takar ve kurulum kaydını açar, motor satırı kilitlenerek motora aynı anda iki cihaz takılması engellenir
+func install(tx *gorm.DB, device *models.Device, motorbikeID uint, adminID uint, now time.Time) error {
+	var motor modelMotor.Motorbike
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", motorbikeID).First(&motor).Error; err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&models.Device{}).Where("motorbike_id = ?", motorbikeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrMotorbikeHasDevice
+	}
+
+	if err := tx.Model(device).Updates(map[string]interface{}{
+		"state":        models.DeviceInstalled,
+		"motorbike_id": motorbikeID,
+	}).Error; err != nil {
+		return err
+	}
+
+	device.State = models.DeviceInstalled
+	device.MotorbikeID = &motorbikeID
+	return tx.Create(&models.DeviceInstallation{
+		DeviceID:    uint(device.ID),
+		MotorbikeID: motorbikeID,
+		InstalledAt: now,
+		InstalledBy: &adminID,
+	}).Error
+}
+
+// uninstall cihazı motordan söker, açık kurulum kaydını kapatır ve cihazı verilen duruma alır
+func uninstall(tx *gorm.DB, device *models.Device, state models.DeviceState, adminID uint, reason string, now time.Time) error {
+	if err := tx.Model(&models.DeviceInstallation{}).
+		Where("device_id = ? AND removed_at IS NULL", device.ID).
+		Updates(map[string]interface{}{
+			"removed_at": now,
+			"removed_by": adminID,
+			"reason":     reason,
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(device).Updates(map[string]interface{}{
+		"state":        state,
+		"motorbike_id": nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	device.State = state
+	device.MotorbikeID = nil
+	return nil
+}
+
+func newRandomKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
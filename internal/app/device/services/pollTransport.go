@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"motorbike-rental-backend/internal/app/device/models"
+)
+
+// PollTransport komutları cihaza iletmez; bekleyen komutlar veritabanında kalır ve cihaz bunları
+// cihaz API'sinden (/api/device-api/commands) alıp sonucunu yine cihaz API'sine bildirir.
+type PollTransport struct{}
+
+func NewPollTransport() *PollTransport {
+	return &PollTransport{}
+}
+
+func (t *PollTransport) Name() string {
+	return "device-api"
+}
+
+func (t *PollTransport) Send(_ context.Context, _ models.DeviceCommand) error {
+	return nil
+}
+
+// OnResult kullanılmaz, yanıtlar cihaz API'si üzerinden doğrudan komut servisine gelir
+func (t *PollTransport) OnResult(_ ResultHandler) {}
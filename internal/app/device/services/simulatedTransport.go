@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"motorbike-rental-backend/internal/app/device/models"
+	"motorbike-rental-backend/pkg/log"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SimulatedTransport gerçek donanım olmadan yerel geliştirme için kullanılan sahte komut kanalı.
+// Her komutu Delay kadar bekledikten sonra uygulanmış sayar; Offline listesindeki motorlar yanıt vermez,
+// böylece komutların zaman aşımı da denenebilir.
+type SimulatedTransport struct {
+	Delay time.Duration
+
+	mu      sync.Mutex
+	offline map[uint]bool
+	handler ResultHandler
+}
+
+func NewSimulatedTransport(delay time.Duration) *SimulatedTransport {
+	return &SimulatedTransport{Delay: delay, offline: map[uint]bool{}}
+}
+
+func (t *SimulatedTransport) Name() string {
+	return "simulated"
+}
+
+func (t *SimulatedTransport) OnResult(handler ResultHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handler = handler
+}
+
+// SetOffline motoru yanıt vermeyen (çevrimdışı) olarak işaretler
+func (t *SimulatedTransport) SetOffline(motorbikeID uint, offline bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.offline[motorbikeID] = offline
+}
+
+func (t *SimulatedTransport) Send(_ context.Context, command models.DeviceCommand) error {
+	t.mu.Lock()
+	handler, offline := t.handler, t.offline[command.MotorbikeID]
+	t.mu.Unlock()
+
+	if offline || handler == nil {
+		return nil
+	}
+
+	// yanıt istekten bağımsız geldiği için istek context'i kullanılmaz
+	go func() {
+		time.Sleep(t.Delay)
+		result := CommandResult{CommandID: command.ID, Success: true, LockStatus: command.TargetLockStatus()}
+		if err := handler(context.Background(), result); err != nil {
+			l := log.GetLogger("")
+			l.Error("Simüle cihaz yanıtı işlenemedi", zap.Int64("command_id", command.ID), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
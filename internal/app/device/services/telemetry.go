@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/device/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/pkg/events"
+	"time"
+)
+
+type ITelemetryService interface {
+	RecordTelemetry(ctx context.Context, device *models.Device, telemetry *models.Telemetry) error
+	GetTelemetryByMotorID(ctx context.Context, motorbikeID int, limit int) (*[]models.Telemetry, error)
+}
+
+type TelemetryService struct {
+	DB     *gorm.DB
+	events *events.Bus
+}
+
+func NewTelemetryService(db *gorm.DB, bus *events.Bus) ITelemetryService {
+	return &TelemetryService{DB: db, events: bus}
+}
+
+// RecordTelemetry heartbeat kaydını geçmişe ekler ve motorun batarya, konum, kilit ve son görülme bilgilerini günceller.
+// Cihazın bildirdiği kilit durumu bekleyen komutun hedefiyle aynıysa komut da 'acked' yapılır.
+// Kayıttan sonra telemetri olayı, kilit durumu değiştiyse ayrıca kilit olayı yayınlanır.
+func (s *TelemetryService) RecordTelemetry(ctx context.Context, device *models.Device, telemetry *models.Telemetry) error {
+	now := time.Now().UTC()
+	telemetry.MotorbikeID = *device.MotorbikeID
+	telemetry.DeviceID = uint(device.ID)
+	if telemetry.RecordedAt.IsZero() || telemetry.RecordedAt.After(now) {
+		telemetry.RecordedAt = now
+	}
+
+	var previousLock modelMotor.LockStatus
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var motor modelMotor.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", telemetry.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+		previousLock = motor.LockStatus
+
+		if err := tx.Create(telemetry).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"last_seen_at": now}
+		if telemetry.BatteryLevel != nil {
+			updates["battery_level"] = *telemetry.BatteryLevel
+		}
+		if telemetry.Latitude != nil && telemetry.Longitude != nil {
+			updates["location_latitude"] = *telemetry.Latitude
+			updates["location_longitude"] = *telemetry.Longitude
+		}
+		if telemetry.LockStatus != "" {
+			updates["lock_status"] = telemetry.LockStatus
+		}
+		if err := tx.Model(&motor).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if telemetry.LockStatus != "" {
+			commandType := models.CommandUnlock
+			if telemetry.LockStatus == modelMotor.Locked {
+				commandType = models.CommandLock
+			}
+			if err := tx.Model(&models.DeviceCommand{}).
+				Where("motorbike_id = ? AND status = ? AND type = ?", telemetry.MotorbikeID, models.CommandPending, commandType).
+				Updates(map[string]interface{}{"status": models.CommandAcked, "acked_at": now}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(device).Update("last_seen_at", now).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, events.Event{Type: events.BikeTelemetry, MotorbikeID: telemetry.MotorbikeID, Data: *telemetry})
+	if telemetry.LockStatus != "" && telemetry.LockStatus != previousLock {
+		publishLockEvent(ctx, s.events, telemetry.MotorbikeID, telemetry.LockStatus)
+	}
+
+	return nil
+}
+
+func (s *TelemetryService) GetTelemetryByMotorID(ctx context.Context, motorbikeID int, limit int) (*[]models.Telemetry, error) {
+	var telemetry []models.Telemetry
+	if err := s.DB.WithContext(ctx).Where("motorbike_id = ?", motorbikeID).
+		Order("recorded_at DESC").Limit(limit).
+		Find(&telemetry).Error; err != nil {
+		return nil, err
+	}
+
+	return &telemetry, nil
+}
+
+// publishLockEvent motorun yeni kilit durumuna göre kilit olayını yayınlar
+func publishLockEvent(ctx context.Context, bus *events.Bus, motorbikeID uint, lockStatus modelMotor.LockStatus) {
+	eventType := events.BikeUnlocked
+	if lockStatus == modelMotor.Locked {
+		eventType = events.BikeLocked
+	}
+	bus.Publish(ctx, events.Event{Type: eventType, MotorbikeID: motorbikeID})
+}
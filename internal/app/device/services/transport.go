@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"motorbike-rental-backend/internal/app/device/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+var ErrUnknownCommandTransport = errors.New("unknown command transport")
+
+// CommandResult cihazın komuta verdiği yanıt. Başarılı yanıtlarda LockStatus boşsa komutun hedef durumu kabul edilir.
+type CommandResult struct {
+	CommandID  int64
+	Success    bool
+	LockStatus modelMotor.LockStatus
+	Error      string
+}
+
+// ResultHandler cihazdan gelen yanıtları işleyen fonksiyon
+type ResultHandler func(ctx context.Context, result CommandResult) error
+
+// CommandTransport komutları motorun kilit modülüne ileten kanalların ortak arayüzü.
+// Send yalnızca komutu iletir, cihazın yanıtı OnResult ile verilen fonksiyona sonradan gelir.
+type CommandTransport interface {
+	Name() string
+	Send(ctx context.Context, command models.DeviceCommand) error
+	OnResult(handler ResultHandler)
+}
+
+// NewTransport DEVICE_TRANSPORT ayarına göre komut kanalını oluşturur
+func NewTransport(name string, simulatedDelay time.Duration) (CommandTransport, error) {
+	switch name {
+	case "simulated":
+		return NewSimulatedTransport(simulatedDelay), nil
+	case "device-api":
+		return NewPollTransport(), nil
+	default:
+		return nil, ErrUnknownCommandTransport
+	}
+}
@@ -0,0 +1,39 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/device/models"
+	"time"
+)
+
+// Admin tarafından komut göndermek için view model
+type CommandCreateVM struct {
+	Type string `json:"type" validate:"required,oneof=lock unlock"`
+}
+
+// Cihaz komutu detayları için view model
+type CommandDetailVM struct {
+	ID          int64      `json:"id"`
+	MotorbikeID uint       `json:"motorbike_id"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	Transport   string     `json:"transport"`
+	RequestedBy uint       `json:"requested_by"`
+	Error       string     `json:"error"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AckedAt     *time.Time `json:"acked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (vm CommandDetailVM) ToViewModel(m models.DeviceCommand) CommandDetailVM {
+	vm.ID = m.ID
+	vm.MotorbikeID = m.MotorbikeID
+	vm.Type = m.Type.String()
+	vm.Status = m.Status.String()
+	vm.Transport = m.Transport
+	vm.RequestedBy = m.RequestedBy
+	vm.Error = m.Error
+	vm.ExpiresAt = m.ExpiresAt
+	vm.AckedAt = m.AckedAt
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
@@ -0,0 +1,158 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/device/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"strings"
+	"time"
+)
+
+// Cihaz kaydı için view model, motor verilirse cihaz kayıtla birlikte motora takılır
+type DeviceCreateVM struct {
+	Serial          string `json:"serial" validate:"required,max=64"`
+	MACAddress      string `json:"mac_address" validate:"omitempty,mac"`
+	FirmwareVersion string `json:"firmware_version" validate:"max=32"`
+	MotorbikeID     *uint  `json:"motorbike_id" validate:"omitempty,gt=0"`
+}
+
+func (vm DeviceCreateVM) ToDBModel() models.Device {
+	return models.Device{
+		Serial:          strings.TrimSpace(vm.Serial),
+		MACAddress:      strings.ToUpper(vm.MACAddress),
+		FirmwareVersion: strings.TrimSpace(vm.FirmwareVersion),
+		MotorbikeID:     vm.MotorbikeID,
+	}
+}
+
+// Cihazın donanım bilgilerini güncellemek için view model
+type DeviceUpdateVM struct {
+	MACAddress      string `json:"mac_address" validate:"omitempty,mac"`
+	FirmwareVersion string `json:"firmware_version" validate:"max=32"`
+}
+
+func (vm DeviceUpdateVM) ToDBModel(m models.Device) models.Device {
+	m.MACAddress = strings.ToUpper(vm.MACAddress)
+	m.FirmwareVersion = strings.TrimSpace(vm.FirmwareVersion)
+	return m
+}
+
+// Cihazı motora takmak için view model
+type DeviceInstallVM struct {
+	MotorbikeID uint `json:"motorbike_id" validate:"required,gt=0"`
+}
+
+// Cihazı sökmek veya kullanımdan kaldırmak için view model
+type DeviceRemoveVM struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+// Motordaki cihazı depodaki başka bir cihazla değiştirmek için view model
+type DeviceSwapVM struct {
+	NewDeviceID int    `json:"new_device_id" validate:"required,gt=0"`
+	Reason      string `json:"reason" validate:"max=255"`
+}
+
+// Cihaz detayları için view model, API ve eşleştirme anahtarları dönmez
+type DeviceDetailVM struct {
+	ID              int64      `json:"id"`
+	Serial          string     `json:"serial"`
+	MACAddress      string     `json:"mac_address"`
+	FirmwareVersion string     `json:"firmware_version"`
+	State           string     `json:"state"`
+	MotorbikeID     *uint      `json:"motorbike_id"`
+	LastSeenAt      *time.Time `json:"last_seen_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (vm DeviceDetailVM) ToViewModel(m models.Device) DeviceDetailVM {
+	vm.ID = m.ID
+	vm.Serial = m.Serial
+	vm.MACAddress = m.MACAddress
+	vm.FirmwareVersion = m.FirmwareVersion
+	vm.State = m.State.String()
+	vm.MotorbikeID = m.MotorbikeID
+	vm.LastSeenAt = m.LastSeenAt
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
+
+// Cihazın kurulum geçmişi için view model
+type InstallationDetailVM struct {
+	ID          int64      `json:"id"`
+	DeviceID    uint       `json:"device_id"`
+	Serial      string     `json:"serial"`
+	MotorbikeID uint       `json:"motorbike_id"`
+	InstalledAt time.Time  `json:"installed_at"`
+	InstalledBy *uint      `json:"installed_by"`
+	RemovedAt   *time.Time `json:"removed_at"`
+	RemovedBy   *uint      `json:"removed_by"`
+	Reason      string     `json:"reason"`
+}
+
+func (vm InstallationDetailVM) ToViewModel(m models.DeviceInstallation) InstallationDetailVM {
+	vm.ID = m.ID
+	vm.DeviceID = m.DeviceID
+	vm.Serial = m.Device.Serial
+	vm.MotorbikeID = m.MotorbikeID
+	vm.InstalledAt = m.InstalledAt
+	vm.InstalledBy = m.InstalledBy
+	vm.RemovedAt = m.RemovedAt
+	vm.RemovedBy = m.RemovedBy
+	vm.Reason = m.Reason
+	return vm
+}
+
+// Cihazın gönderdiği heartbeat için view model, gönderilmeyen alanlar değiştirilmez
+type HeartbeatVM struct {
+	LockStatus   string     `json:"lock_status" validate:"omitempty,oneof=locked unlocked"`
+	BatteryLevel *float64   `json:"battery_level" validate:"omitempty,gte=0,lte=100"`
+	OdometerKm   *float64   `json:"odometer_km" validate:"omitempty,gte=0"`
+	Latitude     *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude    *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	RecordedAt   *time.Time `json:"recorded_at"` // verilmezse sunucu zamanı
+}
+
+func (vm HeartbeatVM) ToDBModel() models.Telemetry {
+	telemetry := models.Telemetry{
+		LockStatus:   modelMotor.LockStatus(vm.LockStatus),
+		BatteryLevel: vm.BatteryLevel,
+		OdometerKm:   vm.OdometerKm,
+		Latitude:     vm.Latitude,
+		Longitude:    vm.Longitude,
+	}
+	if vm.RecordedAt != nil {
+		telemetry.RecordedAt = vm.RecordedAt.UTC()
+	}
+	return telemetry
+}
+
+// Cihazın komut sonucunu bildirmesi için view model
+type CommandResultVM struct {
+	Success    bool   `json:"success"`
+	LockStatus string `json:"lock_status" validate:"omitempty,oneof=locked unlocked"`
+	Error      string `json:"error" validate:"max=255"`
+}
+
+// Telemetri kaydı için view model
+type TelemetryDetailVM struct {
+	ID           int64     `json:"id"`
+	DeviceID     uint      `json:"device_id"`
+	LockStatus   string    `json:"lock_status"`
+	BatteryLevel *float64  `json:"battery_level"`
+	OdometerKm   *float64  `json:"odometer_km"`
+	Latitude     *float64  `json:"latitude"`
+	Longitude    *float64  `json:"longitude"`
+	RecordedAt   time.Time `json:"recorded_at"`
+}
+
+func (vm TelemetryDetailVM) ToViewModel(m models.Telemetry) TelemetryDetailVM {
+	vm.ID = m.ID
+	vm.DeviceID = m.DeviceID
+	vm.LockStatus = string(m.LockStatus)
+	vm.BatteryLevel = m.BatteryLevel
+	vm.OdometerKm = m.OdometerKm
+	vm.Latitude = m.Latitude
+	vm.Longitude = m.Longitude
+	vm.RecordedAt = m.RecordedAt
+	return vm
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/dispute/models"
+	disputeService "motorbike-rental-backend/internal/app/dispute/services"
+	"motorbike-rental-backend/internal/app/dispute/viewmodels"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/log"
+	"motorbike-rental-backend/pkg/storage"
+	"motorbike-rental-backend/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DisputeHandler struct {
+	disputeService disputeService.IDisputeService
+	rideService    rideService.IRideService
+	paymentService paymentService.IPaymentService
+	uploader       storage.Uploader
+}
+
+func NewDisputeHandler(s disputeService.IDisputeService, r rideService.IRideService, p paymentService.IPaymentService, u storage.Uploader) DisputeHandler {
+	return DisputeHandler{disputeService: s, rideService: r, paymentService: p, uploader: u}
+}
+
+// kullanıcı bitmiş sürüşüne itiraz açar -> /ride/:id/dispute (multipart: reason, isteğe bağlı photo)
+func (h DisputeHandler) OpenDispute(ctx *app.Ctx) error {
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	reason := strings.TrimSpace(ctx.FormValue("reason"))
+	if reason == "" || len(reason) > 1000 {
+		return errorsx.BadRequestError("Lütfen itiraz nedenini yazın (en fazla 1000 karakter)!")
+	}
+
+	dispute := models.Dispute{RideID: uint(ride.ID), UserID: ride.UserID, Reason: reason}
+
+	// fotoğraf zorunlu değil
+	if photo, err := ctx.FormFile("photo"); err == nil {
+		photoRef, err := h.uploader.Image(ctx.Context(), "disputes/"+strconv.Itoa(int(ride.ID)), photo)
+		if err != nil {
+			if storage.IsInvalidUpload(err) {
+				return errorsx.BadRequestError("Fotoğraf JPEG, PNG veya WebP olmalı ve boyut sınırını aşmamalı!")
+			}
+			return errorsx.InternalError(err, "Fotoğraf kaydedilemedi!")
+		}
+		dispute.PhotoURL = photoRef
+	}
+
+	if err = h.disputeService.OpenDispute(ctx.Context(), &dispute); err != nil {
+		if errorsx.Is(err, disputeService.ErrDisputeAlreadyOpen) {
+			return errorsx.ConflictError("Bu sürüş için zaten açık bir itiraz var!")
+		}
+		if errorsx.Is(err, disputeService.ErrRideNotDisputable) {
+			return errorsx.BadRequestError("Yalnızca bitmiş sürüşlere itiraz edilebilir!")
+		}
+		return errorsx.InternalError(err, "İtiraz oluşturulamadı!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "İtirazınız alındı!", "dispute": viewmodels.DisputeDetailVM{}.ToViewModel(dispute)})
+}
+
+// kullanıcının sürüşüne ait itirazları ve durumlarını döner -> /rides/:id/disputes
+func (h DisputeHandler) GetRideDisputes(ctx *app.Ctx) error {
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	disputes, err := h.disputeService.GetDisputesByRideID(ctx.Context(), int(ride.ID))
+	if err != nil {
+		return errorsx.InternalError(err, "İtirazlar getirilemedi!")
+	}
+
+	var disputeDetails []viewmodels.DisputeDetailVM
+	for _, dispute := range *disputes {
+		disputeDetails = append(disputeDetails, viewmodels.DisputeDetailVM{}.ToViewModel(dispute))
+	}
+
+	return ctx.SuccessResponse(disputeDetails, len(disputeDetails))
+}
+
+// (adminler için) itirazları döner -> /disputes?status=open
+func (h DisputeHandler) GetAllDisputes(ctx *app.Ctx) error {
+	disputes, err := h.disputeService.GetDisputes(ctx.Context(), ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "İtirazlar getirilemedi!")
+	}
+
+	var disputeDetails []viewmodels.DisputeDetailVM
+	for _, dispute := range *disputes {
+		disputeDetails = append(disputeDetails, viewmodels.DisputeDetailVM{}.ToViewModel(dispute))
+	}
+
+	return ctx.SuccessResponse(disputeDetails, len(disputeDetails))
+}
+
+// (adminler için) itirazı onaylar ve tutarın tamamını veya bir kısmını iade eder.
+// İade Ride.Cost değiştirilmeden sürüşe düzeltme kaydı olarak yazılır; ödeme alınamamış sürüşte
+// para iadesi yapılmaz, yalnızca kullanıcının borcu düşer.
+func (h DisputeHandler) ApproveDispute(ctx *app.Ctx) error {
+	var vm viewmodels.DisputeApproveVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	dispute, ride, err := h.getOpenDispute(ctx)
+	if err != nil {
+		return err
+	}
+
+	amount := ride.TotalCost()
+	if vm.Amount != nil {
+		amount = *vm.Amount
+	}
+	if amount <= 0 || amount > ride.TotalCost() {
+		return errorsx.BadRequestError("İade tutarı sürüşün güncel ücretini aşamaz!")
+	}
+
+	adminID := uint(ctx.GetUserID())
+	now := time.Now().UTC()
+	dispute.Status = models.DisputeApproved
+	dispute.RefundAmount = amount
+	dispute.AdminNote = vm.Note
+	dispute.ResolvedBy = &adminID
+	dispute.ResolvedAt = &now
+
+	// itiraz önce kapatılır, böylece aynı itiraz için eşzamanlı iki onayda iade iki kez yapılmaz
+	if err = h.disputeService.ResolveDispute(ctx.Context(), dispute); err != nil {
+		if errorsx.Is(err, disputeService.ErrDisputeClosed) {
+			return errorsx.ConflictError("Bu itiraz zaten sonuçlandırıldı!")
+		}
+		return errorsx.InternalError(err, "İtiraz sonuçlandırılamadı!")
+	}
+
+	reason := "İtiraz #" + strconv.Itoa(int(dispute.ID))
+	if vm.Note != "" {
+		reason += ": " + vm.Note
+	}
+
+	// düzeltme kaydı iadeden önce yazılır, iade hiç yapılamazsa kayıt geri alınır ve itiraz tekrar açılır
+	disputeID := uint(dispute.ID)
+	adjustment := modelRide.RideAdjustment{
+		RideID:    uint(ride.ID),
+		Type:      modelRide.RideAdjustmentRefund,
+		Amount:    -amount,
+		Reason:    reason,
+		DisputeID: &disputeID,
+		CreatedBy: adminID,
+	}
+	if err = h.rideService.AddAdjustment(ctx.Context(), &adjustment); err != nil {
+		h.reopenDispute(ctx, dispute.ID)
+		if errorsx.Is(err, rideService.ErrAdjustmentExceedsCost) {
+			return errorsx.BadRequestError("İade tutarı sürüşün güncel ücretini aşamaz!")
+		}
+		return errorsx.InternalError(err, "Düzeltme kaydı yazılamadı!")
+	}
+
+	refunded, err := h.paymentService.RefundRide(ctx.Context(), uint(ride.ID), amount)
+	if err != nil && refunded == 0 {
+		if revertErr := h.rideService.RevertAdjustment(ctx.Context(), &adjustment); revertErr != nil {
+			l := log.GetLogger("")
+			l.Error("İadesi yapılamayan itiraz düzeltmesi geri alınamadı", zap.Int64("adjustment_id", adjustment.ID), zap.Error(revertErr))
+		}
+		h.reopenDispute(ctx, dispute.ID)
+		return errorsx.InternalError(err, "İade yapılamadı!")
+	}
+	if err = h.rideService.SetAdjustmentRefund(ctx.Context(), adjustment.ID, refunded); err != nil {
+		return errorsx.InternalError(err, "İade yapıldı fakat iade tutarı kaydedilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İtiraz onaylandı!", "refund_amount": amount, "refunded_to_payment": refunded})
+}
+
+// (adminler için) itirazı reddeder, sürüşün ücreti değişmez
+func (h DisputeHandler) RejectDispute(ctx *app.Ctx) error {
+	var vm viewmodels.DisputeRejectVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	dispute, _, err := h.getOpenDispute(ctx)
+	if err != nil {
+		return err
+	}
+
+	adminID := uint(ctx.GetUserID())
+	now := time.Now().UTC()
+	dispute.Status = models.DisputeRejected
+	dispute.AdminNote = vm.Note
+	dispute.ResolvedBy = &adminID
+	dispute.ResolvedAt = &now
+
+	if err = h.disputeService.ResolveDispute(ctx.Context(), dispute); err != nil {
+		if errorsx.Is(err, disputeService.ErrDisputeClosed) {
+			return errorsx.ConflictError("Bu itiraz zaten sonuçlandırıldı!")
+		}
+		return errorsx.InternalError(err, "İtiraz sonuçlandırılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İtiraz reddedildi!"})
+}
+
+// getMyRide URL'deki sürüşü getirir ve giriş yapan kullanıcıya ait olduğunu kontrol eder
+func (h DisputeHandler) getMyRide(ctx *app.Ctx) (*modelRide.Ride, error) {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return nil, errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	ride, err := h.rideService.GetRideByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorsx.NotFoundError("Sürüş bulunamadı!")
+		}
+		return nil, errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	if ride.UserID != uint(ctx.GetUserID()) {
+		return nil, errorsx.ForbiddenError("Bu sürüş size ait değil!")
+	}
+
+	return ride, nil
+}
+
+// getOpenDispute URL'deki itirazı ve sürüşünü getirir, itiraz açık değilse hata döner
+func (h DisputeHandler) getOpenDispute(ctx *app.Ctx) (*models.Dispute, *modelRide.Ride, error) {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return nil, nil, errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	dispute, err := h.disputeService.GetDisputeByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errorsx.NotFoundError("İtiraz bulunamadı!")
+		}
+		return nil, nil, errorsx.InternalError(err, "İtiraz getirilirken hata oluştu!")
+	}
+
+	if dispute.Status != models.DisputeOpen {
+		return nil, nil, errorsx.ConflictError("Bu itiraz zaten sonuçlandırıldı!")
+	}
+
+	ride, err := h.rideService.GetRideByID(ctx.Context(), int(dispute.RideID))
+	if err != nil {
+		return nil, nil, errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	return dispute, ride, nil
+}
+
+// reopenDispute onay yarıda kaldığında itirazı tekrar açar, açılamazsa itiraz kapalı kalır ve hata loglanır
+func (h DisputeHandler) reopenDispute(ctx *app.Ctx, id int64) {
+	if err := h.disputeService.ReopenDispute(ctx.Context(), id); err != nil {
+		l := log.GetLogger("")
+		l.Error("İtiraz tekrar açılamadı", zap.Int64("dispute_id", id), zap.Error(err))
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type DisputeStatus string
+
+const (
+	DisputeOpen     DisputeStatus = "open"
+	DisputeApproved DisputeStatus = "approved" // iade yapıldı (tamamı veya bir kısmı)
+	DisputeRejected DisputeStatus = "rejected"
+)
+
+// Dispute kullanıcının bitmiş sürüşüne itirazı. İtiraz açıkken sürüş 'disputed' durumundadır,
+// sonuçlandığında tekrar 'finished' olur. Onaylanan iade sürüşe RideAdjustment olarak yazılır.
+type Dispute struct {
+	BaseModel
+	RideID       uint          `gorm:"not null"`
+	UserID       uint          `gorm:"not null"`
+	Reason       string        `gorm:"type:varchar(1000);not null"`
+	PhotoURL     string        `gorm:"type:varchar(255)"`
+	Status       DisputeStatus `gorm:"type:varchar(20);not null"`
+	RefundAmount float64       `gorm:"not null"` // onaylanan iade tutarı
+	AdminNote    string        `gorm:"type:varchar(500)"`
+	ResolvedBy   *uint
+	ResolvedAt   *time.Time
+}
+
+func (Dispute) TableName() string {
+	return "disputes"
+}
+
+func (s DisputeStatus) String() string {
+	switch s {
+	case DisputeOpen:
+		return "open"
+	case DisputeApproved:
+		return "approved"
+	case DisputeRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
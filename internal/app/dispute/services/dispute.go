@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/dispute/models"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+)
+
+var (
+	ErrRideNotDisputable  = errors.New("only finished rides can be disputed")
+	ErrDisputeAlreadyOpen = errors.New("ride already has an open dispute")
+	ErrDisputeClosed      = errors.New("dispute is already resolved")
+)
+
+type IDisputeService interface {
+	GetDisputes(ctx context.Context, status string) (*[]models.Dispute, error)
+	GetDisputeByID(ctx context.Context, id int) (*models.Dispute, error)
+	GetDisputesByRideID(ctx context.Context, rideID int) (*[]models.Dispute, error)
+	OpenDispute(ctx context.Context, dispute *models.Dispute) error
+	ResolveDispute(ctx context.Context, dispute *models.Dispute) error
+	ReopenDispute(ctx context.Context, id int64) error
+}
+
+type DisputeService struct {
+	DB *gorm.DB
+}
+
+func NewDisputeService(db *gorm.DB) IDisputeService {
+	return &DisputeService{DB: db}
+}
+
+// GetDisputes itirazları döner, status boşsa tümü
+func (s *DisputeService) GetDisputes(ctx context.Context, status string) (*[]models.Dispute, error) {
+	query := s.DB.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var disputes []models.Dispute
+	if err := query.Order("id").Find(&disputes).Error; err != nil {
+		return nil, err
+	}
+
+	return &disputes, nil
+}
+
+func (s *DisputeService) GetDisputeByID(ctx context.Context, id int) (*models.Dispute, error) {
+	var dispute models.Dispute
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&dispute).Error; err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+func (s *DisputeService) GetDisputesByRideID(ctx context.Context, rideID int) (*[]models.Dispute, error) {
+	var disputes []models.Dispute
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("id").Find(&disputes).Error; err != nil {
+		return nil, err
+	}
+
+	return &disputes, nil
+}
+
+// OpenDispute sürüş satırını kilitleyip sürüşü 'disputed' durumuna alır ve itirazı kaydeder.
+// Sürüşün aynı anda yalnızca bir açık itirazı olabilir.
+func (s *DisputeService) OpenDispute(ctx context.Context, dispute *models.Dispute) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ride modelRide.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", dispute.RideID).First(&ride).Error; err != nil {
+			return err
+		}
+
+		switch ride.Status {
+		case modelRide.RideFinished:
+		case modelRide.RideDisputed:
+			return ErrDisputeAlreadyOpen
+		default:
+			return ErrRideNotDisputable
+		}
+
+		if err := tx.Model(&ride).Update("status", modelRide.RideDisputed).Error; err != nil {
+			return err
+		}
+
+		dispute.Status = models.DisputeOpen
+		return tx.Create(dispute).Error
+	})
+}
+
+// ResolveDispute açık itirazı kilitleyip sonucunu yazar ve sürüşü tekrar 'finished' yapar.
+// İtiraz başka bir istekle sonuçlandırıldıysa ErrDisputeClosed döner, böylece aynı itiraz için iki kez iade yapılmaz.
+func (s *DisputeService) ResolveDispute(ctx context.Context, dispute *models.Dispute) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Dispute
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", dispute.ID).First(&current).Error; err != nil {
+			return err
+		}
+
+		if current.Status != models.DisputeOpen {
+			return ErrDisputeClosed
+		}
+
+		if err := tx.Model(&current).Updates(map[string]interface{}{
+			"status":        dispute.Status,
+			"refund_amount": dispute.RefundAmount,
+			"admin_note":    dispute.AdminNote,
+			"resolved_by":   dispute.ResolvedBy,
+			"resolved_at":   dispute.ResolvedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&modelRide.Ride{}).
+			Where("id = ? AND status = ?", current.RideID, modelRide.RideDisputed).
+			Update("status", modelRide.RideFinished).Error
+	})
+}
+
+// ReopenDispute onaylanan itirazın iadesi yapılamadığında itirazı tekrar açar
+func (s *DisputeService) ReopenDispute(ctx context.Context, id int64) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dispute models.Dispute
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&dispute).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&dispute).Updates(map[string]interface{}{
+			"status":        models.DisputeOpen,
+			"refund_amount": 0,
+			"resolved_by":   nil,
+			"resolved_at":   nil,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&modelRide.Ride{}).
+			Where("id = ? AND status = ?", dispute.RideID, modelRide.RideFinished).
+			Update("status", modelRide.RideDisputed).Error
+	})
+}
@@ -0,0 +1,46 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/dispute/models"
+	"motorbike-rental-backend/pkg/storage"
+	"time"
+)
+
+// İtiraz onayı için view model, tutar verilmezse sürüşün güncel ücretinin tamamı iade edilir
+type DisputeApproveVM struct {
+	Amount *float64 `json:"amount" validate:"omitempty,gt=0"`
+	Note   string   `json:"note" validate:"max=500"`
+}
+
+// İtiraz reddi için view model
+type DisputeRejectVM struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
+
+// İtiraz detayları için view model
+type DisputeDetailVM struct {
+	ID           int64      `json:"id"`
+	RideID       uint       `json:"ride_id"`
+	UserID       uint       `json:"user_id"`
+	Reason       string     `json:"reason"`
+	PhotoURL     string     `json:"photo_url"`
+	Status       string     `json:"status"`
+	RefundAmount float64    `json:"refund_amount"`
+	AdminNote    string     `json:"admin_note"`
+	ResolvedAt   *time.Time `json:"resolved_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (vm DisputeDetailVM) ToViewModel(m models.Dispute) DisputeDetailVM {
+	vm.ID = m.ID
+	vm.RideID = m.RideID
+	vm.UserID = m.UserID
+	vm.Reason = m.Reason
+	vm.PhotoURL = storage.URL(m.PhotoURL)
+	vm.Status = m.Status.String()
+	vm.RefundAmount = m.RefundAmount
+	vm.AdminNote = m.AdminNote
+	vm.ResolvedAt = m.ResolvedAt
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
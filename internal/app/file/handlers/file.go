@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/storage"
+	"net/url"
+	"time"
+)
+
+// FileHandler yerel depodaki dosyaları imzalı adreslerle sunar, S3'te dosyalar doğrudan ön imzalı adresten indirilir
+type FileHandler struct {
+	store *storage.LocalStore
+}
+
+func NewFileHandler(s *storage.LocalStore) FileHandler {
+	return FileHandler{store: s}
+}
+
+// imzalı indirme adresi -> /files/<key>?expires=&signature= (JWT gerekmez, yetki imzadadır)
+func (h FileHandler) Download(ctx *app.Ctx) error {
+	key, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.store.Verify(key, ctx.Query("expires"), ctx.Query("signature"), time.Now()); err != nil {
+		if errorsx.Is(err, storage.ErrURLExpired) {
+			return errorsx.ForbiddenError("İndirme bağlantısının süresi dolmuş!")
+		}
+		return errorsx.ForbiddenError("Geçersiz indirme bağlantısı!")
+	}
+
+	body, object, err := h.store.Get(ctx.Context(), key)
+	if err != nil {
+		if errorsx.Is(err, storage.ErrNotFound) {
+			return errorsx.NotFoundError("Dosya bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Dosya getirilemedi!")
+	}
+
+	ctx.Set(fiber.HeaderContentType, object.ContentType)
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return ctx.SendStream(body, int(object.Size))
+}
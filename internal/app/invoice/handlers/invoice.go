@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/invoice/models"
+	invoiceService "motorbike-rental-backend/internal/app/invoice/services"
+	"motorbike-rental-backend/internal/app/invoice/viewmodels"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type InvoiceHandler struct {
+	invoiceService invoiceService.IInvoiceService
+	rideService    rideService.IRideService
+}
+
+func NewInvoiceHandler(s invoiceService.IInvoiceService, r rideService.IRideService) InvoiceHandler {
+	return InvoiceHandler{invoiceService: s, rideService: r}
+}
+
+// kullanıcının bitmiş sürüşünün fişini döner -> /rides/:id/receipt (JSON) veya /rides/:id/receipt?format=pdf
+func (h InvoiceHandler) GetRideReceipt(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	ride, err := h.rideService.GetRideByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Sürüş bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	if ride.UserID != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu sürüş size ait değil!")
+	}
+
+	invoice, err := h.invoiceService.GetInvoiceByRideID(ctx.Context(), id)
+	if errorsx.Is(err, gorm.ErrRecordNotFound) {
+		// fiş sistemi öncesi bitmiş sürüşler için ücret dökümü olmadan tek kalemli fiş kesilir
+		invoice, err = h.invoiceService.IssueRideInvoice(ctx.Context(), *ride, []models.InvoiceLine{
+			{Description: "Sürüş ücreti", Quantity: 1, UnitPrice: ride.Cost, Amount: ride.Cost},
+		})
+	}
+	if err != nil {
+		if errorsx.Is(err, invoiceService.ErrRideNotFinished) {
+			return errorsx.ConflictError("Sürüş henüz bitmedi!")
+		}
+		return errorsx.InternalError(err, "Fiş oluşturulamadı!")
+	}
+
+	if ctx.Query("format") == "pdf" {
+		ctx.Set(fiber.HeaderContentType, "application/pdf")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="fis-%s.pdf"`, invoice.Number))
+		return ctx.Status(fiber.StatusOK).Send(h.invoiceService.RenderPDF(*invoice))
+	}
+
+	return ctx.SuccessResponse(viewmodels.InvoiceDetailVM{}.ToViewModel(*invoice), 1)
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Invoice bitmiş bir sürüşün numaralı fişi. Numara yıl bazında boşluksuz artar (2026-000001 gibi).
+// Tutarlar KDV dahildir, Subtotal ve VATAmount toplamdan geriye hesaplanır.
+type Invoice struct {
+	BaseModel
+	Number          string    `gorm:"type:varchar(20);not null;uniqueIndex"`
+	Year            int       `gorm:"not null"`
+	Sequence        int       `gorm:"not null"`
+	RideID          uint      `gorm:"not null;uniqueIndex"`
+	UserID          uint      `gorm:"not null"`
+	IssuedAt        time.Time `gorm:"not null"`
+	StartTime       time.Time `gorm:"not null"`
+	EndTime         time.Time `gorm:"not null"`
+	DurationSeconds int       `gorm:"not null"`
+	DistanceMeters  float64   `gorm:"not null"`
+	Subtotal        float64   `gorm:"not null"` // KDV hariç
+	VATRate         float64   `gorm:"not null"` // 0.20 = %20
+	VATAmount       float64   `gorm:"not null"`
+	Total           float64   `gorm:"not null"` // KDV dahil, sürüşün Cost değeri
+	Currency        string    `gorm:"type:varchar(3);not null"`
+
+	Lines []InvoiceLine `gorm:"foreignKey:InvoiceID"`
+}
+
+// InvoiceLine fişteki ücret kalemi (kilit açma, dakika ücreti, indirimler vb.), tutarlar KDV dahil
+type InvoiceLine struct {
+	BaseModel
+	InvoiceID   uint    `gorm:"not null"`
+	Description string  `gorm:"type:varchar(255);not null"`
+	Quantity    float64 `gorm:"not null"`
+	UnitPrice   float64 `gorm:"not null"`
+	Amount      float64 `gorm:"not null"`
+}
+
+// InvoiceSequence yıl bazında son verilen fatura numarası, fatura ile aynı transaction içinde kilitlenerek artırılır
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+func (InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
+
+func (InvoiceSequence) TableName() string {
+	return "invoice_sequences"
+}
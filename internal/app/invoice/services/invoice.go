@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math"
+	"motorbike-rental-backend/internal/app/invoice/models"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+	"strconv"
+	"time"
+)
+
+var ErrRideNotFinished = errors.New("ride is not finished")
+
+type IInvoiceService interface {
+	GetInvoiceByRideID(ctx context.Context, rideID int) (*models.Invoice, error)
+	IssueRideInvoice(ctx context.Context, ride modelRide.Ride, lines []models.InvoiceLine) (*models.Invoice, error)
+	RenderPDF(invoice models.Invoice) []byte
+}
+
+type InvoiceService struct {
+	DB       *gorm.DB
+	vatRate  float64
+	location *time.Location
+}
+
+func NewInvoiceService(db *gorm.DB, vatRate float64, timezone string) IInvoiceService {
+	// fatura yılı yerel saate göre belirlenir (31 Aralık gecesi biten sürüşler)
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.FixedZone("TRT", 3*60*60)
+	}
+	return &InvoiceService{DB: db, vatRate: vatRate, location: loc}
+}
+
+func (s *InvoiceService) GetInvoiceByRideID(ctx context.Context, rideID int) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := s.DB.WithContext(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("ride_id = ?", rideID).First(&invoice).Error; err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// IssueRideInvoice bitmiş sürüş için fiş keser. Sürüşün zaten fişi varsa onu döner.
+// Numara invoice_sequences satırı kilitlenerek fatura kaydıyla aynı transaction içinde alınır;
+// transaction geri alınırsa numara da geri alınır, böylece yıl içinde numaralarda boşluk oluşmaz.
+func (s *InvoiceService) IssueRideInvoice(ctx context.Context, ride modelRide.Ride, lines []models.InvoiceLine) (*models.Invoice, error) {
+	if ride.Status != modelRide.RideFinished || ride.EndTime == nil {
+		return nil, ErrRideNotFinished
+	}
+
+	if existing, err := s.GetInvoiceByRideID(ctx, int(ride.ID)); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	issuedAt := time.Now().UTC()
+	year := issuedAt.In(s.location).Year()
+	duration, _ := strconv.Atoi(ride.Duration)
+
+	invoice := models.Invoice{
+		Year:            year,
+		RideID:          uint(ride.ID),
+		UserID:          ride.UserID,
+		IssuedAt:        issuedAt,
+		StartTime:       ride.StartTime,
+		EndTime:         *ride.EndTime,
+		DurationSeconds: duration,
+		DistanceMeters:  ride.DistanceMeters,
+		VATRate:         s.vatRate,
+		Total:           round2(ride.Cost),
+		Currency:        "TRY",
+		Lines:           lines,
+	}
+	invoice.Subtotal = round2(invoice.Total / (1 + s.vatRate))
+	invoice.VATAmount = round2(invoice.Total - invoice.Subtotal)
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.InvoiceSequence{Year: year}).Error; err != nil {
+			return err
+		}
+
+		var sequence models.InvoiceSequence
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("year = ?", year).First(&sequence).Error; err != nil {
+			return err
+		}
+
+		sequence.LastNumber++
+		if err := tx.Model(&sequence).Update("last_number", sequence.LastNumber).Error; err != nil {
+			return err
+		}
+
+		invoice.Sequence = sequence.LastNumber
+		invoice.Number = fmt.Sprintf("%d-%06d", year, sequence.LastNumber)
+		return tx.Create(&invoice).Error
+	})
+	if err != nil {
+		// aynı sürüş için eşzamanlı istek fişi önce kestiyse onu döner
+		if existing, getErr := s.GetInvoiceByRideID(ctx, int(ride.ID)); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// QuoteLines ücret dökümünü fiş kalemlerine çevirir, kalemlerin toplamı sürüşün Cost değerine eşittir
+func QuoteLines(q pricingService.Quote, ride modelRide.Ride) []models.InvoiceLine {
+	var lines []models.InvoiceLine
+	add := func(description string, quantity, unitPrice, amount float64) {
+		if amount == 0 {
+			return
+		}
+		lines = append(lines, models.InvoiceLine{Description: description, Quantity: quantity, UnitPrice: unitPrice, Amount: round2(amount)})
+	}
+
+	activeMinutes := float64(q.Minutes - q.PausedMinutes)
+	unitPrice := q.PerMinuteRate
+	if activeMinutes > 0 {
+		unitPrice = round2(q.TimeCost / activeMinutes) // çarpanlar uygulanmış ortalama dakika ücreti
+	}
+
+	add("Kilit açma ücreti", 1, q.UnlockFee, q.UnlockFee)
+	add("Sürüş süresi (dk)", activeMinutes, unitPrice, q.TimeCost)
+	add("Mola süresi (dk)", float64(q.PausedMinutes), q.PausedRate, q.PausedCost)
+	add("Günlük tavan indirimi", 1, -q.CapDiscount, -q.CapDiscount)
+	add("Minimum ücret tamamlama", 1, q.MinimumTopUp, q.MinimumTopUp)
+	add("Abonelik paketi indirimi", 1, -ride.PassDiscount, -ride.PassDiscount)
+	add("Kampanya indirimi", 1, -ride.Discount, -ride.Discount)
+	add("Park bölgesi ek ücreti", 1, ride.ParkingSurcharge, ride.ParkingSurcharge)
+
+	return lines
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"motorbike-rental-backend/internal/app/invoice/models"
+	"motorbike-rental-backend/pkg/pdf"
+	"time"
+)
+
+// RenderPDF fişi tek sayfalık PDF olarak çizer, saatler fatura saat dilimine göre yazılır
+func (s *InvoiceService) RenderPDF(invoice models.Invoice) []byte {
+	loc := s.location
+
+	doc := pdf.New()
+	const left, right = 50.0, pdf.PageWidth - 50
+	y := pdf.PageHeight - 60
+
+	doc.Text(left, y, pdf.Bold, 18, "Motorbike Rental - Sürüş Fişi")
+	y -= 28
+	doc.Text(left, y, pdf.Regular, 10, "Fiş No: "+invoice.Number)
+	doc.TextRight(right, y, pdf.Regular, 10, "Tarih: "+invoice.IssuedAt.In(loc).Format("02.01.2006 15:04"))
+	y -= 16
+	doc.Text(left, y, pdf.Regular, 10, fmt.Sprintf("Sürüş No: %d", invoice.RideID))
+	y -= 24
+
+	doc.Text(left, y, pdf.Regular, 10, "Başlangıç: "+invoice.StartTime.In(loc).Format("02.01.2006 15:04:05"))
+	y -= 14
+	doc.Text(left, y, pdf.Regular, 10, "Bitiş: "+invoice.EndTime.In(loc).Format("02.01.2006 15:04:05"))
+	y -= 14
+	doc.Text(left, y, pdf.Regular, 10, "Süre: "+formatDuration(invoice.DurationSeconds))
+	if invoice.DistanceMeters > 0 {
+		y -= 14
+		doc.Text(left, y, pdf.Regular, 10, fmt.Sprintf("Mesafe: %.2f km", invoice.DistanceMeters/1000))
+	}
+	y -= 28
+
+	doc.Text(left, y, pdf.Bold, 10, "Kalem")
+	doc.TextRight(right-160, y, pdf.Bold, 10, "Miktar")
+	doc.TextRight(right-80, y, pdf.Bold, 10, "Birim")
+	doc.TextRight(right, y, pdf.Bold, 10, "Tutar")
+	y -= 6
+	doc.Line(left, y, right, y)
+	y -= 14
+
+	for _, line := range invoice.Lines {
+		doc.Text(left, y, pdf.Regular, 10, line.Description)
+		doc.TextRight(right-160, y, pdf.Regular, 10, formatQuantity(line.Quantity))
+		doc.TextRight(right-80, y, pdf.Regular, 10, formatMoney(line.UnitPrice))
+		doc.TextRight(right, y, pdf.Regular, 10, formatMoney(line.Amount))
+		y -= 14
+	}
+
+	y += 8
+	doc.Line(left, y, right, y)
+	y -= 16
+	doc.TextRight(right-80, y, pdf.Regular, 10, "Ara Toplam (KDV hariç)")
+	doc.TextRight(right, y, pdf.Regular, 10, formatMoney(invoice.Subtotal))
+	y -= 14
+	doc.TextRight(right-80, y, pdf.Regular, 10, fmt.Sprintf("KDV (%%%.0f)", invoice.VATRate*100))
+	doc.TextRight(right, y, pdf.Regular, 10, formatMoney(invoice.VATAmount))
+	y -= 16
+	doc.TextRight(right-80, y, pdf.Bold, 11, "Toplam")
+	doc.TextRight(right, y, pdf.Bold, 11, formatMoney(invoice.Total))
+
+	return doc.Bytes()
+}
+
+func formatMoney(v float64) string {
+	return fmt.Sprintf("%.2f TL", v)
+}
+
+func formatQuantity(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formatDuration(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, seconds%60)
+}
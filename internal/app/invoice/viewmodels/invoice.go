@@ -0,0 +1,57 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/invoice/models"
+	"time"
+)
+
+type InvoiceLineVM struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// Sürüş fişi için view model
+type InvoiceDetailVM struct {
+	Number          string          `json:"number"`
+	RideID          uint            `json:"ride_id"`
+	IssuedAt        time.Time       `json:"issued_at"`
+	StartTime       time.Time       `json:"start_time"`
+	EndTime         time.Time       `json:"end_time"`
+	DurationSeconds int             `json:"duration_seconds"`
+	DistanceMeters  float64         `json:"distance_m"`
+	Lines           []InvoiceLineVM `json:"lines"`
+	Subtotal        float64         `json:"subtotal"`
+	VATRate         float64         `json:"vat_rate"`
+	VATAmount       float64         `json:"vat_amount"`
+	Total           float64         `json:"total"`
+	Currency        string          `json:"currency"`
+}
+
+func (vm InvoiceDetailVM) ToViewModel(m models.Invoice) InvoiceDetailVM {
+	vm.Number = m.Number
+	vm.RideID = m.RideID
+	vm.IssuedAt = m.IssuedAt
+	vm.StartTime = m.StartTime
+	vm.EndTime = m.EndTime
+	vm.DurationSeconds = m.DurationSeconds
+	vm.DistanceMeters = m.DistanceMeters
+	vm.Subtotal = m.Subtotal
+	vm.VATRate = m.VATRate
+	vm.VATAmount = m.VATAmount
+	vm.Total = m.Total
+	vm.Currency = m.Currency
+
+	vm.Lines = []InvoiceLineVM{}
+	for _, line := range m.Lines {
+		vm.Lines = append(vm.Lines, InvoiceLineVM{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		})
+	}
+
+	return vm
+}
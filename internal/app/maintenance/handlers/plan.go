@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	maintenanceService "motorbike-rental-backend/internal/app/maintenance/services"
+	"motorbike-rental-backend/internal/app/maintenance/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+	"strconv"
+)
+
+type PlanHandler struct {
+	planService      maintenanceService.IPlanService
+	dueSoonThreshold float64
+}
+
+func NewPlanHandler(s maintenanceService.IPlanService, dueSoonThreshold float64) PlanHandler {
+	return PlanHandler{planService: s, dueSoonThreshold: dueSoonThreshold}
+}
+
+// (adminler için) periyodik bakım planlarını döner -> /maintenance-plans
+func (h PlanHandler) GetPlans(ctx *app.Ctx) error {
+	plans, err := h.planService.GetPlans(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Bakım planları getirilemedi!")
+	}
+
+	var details []viewmodels.PlanDetailVM
+	for _, plan := range *plans {
+		details = append(details, viewmodels.PlanDetailVM{}.ToViewModel(plan))
+	}
+
+	return ctx.SuccessResponse(details, len(details))
+}
+
+func (h PlanHandler) CreatePlan(ctx *app.Ctx) error {
+	var vm viewmodels.PlanCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	plan := vm.ToDBModel()
+	if err := h.planService.CreatePlan(ctx.Context(), &plan); err != nil {
+		return errorsx.InternalError(err, "Bakım planı oluşturulamadı!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Bakım planı oluşturuldu!", "plan": viewmodels.PlanDetailVM{}.ToViewModel(plan)})
+}
+
+func (h PlanHandler) UpdatePlan(ctx *app.Ctx) error {
+	var vm viewmodels.PlanUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	plan, err := h.planService.GetPlanByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Bakım planı bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Bakım planı getirilirken hata oluştu!")
+	}
+
+	updated := vm.ToDBModel(*plan)
+	if err = h.planService.UpdatePlan(ctx.Context(), &updated); err != nil {
+		return errorsx.InternalError(err, "Bakım planı güncellenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bakım planı güncellendi!"})
+}
+
+// (adminler için) planı siler, planın açtığı iş emirleri açık kalır
+func (h PlanHandler) DeletePlan(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.planService.DeletePlan(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Bakım planı bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Bakım planı silinemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bakım planı silindi!"})
+}
+
+// (adminler için) bakımı yaklaşan motorları döner -> /maintenance-plans/due-soon?threshold=0.9
+func (h PlanHandler) GetDueSoon(ctx *app.Ctx) error {
+	threshold := h.dueSoonThreshold
+	if raw := ctx.Query("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 {
+			return errorsx.BadRequestError("threshold sıfırdan büyük bir sayı olmalı!")
+		}
+		threshold = parsed
+	}
+
+	usages, err := h.planService.GetDueSoon(ctx.Context(), threshold)
+	if err != nil {
+		return errorsx.InternalError(err, "Bakımı yaklaşan motorlar getirilemedi!")
+	}
+
+	var report []viewmodels.PlanUsageVM
+	for _, usage := range *usages {
+		report = append(report, viewmodels.PlanUsageVM{}.ToViewModel(usage))
+	}
+
+	return ctx.SuccessResponse(report, len(report))
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/maintenance/models"
+	maintenanceService "motorbike-rental-backend/internal/app/maintenance/services"
+	"motorbike-rental-backend/internal/app/maintenance/viewmodels"
+	motorModel "motorbike-rental-backend/internal/app/motorbike/models"
+	motorService "motorbike-rental-backend/internal/app/motorbike/services"
+	motorViewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+	"strconv"
+	"strings"
+)
+
+type MaintenanceHandler struct {
+	maintenanceService maintenanceService.IMaintenanceService
+	motorService       motorService.IMotorService
+}
+
+func NewMaintenanceHandler(s maintenanceService.IMaintenanceService, m motorService.IMotorService) MaintenanceHandler {
+	return MaintenanceHandler{maintenanceService: s, motorService: m}
+}
+
+// (adminler için) iş emirlerini öncelik sırasıyla döner -> /work-orders?status=open
+func (h MaintenanceHandler) GetWorkOrders(ctx *app.Ctx) error {
+	workOrders, err := h.maintenanceService.GetWorkOrders(ctx.Context(), ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "İş emirleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toDetails(*workOrders), len(*workOrders))
+}
+
+func (h MaintenanceHandler) GetWorkOrder(ctx *app.Ctx) error {
+	workOrder, err := h.getWorkOrder(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ctx.SuccessResponse(viewmodels.WorkOrderDetailVM{}.ToViewModel(*workOrder), 1)
+}
+
+// (adminler için) motorun bakım geçmişini döner -> /motorbikes/:id/work-orders
+func (h MaintenanceHandler) GetMotorWorkOrders(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	workOrders, err := h.maintenanceService.GetWorkOrdersByMotorID(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "İş emirleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toDetails(*workOrders), len(*workOrders))
+}
+
+// (adminler için) teknisyene atanan iş emirlerini döner -> /technicians/:id/work-orders?status=in_progress
+func (h MaintenanceHandler) GetTechnicianWorkOrders(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	workOrders, err := h.maintenanceService.GetWorkOrdersByTechnicianID(ctx.Context(), id, ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "İş emirleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toDetails(*workOrders), len(*workOrders))
+}
+
+// (adminler için) iş emri açar, motor 'maintenance' durumuna geçer
+func (h MaintenanceHandler) OpenWorkOrder(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	adminID := uint(ctx.GetUserID())
+	workOrder := vm.ToDBModel()
+	workOrder.ReportedBy = &adminID
+
+	if err := h.maintenanceService.OpenWorkOrder(ctx.Context(), &workOrder); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Motor bulunamadı!")
+		}
+		if errorsx.Is(err, maintenanceService.ErrMotorbikeInUse) {
+			return errorsx.ConflictError("Motor kirada veya rezerve, sürüş/rezervasyon bitince iş emri açılabilir!")
+		}
+		if errorsx.Is(err, maintenanceService.ErrTechnicianNotFound) {
+			return errorsx.NotFoundError("Teknisyen bulunamadı!")
+		}
+		return errorsx.InternalError(err, "İş emri açılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "İş emri açıldı, motor bakıma alındı!", "work_order": viewmodels.WorkOrderDetailVM{}.ToViewModel(workOrder)})
+}
+
+func (h MaintenanceHandler) UpdateWorkOrder(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	workOrder, err := h.getWorkOrder(ctx)
+	if err != nil {
+		return err
+	}
+
+	updated := vm.ToDBModel(*workOrder)
+	if err = h.maintenanceService.UpdateWorkOrder(ctx.Context(), &updated); err != nil {
+		return workOrderError(err, "İş emri güncellenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İş emri güncellendi!"})
+}
+
+func (h MaintenanceHandler) AssignTechnician(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderAssignVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.maintenanceService.AssignTechnician(ctx.Context(), id, vm.TechnicianID); err != nil {
+		return workOrderError(err, "Teknisyen atanamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Teknisyen atandı!"})
+}
+
+// (adminler için) iş emrini 'in_progress' veya 'waiting_parts' yapar
+func (h MaintenanceHandler) UpdateWorkOrderStatus(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderStatusVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.maintenanceService.SetStatus(ctx.Context(), id, models.WorkOrderStatus(vm.Status)); err != nil {
+		return workOrderError(err, "İş emri durumu değiştirilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İş emri durumu değiştirildi!"})
+}
+
+// (adminler için) iş emrine kullanılan parçayı ekler
+func (h MaintenanceHandler) AddPart(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderPartCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	part := vm.ToDBModel(uint(id))
+	if err = h.maintenanceService.AddPart(ctx.Context(), &part); err != nil {
+		return workOrderError(err, "Parça eklenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Parça eklendi!", "part": viewmodels.WorkOrderPartDetailVM{}.ToViewModel(part)})
+}
+
+// (adminler için) iş emrini tamamlar, motorun başka açık iş emri yoksa motor 'available' olur
+func (h MaintenanceHandler) CompleteWorkOrder(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderCompleteVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	workOrder, err := h.getWorkOrder(ctx)
+	if err != nil {
+		return err
+	}
+
+	adminID := uint(ctx.GetUserID())
+	workOrder.Status = models.WorkOrderCompleted
+	workOrder.ResolutionNote = strings.TrimSpace(vm.ResolutionNote)
+	workOrder.ClosedBy = &adminID
+	if vm.LabourMinutes != nil {
+		workOrder.LabourMinutes = *vm.LabourMinutes
+	}
+
+	if err = h.maintenanceService.CloseWorkOrder(ctx.Context(), workOrder); err != nil {
+		return workOrderError(err, "İş emri tamamlanamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İş emri tamamlandı!"})
+}
+
+// (adminler için) iş emrini iptal eder, motorun başka açık iş emri yoksa motor 'available' olur
+func (h MaintenanceHandler) CancelWorkOrder(ctx *app.Ctx) error {
+	var vm viewmodels.WorkOrderCancelVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	workOrder, err := h.getWorkOrder(ctx)
+	if err != nil {
+		return err
+	}
+
+	adminID := uint(ctx.GetUserID())
+	workOrder.Status = models.WorkOrderCancelled
+	workOrder.ResolutionNote = strings.TrimSpace(vm.Note)
+	workOrder.ClosedBy = &adminID
+
+	if err = h.maintenanceService.CloseWorkOrder(ctx.Context(), workOrder); err != nil {
+		return workOrderError(err, "İş emri iptal edilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İş emri iptal edildi!"})
+}
+
+// (adminler için) bakımdaki motorları açık iş emirleriyle birlikte döner -> /maintenance-motorbikes
+func (h MaintenanceHandler) GetMaintenanceMotors(ctx *app.Ctx) error {
+	motors, err := h.motorService.GetMotorsForStatus(ctx.Context(), string(motorModel.BikeInMaintenance))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bir hata oluştu!"})
+	}
+
+	if len(*motors) == 0 {
+		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "bakımda motor yok!"})
+	}
+
+	motorIDs := make([]int64, 0, len(*motors))
+	for _, motor := range *motors {
+		motorIDs = append(motorIDs, motor.ID)
+	}
+
+	workOrders, err := h.maintenanceService.GetOpenWorkOrdersByMotorIDs(ctx.Context(), motorIDs)
+	if err != nil {
+		return errorsx.InternalError(err, "İş emirleri getirilemedi!")
+	}
+
+	openWorkOrders := map[uint][]viewmodels.WorkOrderDetailVM{}
+	for _, workOrder := range *workOrders {
+		openWorkOrders[workOrder.MotorbikeID] = append(openWorkOrders[workOrder.MotorbikeID], viewmodels.WorkOrderDetailVM{}.ToViewModel(workOrder))
+	}
+
+	var motorDetails []viewmodels.MaintenanceBikeVM
+	for _, motor := range *motors {
+		// Her motorun fotoğraflarını al
+		var photos []motorModel.MotorbikePhoto
+		if err = h.motorService.GetPhotosByID(ctx.Context(), strconv.FormatInt(motor.ID, 10), &photos); err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Motorlar getirilirken bir hata oluştu."})
+		}
+
+		motorDetails = append(motorDetails, viewmodels.MaintenanceBikeVM{
+			BikeDetailVM:   motorViewmodel.NewBikeDetailVM(motor, photos),
+			OpenWorkOrders: append([]viewmodels.WorkOrderDetailVM{}, openWorkOrders[uint(motor.ID)]...),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(motorDetails)
+}
+
+func (h MaintenanceHandler) getWorkOrder(ctx *app.Ctx) (*models.WorkOrder, error) {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return nil, errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	workOrder, err := h.maintenanceService.GetWorkOrderByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorsx.NotFoundError("İş emri bulunamadı!")
+		}
+		return nil, errorsx.InternalError(err, "İş emri getirilirken hata oluştu!")
+	}
+
+	return workOrder, nil
+}
+
+// workOrderError iş emri işlemlerinin hatalarını HTTP hatalarına çevirir
+func workOrderError(err error, msg string) error {
+	switch {
+	case errorsx.Is(err, gorm.ErrRecordNotFound):
+		return errorsx.NotFoundError("İş emri bulunamadı!")
+	case errorsx.Is(err, maintenanceService.ErrWorkOrderClosed):
+		return errorsx.ConflictError("İş emri kapanmış!")
+	case errorsx.Is(err, maintenanceService.ErrTechnicianNotFound):
+		return errorsx.NotFoundError("Teknisyen bulunamadı!")
+	default:
+		return errorsx.InternalError(err, msg)
+	}
+}
+
+func toDetails(workOrders []models.WorkOrder) []viewmodels.WorkOrderDetailVM {
+	var details []viewmodels.WorkOrderDetailVM
+	for _, workOrder := range workOrders {
+		details = append(details, viewmodels.WorkOrderDetailVM{}.ToViewModel(workOrder))
+	}
+	return details
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// MaintenancePlan bir motor modeli için periyodik bakım kuralı, örn. her 1000 km'de yağ değişimi.
+// Aralıklardan en az biri verilir; ikisi de verilirse önce dolan aralık bakımı başlatır.
+type MaintenancePlan struct {
+	BaseModel
+	Model         string    `gorm:"type:varchar(100);not null"` // motorbike.model ile birebir eşleşir
+	Name          string    `gorm:"type:varchar(150);not null"`
+	IssueType     IssueType `gorm:"type:varchar(20);not null"` // açılan iş emrinin arıza tipi
+	Priority      Priority  `gorm:"type:varchar(10);not null"` // açılan iş emrinin önceliği
+	IntervalKm    *float64  // bakım aralığı (km)
+	IntervalHours *float64  // bakım aralığı (sürüş saati)
+	Active        bool      `gorm:"not null"`
+}
+
+func (MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+// PlanUsage motorun plana göre son bakımdan beri kullanımı. Plana bağlı tamamlanmış iş emri yoksa
+// motorun tüm kullanımı sayılır.
+type PlanUsage struct {
+	PlanID            uint
+	PlanName          string
+	IssueType         IssueType
+	Priority          Priority
+	MotorbikeID       uint
+	Model             string
+	MotorStatus       string
+	KmSinceService    float64
+	HoursSinceService float64
+	IntervalKm        *float64
+	IntervalHours     *float64
+	LastServiceAt     *time.Time
+	OpenWorkOrderID   *uint // planın bu motor için açık iş emri
+}
+
+// UsageRatio kullanımın aralığa oranı, iki aralık varsa büyük olanı. 1 ve üzeri bakım zamanı gelmiş demektir.
+func (u PlanUsage) UsageRatio() float64 {
+	var ratio float64
+	if u.IntervalKm != nil && *u.IntervalKm > 0 {
+		ratio = u.KmSinceService / *u.IntervalKm
+	}
+	if u.IntervalHours != nil && *u.IntervalHours > 0 {
+		if hours := u.HoursSinceService / *u.IntervalHours; hours > ratio {
+			ratio = hours
+		}
+	}
+	return ratio
+}
+
+// RemainingKm bakıma kalan mesafe, aralık aşıldıysa negatif
+func (u PlanUsage) RemainingKm() *float64 {
+	if u.IntervalKm == nil {
+		return nil
+	}
+	remaining := *u.IntervalKm - u.KmSinceService
+	return &remaining
+}
+
+// RemainingHours bakıma kalan sürüş saati, aralık aşıldıysa negatif
+func (u PlanUsage) RemainingHours() *float64 {
+	if u.IntervalHours == nil {
+		return nil
+	}
+	remaining := *u.IntervalHours - u.HoursSinceService
+	return &remaining
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	"time"
+)
+
+type IssueType string
+
+const (
+	IssueMechanical IssueType = "mechanical"
+	IssueElectrical IssueType = "electrical"
+	IssueBattery    IssueType = "battery"
+	IssueTyre       IssueType = "tyre"
+	IssueBrake      IssueType = "brake"
+	IssueLock       IssueType = "lock" // kilit/IoT modülü
+	IssueBody       IssueType = "body" // kaporta, ayna, sele vb.
+	IssueOther      IssueType = "other"
+)
+
+type Priority string
+
+const (
+	PriorityLow      Priority = "low"
+	PriorityMedium   Priority = "medium"
+	PriorityHigh     Priority = "high"
+	PriorityCritical Priority = "critical" // motor güvenli değil
+)
+
+type WorkOrderStatus string
+
+const (
+	WorkOrderOpen         WorkOrderStatus = "open"          // açıldı, henüz üzerinde çalışılmıyor
+	WorkOrderInProgress   WorkOrderStatus = "in_progress"   // teknisyen çalışıyor
+	WorkOrderWaitingParts WorkOrderStatus = "waiting_parts" // parça bekleniyor
+	WorkOrderCompleted    WorkOrderStatus = "completed"
+	WorkOrderCancelled    WorkOrderStatus = "cancelled"
+)
+
+// OpenWorkOrderStatuses motoru bakımda tutan iş emri durumları
+var OpenWorkOrderStatuses = []WorkOrderStatus{WorkOrderOpen, WorkOrderInProgress, WorkOrderWaitingParts}
+
+// WorkOrder motorun neden servis dışı olduğunun, kimin onardığının ve ne zaman döneceğinin kaydı.
+// Açık iş emri olan motor 'maintenance' durumundadır (kiradayken açıldıysa sürüş bitince geçer), son açık iş emri
+// kapanınca 'available' olur.
+type WorkOrder struct {
+	BaseModel
+	MotorbikeID      uint            `gorm:"not null"`
+	IssueType        IssueType       `gorm:"type:varchar(20);not null"`
+	Priority         Priority        `gorm:"type:varchar(10);not null"`
+	Status           WorkOrderStatus `gorm:"type:varchar(20);not null"`
+	Description      string          `gorm:"type:varchar(1000);not null"`
+	ReportedBy       *uint           // iş emrini açan admin
+	TechnicianID     *uint           // atanan teknisyen
+	LabourMinutes    int             `gorm:"not null"` // toplam işçilik süresi (dakika)
+	ResolutionNote   string          `gorm:"type:varchar(1000)"`
+	ExpectedReturnAt *time.Time      // motorun servise dönmesi beklenen zaman
+	StartedAt        *time.Time      // ilk kez 'in_progress' olduğu zaman
+	ClosedAt         *time.Time
+	ClosedBy         *uint
+	PlanID           *uint    // periyodik bakım planının açtığı iş emirlerinde plan
+	OdometerMeters   *float64 // kapanışta motorun toplam sürüş mesafesi, planın sonraki bakımı buradan sayılır
+	RideSeconds      *int64   // kapanışta motorun toplam sürüş süresi
+
+	Parts     []WorkOrderPart       `gorm:"foreignKey:WorkOrderID"`
+	Motorbike *modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
+}
+
+// WorkOrderPart iş emrinde kullanılan parça
+type WorkOrderPart struct {
+	BaseModel
+	WorkOrderID uint    `gorm:"not null"`
+	Name        string  `gorm:"type:varchar(150);not null"`
+	PartNumber  string  `gorm:"type:varchar(64)"`
+	Quantity    int     `gorm:"not null"`
+	UnitCost    float64 `gorm:"not null"` // TL
+}
+
+func (WorkOrder) TableName() string {
+	return "work_orders"
+}
+
+func (WorkOrderPart) TableName() string {
+	return "work_order_parts"
+}
+
+// PartsCost iş emrinde kullanılan parçaların toplam maliyeti
+func (w WorkOrder) PartsCost() float64 {
+	var total float64
+	for _, part := range w.Parts {
+		total += float64(part.Quantity) * part.UnitCost
+	}
+	return total
+}
+
+func (s WorkOrderStatus) IsOpen() bool {
+	return s == WorkOrderOpen || s == WorkOrderInProgress || s == WorkOrderWaitingParts
+}
+
+func (s WorkOrderStatus) String() string {
+	switch s {
+	case WorkOrderOpen:
+		return "open"
+	case WorkOrderInProgress:
+		return "in_progress"
+	case WorkOrderWaitingParts:
+		return "waiting_parts"
+	case WorkOrderCompleted:
+		return "completed"
+	case WorkOrderCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+func (t IssueType) String() string {
+	switch t {
+	case IssueMechanical:
+		return "mechanical"
+	case IssueElectrical:
+		return "electrical"
+	case IssueBattery:
+		return "battery"
+	case IssueTyre:
+		return "tyre"
+	case IssueBrake:
+		return "brake"
+	case IssueLock:
+		return "lock"
+	case IssueBody:
+		return "body"
+	case IssueOther:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityMedium:
+		return "medium"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
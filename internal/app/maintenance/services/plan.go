@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/maintenance/models"
+	"sort"
+)
+
+// planUsageQuery aktif planları modeli eşleşen motorlarla birleştirir. Kullanım, planın motor için son
+// tamamlanan iş emrinin kapanıştaki sayaçlarından, hiç tamamlanmadıysa sıfırdan sayılır.
+const planUsageQuery = `
+SELECT p.id AS plan_id, p.name AS plan_name, p.issue_type, p.priority,
+       m.id AS motorbike_id, m.model, m.status AS motor_status,
+       (m.total_distance_meters - COALESCE(last_wo.odometer_meters, 0)) / 1000 AS km_since_service,
+       (m.total_ride_seconds - COALESCE(last_wo.ride_seconds, 0)) / 3600.0 AS hours_since_service,
+       p.interval_km, p.interval_hours,
+       last_wo.closed_at AS last_service_at,
+       open_wo.id AS open_work_order_id
+FROM maintenance_plans p
+JOIN motorbike m ON m.model = p.model AND m.deleted_at IS NULL
+LEFT JOIN LATERAL (
+    SELECT w.odometer_meters, w.ride_seconds, w.closed_at
+    FROM work_orders w
+    WHERE w.plan_id = p.id AND w.motorbike_id = m.id AND w.status = 'completed' AND w.deleted_at IS NULL
+    ORDER BY w.closed_at DESC
+    LIMIT 1
+) last_wo ON TRUE
+LEFT JOIN work_orders open_wo ON open_wo.plan_id = p.id AND open_wo.motorbike_id = m.id AND open_wo.deleted_at IS NULL
+    AND open_wo.status IN ?
+WHERE p.active AND p.deleted_at IS NULL
+ORDER BY p.id, m.id`
+
+type IPlanService interface {
+	GetPlans(ctx context.Context) (*[]models.MaintenancePlan, error)
+	GetPlanByID(ctx context.Context, id int) (*models.MaintenancePlan, error)
+	CreatePlan(ctx context.Context, plan *models.MaintenancePlan) error
+	UpdatePlan(ctx context.Context, plan *models.MaintenancePlan) error
+	DeletePlan(ctx context.Context, id int) error
+	GetDueSoon(ctx context.Context, threshold float64) (*[]models.PlanUsage, error)
+	RunScheduler(ctx context.Context) (*[]models.WorkOrder, error)
+}
+
+type PlanService struct {
+	DB *gorm.DB
+}
+
+func NewPlanService(db *gorm.DB) IPlanService {
+	return &PlanService{DB: db}
+}
+
+func (s *PlanService) GetPlans(ctx context.Context) (*[]models.MaintenancePlan, error) {
+	var plans []models.MaintenancePlan
+	if err := s.DB.WithContext(ctx).Order("model, id").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+
+	return &plans, nil
+}
+
+func (s *PlanService) GetPlanByID(ctx context.Context, id int) (*models.MaintenancePlan, error) {
+	var plan models.MaintenancePlan
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&plan).Error; err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func (s *PlanService) CreatePlan(ctx context.Context, plan *models.MaintenancePlan) error {
+	return s.DB.WithContext(ctx).Create(plan).Error
+}
+
+func (s *PlanService) UpdatePlan(ctx context.Context, plan *models.MaintenancePlan) error {
+	return s.DB.WithContext(ctx).Model(plan).
+		Select("model", "name", "issue_type", "priority", "interval_km", "interval_hours", "active").
+		Updates(plan).Error
+}
+
+// DeletePlan planı siler, planın açtığı iş emirleri açık kalır
+func (s *PlanService) DeletePlan(ctx context.Context, id int) error {
+	result := s.DB.WithContext(ctx).Delete(&models.MaintenancePlan{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetDueSoon kullanımı aralığın threshold oranına ulaşmış motorları en çok kullanılandan başlayarak döner.
+// Bakım zamanı geçmiş ve iş emri açılmış motorlar da listede yer alır.
+func (s *PlanService) GetDueSoon(ctx context.Context, threshold float64) (*[]models.PlanUsage, error) {
+	usages, err := s.getPlanUsages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dueSoon := []models.PlanUsage{}
+	for _, usage := range usages {
+		if usage.UsageRatio() >= threshold {
+			dueSoon = append(dueSoon, usage)
+		}
+	}
+	sort.SliceStable(dueSoon, func(i, j int) bool { return dueSoon[i].UsageRatio() > dueSoon[j].UsageRatio() })
+
+	return &dueSoon, nil
+}
+
+// RunScheduler bakım zamanı gelen her motor için planın iş emrini açar, motor 'maintenance' durumuna geçer.
+// Kiradaki veya rezerve motorlar atlanır, sürüş/rezervasyon bitince sonraki çalışmada iş emri açılır.
+func (s *PlanService) RunScheduler(ctx context.Context) (*[]models.WorkOrder, error) {
+	usages, err := s.getPlanUsages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opened := []models.WorkOrder{}
+	for _, usage := range usages {
+		if usage.OpenWorkOrderID != nil || usage.UsageRatio() < 1 {
+			continue
+		}
+
+		planID := usage.PlanID
+		workOrder := models.WorkOrder{
+			MotorbikeID: usage.MotorbikeID,
+			IssueType:   usage.IssueType,
+			Priority:    usage.Priority,
+			Description: fmt.Sprintf("Periyodik bakım: %s (son bakımdan beri %.0f km, %.1f saat)", usage.PlanName, usage.KmSinceService, usage.HoursSinceService),
+			PlanID:      &planID,
+		}
+
+		err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return openWorkOrder(tx, &workOrder, false)
+		})
+		if errors.Is(err, ErrMotorbikeInUse) || errors.Is(err, ErrPlanWorkOrderOpen) {
+			continue
+		}
+		if err != nil {
+			return &opened, err
+		}
+
+		opened = append(opened, workOrder)
+	}
+
+	return &opened, nil
+}
+
+func (s *PlanService) getPlanUsages(ctx context.Context) ([]models.PlanUsage, error) {
+	var usages []models.PlanUsage
+	if err := s.DB.WithContext(ctx).Raw(planUsageQuery, models.OpenWorkOrderStatuses).Scan(&usages).Error; err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
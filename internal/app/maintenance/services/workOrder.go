@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/maintenance/models"
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	modelUser "motorbike-rental-backend/internal/app/user-and-auth/models"
+	"time"
+)
+
+var (
+	ErrMotorbikeInUse     = errors.New("motorbike is rented or reserved")
+	ErrWorkOrderClosed    = errors.New("work order is already closed")
+	ErrTechnicianNotFound = errors.New("technician not found")
+	ErrPlanWorkOrderOpen  = errors.New("maintenance plan already has an open work order for this motorbike")
+)
+
+// priorityOrder iş emirlerini önce önceliğe, sonra açılış sırasına göre sıralar
+const priorityOrder = "CASE priority WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END, id"
+
+type IMaintenanceService interface {
+	GetWorkOrders(ctx context.Context, status string) (*[]models.WorkOrder, error)
+	GetWorkOrderByID(ctx context.Context, id int) (*models.WorkOrder, error)
+	GetWorkOrdersByMotorID(ctx context.Context, motorbikeID int) (*[]models.WorkOrder, error)
+	GetWorkOrdersByTechnicianID(ctx context.Context, technicianID int, status string) (*[]models.WorkOrder, error)
+	GetOpenWorkOrdersByMotorIDs(ctx context.Context, motorbikeIDs []int64) (*[]models.WorkOrder, error)
+	OpenWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error
+	UpdateWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error
+	AssignTechnician(ctx context.Context, id int, technicianID uint) error
+	SetStatus(ctx context.Context, id int, status models.WorkOrderStatus) error
+	AddPart(ctx context.Context, part *models.WorkOrderPart) error
+	CloseWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error
+}
+
+type MaintenanceService struct {
+	DB *gorm.DB
+}
+
+func NewMaintenanceService(db *gorm.DB) IMaintenanceService {
+	return &MaintenanceService{DB: db}
+}
+
+// GetWorkOrders iş emirlerini öncelik sırasıyla döner, status boşsa tümü
+func (s *MaintenanceService) GetWorkOrders(ctx context.Context, status string) (*[]models.WorkOrder, error) {
+	query := s.DB.WithContext(ctx).Preload("Parts")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var workOrders []models.WorkOrder
+	if err := query.Order(priorityOrder).Find(&workOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrders, nil
+}
+
+func (s *MaintenanceService) GetWorkOrderByID(ctx context.Context, id int) (*models.WorkOrder, error) {
+	var workOrder models.WorkOrder
+	if err := s.DB.WithContext(ctx).Preload("Parts").Where("id = ?", id).First(&workOrder).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrder, nil
+}
+
+// GetWorkOrdersByMotorID motorun bakım geçmişini yeniden eskiye döner
+func (s *MaintenanceService) GetWorkOrdersByMotorID(ctx context.Context, motorbikeID int) (*[]models.WorkOrder, error) {
+	var workOrders []models.WorkOrder
+	if err := s.DB.WithContext(ctx).Preload("Parts").
+		Where("motorbike_id = ?", motorbikeID).
+		Order("id DESC").
+		Find(&workOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrders, nil
+}
+
+// GetWorkOrdersByTechnicianID teknisyene atanan iş emirlerini döner, status boşsa tümü
+func (s *MaintenanceService) GetWorkOrdersByTechnicianID(ctx context.Context, technicianID int, status string) (*[]models.WorkOrder, error) {
+	query := s.DB.WithContext(ctx).Preload("Parts").Where("technician_id = ?", technicianID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var workOrders []models.WorkOrder
+	if err := query.Order(priorityOrder).Find(&workOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrders, nil
+}
+
+// GetOpenWorkOrdersByMotorIDs verilen motorların açık iş emirlerini döner
+func (s *MaintenanceService) GetOpenWorkOrdersByMotorIDs(ctx context.Context, motorbikeIDs []int64) (*[]models.WorkOrder, error) {
+	var workOrders []models.WorkOrder
+	if len(motorbikeIDs) == 0 {
+		return &workOrders, nil
+	}
+
+	if err := s.DB.WithContext(ctx).Preload("Parts").
+		Where("motorbike_id IN ? AND status IN ?", motorbikeIDs, models.OpenWorkOrderStatuses).
+		Order(priorityOrder).
+		Find(&workOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return &workOrders, nil
+}
+
+// OpenWorkOrder motor satırını kilitleyip iş emrini açar ve motoru 'maintenance' durumuna alır.
+// Kiradaki veya rezerve motor için iş emri açılamaz; motor zaten bakımdaysa yeni arıza aynı motora eklenir.
+func (s *MaintenanceService) OpenWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return openWorkOrder(tx, workOrder, false)
+	})
+}
+
+// OpenWorkOrderTx iş emrini çağıranın transaction'ı içinde açar, iş emriyle birlikte başka kayıtların yazıldığı
+// akışlar (hasar bildirimi) için. Motor kiradaysa iş emri yine açılır ama motor kirada kalır ve bakım için
+// işaretlenir, kiralama bitince 'maintenance' durumuna geçer. Rezerve motor için ErrMotorbikeInUse döner.
+func OpenWorkOrderTx(tx *gorm.DB, workOrder *models.WorkOrder) error {
+	return openWorkOrder(tx, workOrder, true)
+}
+
+// UpdateWorkOrder açık iş emrinin arıza bilgilerini, beklenen dönüş zamanını ve işçilik süresini günceller
+func (s *MaintenanceService) UpdateWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error {
+	result := s.DB.WithContext(ctx).Model(workOrder).
+		Where("status IN ?", models.OpenWorkOrderStatuses).
+		Select("issue_type", "priority", "description", "expected_return_at", "labour_minutes").
+		Updates(workOrder)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWorkOrderClosed
+	}
+
+	return nil
+}
+
+// AssignTechnician açık iş emrini teknisyene atar
+func (s *MaintenanceService) AssignTechnician(ctx context.Context, id int, technicianID uint) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkTechnician(tx, technicianID); err != nil {
+			return err
+		}
+
+		return updateOpenWorkOrder(tx, id, map[string]interface{}{"technician_id": technicianID})
+	})
+}
+
+// SetStatus açık iş emrini 'in_progress' veya 'waiting_parts' yapar, kapatma CloseWorkOrder ile yapılır
+func (s *MaintenanceService) SetStatus(ctx context.Context, id int, status models.WorkOrderStatus) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": status}
+		if status == models.WorkOrderInProgress {
+			updates["started_at"] = gorm.Expr("COALESCE(started_at, ?)", time.Now().UTC())
+		}
+
+		return updateOpenWorkOrder(tx, id, updates)
+	})
+}
+
+// AddPart açık iş emrine kullanılan parçayı ekler
+func (s *MaintenanceService) AddPart(ctx context.Context, part *models.WorkOrderPart) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var workOrder models.WorkOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", part.WorkOrderID).First(&workOrder).Error; err != nil {
+			return err
+		}
+		if !workOrder.Status.IsOpen() {
+			return ErrWorkOrderClosed
+		}
+
+		return tx.Create(part).Error
+	})
+}
+
+// CloseWorkOrder iş emrini 'completed' veya 'cancelled' olarak kapatır. Motorun başka açık iş emri
+// kalmadıysa ve motor hâlâ bakımdaysa motor 'available' yapılır. İkisi aynı transaction içinde yazılır.
+func (s *MaintenanceService) CloseWorkOrder(ctx context.Context, workOrder *models.WorkOrder) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// kilit sırası iş emri açılırkenki ile aynı: önce motor, sonra iş emri
+		var motor modelMotor.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", workOrder.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		var current models.WorkOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", workOrder.ID).First(&current).Error; err != nil {
+			return err
+		}
+		if !current.Status.IsOpen() {
+			return ErrWorkOrderClosed
+		}
+
+		// periyodik bakım planlarının sonraki bakımı motorun kapanıştaki sayaçlarından sayılır
+		now := time.Now().UTC()
+		workOrder.ClosedAt = &now
+		workOrder.OdometerMeters = &motor.TotalDistanceM
+		workOrder.RideSeconds = &motor.TotalRideSeconds
+		if err := tx.Model(workOrder).
+			Select("status", "resolution_note", "labour_minutes", "closed_at", "closed_by", "odometer_meters", "ride_seconds").
+			Updates(workOrder).Error; err != nil {
+			return err
+		}
+
+		var openCount int64
+		if err := tx.Model(&models.WorkOrder{}).
+			Where("motorbike_id = ? AND status IN ?", workOrder.MotorbikeID, models.OpenWorkOrderStatuses).
+			Count(&openCount).Error; err != nil {
+			return err
+		}
+		switch {
+		case openCount > 0:
+			return nil
+		case motor.MaintenancePending:
+			// kiradayken açılan iş emirleri sürüş bitmeden kapandı, motor kiralama bitince servise döner
+			return tx.Model(&motor).Update("maintenance_pending", false).Error
+		case motor.Status == modelMotor.BikeInMaintenance:
+			return tx.Model(&motor).Update("status", modelMotor.BikeAvailable).Error
+		}
+		return nil
+	})
+}
+
+// openWorkOrder OpenWorkOrder, OpenWorkOrderTx ve periyodik bakım zamanlayıcısı tarafından ortak kullanılır. Plan iş
+// emirlerinde planın motor için açık iş emri varsa ErrPlanWorkOrderOpen döner. deferIfRented true ise kiradaki motor
+// için iş emri açılır ve motor kiralama bitince bakıma alınmak üzere işaretlenir.
+func openWorkOrder(tx *gorm.DB, workOrder *models.WorkOrder, deferIfRented bool) error {
+	var motor modelMotor.Motorbike
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", workOrder.MotorbikeID).First(&motor).Error; err != nil {
+		return err
+	}
+
+	if motor.Status == modelMotor.BikeReserved || (motor.Status == modelMotor.BikeRented && !deferIfRented) {
+		return ErrMotorbikeInUse
+	}
+
+	if workOrder.PlanID != nil {
+		var openCount int64
+		if err := tx.Model(&models.WorkOrder{}).
+			Where("plan_id = ? AND motorbike_id = ? AND status IN ?", *workOrder.PlanID, workOrder.MotorbikeID, models.OpenWorkOrderStatuses).
+			Count(&openCount).Error; err != nil {
+			return err
+		}
+		if openCount > 0 {
+			return ErrPlanWorkOrderOpen
+		}
+	}
+
+	if workOrder.TechnicianID != nil {
+		if err := checkTechnician(tx, *workOrder.TechnicianID); err != nil {
+			return err
+		}
+	}
+
+	workOrder.Status = models.WorkOrderOpen
+	if err := tx.Create(workOrder).Error; err != nil {
+		return err
+	}
+
+	switch {
+	case motor.Status == modelMotor.BikeRented:
+		return tx.Model(&motor).Update("maintenance_pending", true).Error
+	case motor.Status == modelMotor.BikeInMaintenance:
+		return nil
+	}
+	return tx.Model(&motor).Update("status", modelMotor.BikeInMaintenance).Error
+}
+
+func updateOpenWorkOrder(tx *gorm.DB, id int, updates map[string]interface{}) error {
+	var workOrder models.WorkOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&workOrder).Error; err != nil {
+		return err
+	}
+	if !workOrder.Status.IsOpen() {
+		return ErrWorkOrderClosed
+	}
+
+	return tx.Model(&workOrder).Updates(updates).Error
+}
+
+func checkTechnician(tx *gorm.DB, technicianID uint) error {
+	var count int64
+	if err := tx.Model(&modelUser.User{}).Where("id = ?", technicianID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrTechnicianNotFound
+	}
+
+	return nil
+}
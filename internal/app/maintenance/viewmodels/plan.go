@@ -0,0 +1,120 @@
+package viewmodels
+
+import (
+	"math"
+	"motorbike-rental-backend/internal/app/maintenance/models"
+	"strings"
+	"time"
+)
+
+// Bakım planı oluşturmak için view model, aralıklardan en az biri verilmeli
+type PlanCreateVM struct {
+	Model         string   `json:"model" validate:"required,max=100"`
+	Name          string   `json:"name" validate:"required,max=150"`
+	IssueType     string   `json:"issue_type" validate:"required,oneof=mechanical electrical battery tyre brake lock body other"`
+	Priority      string   `json:"priority" validate:"required,oneof=low medium high critical"`
+	IntervalKm    *float64 `json:"interval_km" validate:"required_without=IntervalHours,omitempty,gt=0"`
+	IntervalHours *float64 `json:"interval_hours" validate:"required_without=IntervalKm,omitempty,gt=0"`
+}
+
+func (vm PlanCreateVM) ToDBModel() models.MaintenancePlan {
+	return models.MaintenancePlan{
+		Model:         strings.TrimSpace(vm.Model),
+		Name:          strings.TrimSpace(vm.Name),
+		IssueType:     models.IssueType(vm.IssueType),
+		Priority:      models.Priority(vm.Priority),
+		IntervalKm:    vm.IntervalKm,
+		IntervalHours: vm.IntervalHours,
+		Active:        true,
+	}
+}
+
+// Bakım planını güncellemek için view model
+type PlanUpdateVM struct {
+	Model         string   `json:"model" validate:"required,max=100"`
+	Name          string   `json:"name" validate:"required,max=150"`
+	IssueType     string   `json:"issue_type" validate:"required,oneof=mechanical electrical battery tyre brake lock body other"`
+	Priority      string   `json:"priority" validate:"required,oneof=low medium high critical"`
+	IntervalKm    *float64 `json:"interval_km" validate:"required_without=IntervalHours,omitempty,gt=0"`
+	IntervalHours *float64 `json:"interval_hours" validate:"required_without=IntervalKm,omitempty,gt=0"`
+	Active        *bool    `json:"active" validate:"required"`
+}
+
+func (vm PlanUpdateVM) ToDBModel(m models.MaintenancePlan) models.MaintenancePlan {
+	m.Model = strings.TrimSpace(vm.Model)
+	m.Name = strings.TrimSpace(vm.Name)
+	m.IssueType = models.IssueType(vm.IssueType)
+	m.Priority = models.Priority(vm.Priority)
+	m.IntervalKm = vm.IntervalKm
+	m.IntervalHours = vm.IntervalHours
+	m.Active = *vm.Active
+	return m
+}
+
+// Bakım planı detayları için view model
+type PlanDetailVM struct {
+	ID            int64     `json:"id"`
+	Model         string    `json:"model"`
+	Name          string    `json:"name"`
+	IssueType     string    `json:"issue_type"`
+	Priority      string    `json:"priority"`
+	IntervalKm    *float64  `json:"interval_km"`
+	IntervalHours *float64  `json:"interval_hours"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (vm PlanDetailVM) ToViewModel(m models.MaintenancePlan) PlanDetailVM {
+	vm.ID = m.ID
+	vm.Model = m.Model
+	vm.Name = m.Name
+	vm.IssueType = m.IssueType.String()
+	vm.Priority = m.Priority.String()
+	vm.IntervalKm = m.IntervalKm
+	vm.IntervalHours = m.IntervalHours
+	vm.Active = m.Active
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
+
+// Bakımı yaklaşan motorlar raporu için view model
+type PlanUsageVM struct {
+	PlanID            uint       `json:"plan_id"`
+	PlanName          string     `json:"plan_name"`
+	MotorbikeID       uint       `json:"motorbike_id"`
+	Model             string     `json:"model"`
+	MotorStatus       string     `json:"motor_status"`
+	KmSinceService    float64    `json:"km_since_service"`
+	HoursSinceService float64    `json:"hours_since_service"`
+	RemainingKm       *float64   `json:"remaining_km"`
+	RemainingHours    *float64   `json:"remaining_hours"`
+	UsageRatio        float64    `json:"usage_ratio"` // 1 ve üzeri bakım zamanı gelmiş demektir
+	LastServiceAt     *time.Time `json:"last_service_at"`
+	OpenWorkOrderID   *uint      `json:"open_work_order_id"`
+}
+
+func (vm PlanUsageVM) ToViewModel(m models.PlanUsage) PlanUsageVM {
+	vm.PlanID = m.PlanID
+	vm.PlanName = m.PlanName
+	vm.MotorbikeID = m.MotorbikeID
+	vm.Model = m.Model
+	vm.MotorStatus = m.MotorStatus
+	vm.KmSinceService = round2(m.KmSinceService)
+	vm.HoursSinceService = round2(m.HoursSinceService)
+	if remaining := m.RemainingKm(); remaining != nil {
+		rounded := round2(*remaining)
+		vm.RemainingKm = &rounded
+	}
+	if remaining := m.RemainingHours(); remaining != nil {
+		rounded := round2(*remaining)
+		vm.RemainingHours = &rounded
+	}
+	vm.UsageRatio = round2(m.UsageRatio())
+	vm.LastServiceAt = m.LastServiceAt
+	vm.OpenWorkOrderID = m.OpenWorkOrderID
+	return vm
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
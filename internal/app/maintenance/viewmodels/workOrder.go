@@ -0,0 +1,155 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/maintenance/models"
+	motorViewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
+	"strings"
+	"time"
+)
+
+// İş emri açmak için view model
+type WorkOrderCreateVM struct {
+	MotorbikeID      uint       `json:"motorbike_id" validate:"required,gt=0"`
+	IssueType        string     `json:"issue_type" validate:"required,oneof=mechanical electrical battery tyre brake lock body other"`
+	Priority         string     `json:"priority" validate:"required,oneof=low medium high critical"`
+	Description      string     `json:"description" validate:"required,max=1000"`
+	TechnicianID     *uint      `json:"technician_id" validate:"omitempty,gt=0"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at"`
+}
+
+func (vm WorkOrderCreateVM) ToDBModel() models.WorkOrder {
+	return models.WorkOrder{
+		MotorbikeID:      vm.MotorbikeID,
+		IssueType:        models.IssueType(vm.IssueType),
+		Priority:         models.Priority(vm.Priority),
+		Description:      strings.TrimSpace(vm.Description),
+		TechnicianID:     vm.TechnicianID,
+		ExpectedReturnAt: vm.ExpectedReturnAt,
+	}
+}
+
+// İş emrini güncellemek için view model
+type WorkOrderUpdateVM struct {
+	IssueType        string     `json:"issue_type" validate:"required,oneof=mechanical electrical battery tyre brake lock body other"`
+	Priority         string     `json:"priority" validate:"required,oneof=low medium high critical"`
+	Description      string     `json:"description" validate:"required,max=1000"`
+	ExpectedReturnAt *time.Time `json:"expected_return_at"`
+	LabourMinutes    int        `json:"labour_minutes" validate:"gte=0"`
+}
+
+func (vm WorkOrderUpdateVM) ToDBModel(m models.WorkOrder) models.WorkOrder {
+	m.IssueType = models.IssueType(vm.IssueType)
+	m.Priority = models.Priority(vm.Priority)
+	m.Description = strings.TrimSpace(vm.Description)
+	m.ExpectedReturnAt = vm.ExpectedReturnAt
+	m.LabourMinutes = vm.LabourMinutes
+	return m
+}
+
+// İş emrini teknisyene atamak için view model
+type WorkOrderAssignVM struct {
+	TechnicianID uint `json:"technician_id" validate:"required,gt=0"`
+}
+
+// İş emrinin çalışma durumunu değiştirmek için view model
+type WorkOrderStatusVM struct {
+	Status string `json:"status" validate:"required,oneof=in_progress waiting_parts"`
+}
+
+// İş emrini tamamlamak için view model, işçilik süresi verilmezse mevcut değer korunur
+type WorkOrderCompleteVM struct {
+	LabourMinutes  *int   `json:"labour_minutes" validate:"omitempty,gte=0"`
+	ResolutionNote string `json:"resolution_note" validate:"required,max=1000"`
+}
+
+// İş emrini iptal etmek için view model
+type WorkOrderCancelVM struct {
+	Note string `json:"note" validate:"required,max=1000"`
+}
+
+// İş emrine parça eklemek için view model
+type WorkOrderPartCreateVM struct {
+	Name       string  `json:"name" validate:"required,max=150"`
+	PartNumber string  `json:"part_number" validate:"max=64"`
+	Quantity   int     `json:"quantity" validate:"required,gt=0"`
+	UnitCost   float64 `json:"unit_cost" validate:"gte=0"`
+}
+
+func (vm WorkOrderPartCreateVM) ToDBModel(workOrderID uint) models.WorkOrderPart {
+	return models.WorkOrderPart{
+		WorkOrderID: workOrderID,
+		Name:        strings.TrimSpace(vm.Name),
+		PartNumber:  strings.TrimSpace(vm.PartNumber),
+		Quantity:    vm.Quantity,
+		UnitCost:    vm.UnitCost,
+	}
+}
+
+// İş emri parçası için view model
+type WorkOrderPartDetailVM struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	PartNumber string  `json:"part_number"`
+	Quantity   int     `json:"quantity"`
+	UnitCost   float64 `json:"unit_cost"`
+}
+
+func (vm WorkOrderPartDetailVM) ToViewModel(m models.WorkOrderPart) WorkOrderPartDetailVM {
+	vm.ID = m.ID
+	vm.Name = m.Name
+	vm.PartNumber = m.PartNumber
+	vm.Quantity = m.Quantity
+	vm.UnitCost = m.UnitCost
+	return vm
+}
+
+// İş emri detayları için view model
+type WorkOrderDetailVM struct {
+	ID               int64                   `json:"id"`
+	MotorbikeID      uint                    `json:"motorbike_id"`
+	IssueType        string                  `json:"issue_type"`
+	Priority         string                  `json:"priority"`
+	Status           string                  `json:"status"`
+	Description      string                  `json:"description"`
+	ReportedBy       *uint                   `json:"reported_by"`
+	TechnicianID     *uint                   `json:"technician_id"`
+	LabourMinutes    int                     `json:"labour_minutes"`
+	Parts            []WorkOrderPartDetailVM `json:"parts"`
+	PartsCost        float64                 `json:"parts_cost"`
+	ResolutionNote   string                  `json:"resolution_note"`
+	ExpectedReturnAt *time.Time              `json:"expected_return_at"`
+	StartedAt        *time.Time              `json:"started_at"`
+	ClosedAt         *time.Time              `json:"closed_at"`
+	PlanID           *uint                   `json:"plan_id"`
+	CreatedAt        time.Time               `json:"created_at"`
+}
+
+func (vm WorkOrderDetailVM) ToViewModel(m models.WorkOrder) WorkOrderDetailVM {
+	vm.ID = m.ID
+	vm.MotorbikeID = m.MotorbikeID
+	vm.IssueType = m.IssueType.String()
+	vm.Priority = m.Priority.String()
+	vm.Status = m.Status.String()
+	vm.Description = m.Description
+	vm.ReportedBy = m.ReportedBy
+	vm.TechnicianID = m.TechnicianID
+	vm.LabourMinutes = m.LabourMinutes
+	vm.Parts = []WorkOrderPartDetailVM{}
+	for _, part := range m.Parts {
+		vm.Parts = append(vm.Parts, WorkOrderPartDetailVM{}.ToViewModel(part))
+	}
+	vm.PartsCost = m.PartsCost()
+	vm.ResolutionNote = m.ResolutionNote
+	vm.ExpectedReturnAt = m.ExpectedReturnAt
+	vm.StartedAt = m.StartedAt
+	vm.ClosedAt = m.ClosedAt
+	vm.PlanID = m.PlanID
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
+
+// Bakımdaki motor ve açık iş emirleri için view model
+type MaintenanceBikeVM struct {
+	motorViewmodel.BikeDetailVM
+	OpenWorkOrders []WorkOrderDetailVM `json:"open_work_orders"`
+}
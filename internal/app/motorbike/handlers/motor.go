@@ -3,20 +3,25 @@ package handlers
 import (
 	"errors"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"motorbike-rental-backend/internal/app/motorbike/models"
 	bikeService "motorbike-rental-backend/internal/app/motorbike/services"
 	viewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
 	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/log"
+	"motorbike-rental-backend/pkg/storage"
 	"strconv"
 )
 
 type MotorHandler struct {
 	bikeService bikeService.IMotorService
+	uploader    storage.Uploader
 }
 
-func NewMotorHandler(s bikeService.IMotorService) MotorHandler {
-	return MotorHandler{bikeService: s}
+func NewMotorHandler(s bikeService.IMotorService, u storage.Uploader) MotorHandler {
+	return MotorHandler{bikeService: s, uploader: u}
 }
 
 func (h MotorHandler) CreateMotor(ctx *app.Ctx) error {
@@ -127,16 +132,71 @@ func (h MotorHandler) GetPhotosByID(ctx *app.Ctx) error {
 
 	var photoDetailVMs []viewmodel.PhotoDetailVM
 	for _, photo := range photos {
-		photoDetailVMs = append(photoDetailVMs, viewmodel.PhotoDetailVM{
-			ID:          int(photo.ID),
-			MotorbikeID: photo.MotorbikeID,
-			PhotoURL:    photo.PhotoURL,
-		})
+		photoDetailVMs = append(photoDetailVMs, viewmodel.NewPhotoDetailVM(photo))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(photoDetailVMs)
 }
 
+// (adminler için) motora fotoğraf yükler -> /motorbike/:id/photo (multipart: photo)
+func (h MotorHandler) UploadPhoto(ctx *app.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID."})
+	}
+
+	if _, err = h.bikeService.GetMotorByID(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Motor bulunamadı."})
+	}
+
+	file, err := ctx.FormFile("photo")
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Fotoğraf yüklenemedi."})
+	}
+
+	photoRef, err := h.uploader.Image(ctx.Context(), "motorbikes/"+strconv.Itoa(id), file)
+	if err != nil {
+		if storage.IsInvalidUpload(err) {
+			return errorsx.BadRequestError("Fotoğraf JPEG, PNG veya WebP olmalı ve boyut sınırını aşmamalı!")
+		}
+		return errorsx.InternalError(err, "Fotoğraf kaydedilemedi!")
+	}
+
+	photo := models.MotorbikePhoto{MotorbikeID: id, PhotoURL: photoRef}
+	if err = h.bikeService.AddPhotosToMotor(ctx.Context(), []models.MotorbikePhoto{photo}); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Fotoğraflar eklenirken bir hata oluştu."})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Fotoğraf eklendi!"})
+}
+
+// (adminler için) motorun fotoğrafını siler, yüklenmiş fotoğrafın dosyası da silinir -> /motorbike/:id/photo/:photoID
+func (h MotorHandler) DeletePhoto(ctx *app.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID."})
+	}
+	photoID, err := strconv.Atoi(ctx.Params("photoID"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz fotoğraf ID."})
+	}
+
+	photo, err := h.bikeService.DeletePhoto(ctx.Context(), id, photoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Fotoğraf bulunamadı!"})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Fotoğraf silinirken bir hata oluştu!"})
+	}
+
+	if err = h.uploader.Delete(ctx.Context(), photo.PhotoURL); err != nil {
+		l := log.GetLogger("")
+		l.Error("Fotoğraf dosyası silinemedi", zap.String("photo_url", photo.PhotoURL), zap.Error(err))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Fotoğraf silindi!"})
+}
+
 func (h MotorHandler) GetMotorByID(ctx *app.Ctx) error {
 	param := ctx.Params("id")
 
@@ -190,34 +250,6 @@ func (h MotorHandler) GetAvailableMotors(ctx *app.Ctx) error {
 	}
 }
 
-func (h MotorHandler) GetMaintenanceMotors(ctx *app.Ctx) error {
-	motors, err := h.bikeService.GetMotorsForStatus(ctx.Context(), string(models.BikeInMaintenance))
-	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bir hata oluştu!"})
-	}
-
-	if len(*motors) == 0 {
-		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "bakımda motor yok!"})
-	} else {
-		var motorDetails []viewmodel.BikeDetailVM
-
-		for _, motor := range *motors {
-			// Her motorun fotoğraflarını al
-			var photos []models.MotorbikePhoto
-			err := h.bikeService.GetPhotosByID(ctx.Context(), strconv.FormatInt(motor.ID, 10), &photos)
-			if err != nil {
-				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Motorlar getirilirken bir hata oluştu."})
-			}
-
-			// Motor detaylarını oluştur
-			motorDetail := viewmodel.NewBikeDetailVM(motor, photos)
-			motorDetails = append(motorDetails, motorDetail)
-		}
-
-		return ctx.Status(fiber.StatusOK).JSON(motorDetails)
-	}
-}
-
 func (h MotorHandler) GetRentedMotors(ctx *app.Ctx) error {
 	motors, err := h.bikeService.GetMotorsForStatus(ctx.Context(), string(models.BikeRented))
 	if err != nil {
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"motorbike-rental-backend/internal/app/motorbike/models"
+	bikeService "motorbike-rental-backend/internal/app/motorbike/services"
+	viewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/geo"
+	"strconv"
+)
+
+const (
+	defaultNearbyRadius = 1000  // metre
+	maxNearbyRadius     = 10000 // metre
+	defaultNearbyLimit  = 20
+	maxNearbyLimit      = 100
+	maxBBoxSpan         = 1.0 // derece, daha büyük alanlar harita için anlamsız
+)
+
+// konuma en yakın müsait motorları yakından uzağa döner -> /motorbikes/nearby?lat=41.01&lng=28.97&radius_m=500&limit=20
+func (h MotorHandler) GetNearbyMotors(ctx *app.Ctx) error {
+	center, err := parsePoint(ctx)
+	if err != nil {
+		return errorsx.BadRequestError("Lütfen geçerli bir lat ve lng değeri girin!")
+	}
+
+	radius, err := queryFloat(ctx, "radius_m", defaultNearbyRadius)
+	if err != nil || radius <= 0 || radius > maxNearbyRadius {
+		return errorsx.BadRequestError("radius_m 0 ile 10000 arasında olmalıdır!")
+	}
+
+	limit, err := queryLimit(ctx)
+	if err != nil {
+		return errorsx.BadRequestError("limit 1 ile 100 arasında olmalıdır!")
+	}
+
+	motors, err := h.bikeService.GetNearbyMotors(ctx.Context(), string(models.BikeAvailable), center, radius, limit)
+	if err != nil {
+		return errorsx.InternalError(err, "Yakındaki motorlar getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toNearbyVMs(motors), len(motors))
+}
+
+// harita ekranındaki dikdörtgen içindeki müsait motorları döner, lat/lng verilmişse o noktaya, verilmemişse alanın merkezine göre sıralanır
+// -> /motorbikes/within?min_lat=40.98&min_lng=28.95&max_lat=41.02&max_lng=29.01&limit=50
+func (h MotorHandler) GetMotorsInBBox(ctx *app.Ctx) error {
+	var box geo.BBox
+	var err error
+	for key, target := range map[string]*float64{"min_lat": &box.MinLat, "min_lng": &box.MinLng, "max_lat": &box.MaxLat, "max_lng": &box.MaxLng} {
+		if *target, err = strconv.ParseFloat(ctx.Query(key), 64); err != nil {
+			return errorsx.BadRequestError("Lütfen geçerli min_lat, min_lng, max_lat ve max_lng değerleri girin!")
+		}
+	}
+
+	if box.MinLat > box.MaxLat || box.MinLng > box.MaxLng || box.MinLat < -90 || box.MaxLat > 90 || box.MinLng < -180 || box.MaxLng > 180 {
+		return errorsx.BadRequestError("Geçersiz alan!")
+	}
+	if box.MaxLat-box.MinLat > maxBBoxSpan || box.MaxLng-box.MinLng > maxBBoxSpan {
+		return errorsx.BadRequestError("Alan çok büyük, lütfen haritayı yakınlaştırın!")
+	}
+
+	center := box.Center()
+	if ctx.Query("lat") != "" || ctx.Query("lng") != "" {
+		if center, err = parsePoint(ctx); err != nil {
+			return errorsx.BadRequestError("Lütfen geçerli bir lat ve lng değeri girin!")
+		}
+	}
+
+	limit, err := queryLimit(ctx)
+	if err != nil {
+		return errorsx.BadRequestError("limit 1 ile 100 arasında olmalıdır!")
+	}
+
+	motors, err := h.bikeService.GetMotorsInBBox(ctx.Context(), string(models.BikeAvailable), box, center, limit)
+	if err != nil {
+		return errorsx.InternalError(err, "Alandaki motorlar getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(toNearbyVMs(motors), len(motors))
+}
+
+func parsePoint(ctx *app.Ctx) (geo.Point, error) {
+	lat, err := strconv.ParseFloat(ctx.Query("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return geo.Point{}, fiber.ErrBadRequest
+	}
+
+	lng, err := strconv.ParseFloat(ctx.Query("lng"), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return geo.Point{}, fiber.ErrBadRequest
+	}
+
+	return geo.Point{Lat: lat, Lng: lng}, nil
+}
+
+func queryFloat(ctx *app.Ctx, key string, fallback float64) (float64, error) {
+	if ctx.Query(key) == "" {
+		return fallback, nil
+	}
+	return strconv.ParseFloat(ctx.Query(key), 64)
+}
+
+func queryLimit(ctx *app.Ctx) (int, error) {
+	limit := ctx.QueryInt("limit", defaultNearbyLimit)
+	if limit <= 0 || limit > maxNearbyLimit {
+		return 0, fiber.ErrBadRequest
+	}
+	return limit, nil
+}
+
+func toNearbyVMs(motors []bikeService.NearbyMotor) []viewmodel.NearbyBikeVM {
+	vms := make([]viewmodel.NearbyBikeVM, 0, len(motors))
+	for _, m := range motors {
+		vms = append(vms, viewmodel.NewNearbyBikeVM(m.Motorbike, m.DistanceMeters))
+	}
+	return vms
+}
@@ -1,11 +1,14 @@
 package models
 
+import "time"
+
 type MotorBikeStatus string
 
 const (
 	BikeAvailable     MotorBikeStatus = "available"
 	BikeInMaintenance MotorBikeStatus = "maintenance"
 	BikeRented        MotorBikeStatus = "rented"
+	BikeReserved      MotorBikeStatus = "reserved"
 )
 
 type LockStatus string
@@ -18,12 +21,18 @@ const (
 // Motorbike modeli
 type Motorbike struct {
 	BaseModel
-	Model             string           `gorm:"not null"`
-	LocationLatitude  float64          `gorm:"not null"`
-	LocationLongitude float64          `gorm:"not null"`
-	Photos            []MotorbikePhoto `gorm:"foreignKey:MotorbikeID"`
-	Status            MotorBikeStatus  `gorm:"type:varchar(20);not null"` // ENUM gibi çalışacak şekilde varchar tanımlandı
-	LockStatus        LockStatus       `gorm:"type:varchar(10);not null"` // ENUM gibi çalışacak şekilde varchar tanımlandı
+	Model              string           `gorm:"not null"`
+	LocationLatitude   float64          `gorm:"not null"`
+	LocationLongitude  float64          `gorm:"not null"`
+	Photos             []MotorbikePhoto `gorm:"foreignKey:MotorbikeID"`
+	Status             MotorBikeStatus  `gorm:"type:varchar(20);not null"` // ENUM gibi çalışacak şekilde varchar tanımlandı
+	LockStatus         LockStatus       `gorm:"type:varchar(10);not null"` // ENUM gibi çalışacak şekilde varchar tanımlandı
+	GridCell           int64            `gorm:"->"`                        // konumdan veritabanında üretilir (geo.GridCell), yakındaki motor sorguları için
+	BatteryLevel       *float64         // cihazın bildirdiği son batarya/yakıt seviyesi (%)
+	LastSeenAt         *time.Time       // cihazdan son heartbeat zamanı
+	TotalDistanceM     float64          `gorm:"column:total_distance_meters;not null"` // biten sürüşlerin toplam mesafesi, periyodik bakım için
+	TotalRideSeconds   int64            `gorm:"not null"`                              // biten sürüşlerin toplam süresi
+	MaintenancePending bool             `gorm:"not null"`                              // kiradayken iş emri açıldı, kiralama bitince 'maintenance' durumuna geçer
 }
 
 type MotorbikePhoto struct {
@@ -36,6 +45,15 @@ func (Motorbike) TableName() string {
 	return "motorbike"
 }
 
+// ReleaseStatus kiralama biten motorun geçeceği durum: kiradayken bakım istendiyse 'maintenance', değilse 'available'.
+// Durumla birlikte maintenance_pending de temizlenmelidir.
+func (m Motorbike) ReleaseStatus() MotorBikeStatus {
+	if m.MaintenancePending {
+		return BikeInMaintenance
+	}
+	return BikeAvailable
+}
+
 func (r MotorBikeStatus) String() string {
 	switch r {
 	case BikeAvailable:
@@ -44,6 +62,8 @@ func (r MotorBikeStatus) String() string {
 		return "maintenance"
 	case BikeRented:
 		return "rented"
+	case BikeReserved:
+		return "reserved"
 	default:
 		return "unknown"
 	}
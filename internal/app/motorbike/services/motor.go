@@ -5,19 +5,34 @@ import (
 	"errors"
 	"gorm.io/gorm"
 	"motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/pkg/geo"
+	"motorbike-rental-backend/pkg/storage"
+	"sort"
 	"time"
 )
 
+// maxGridCells bu sayıdan fazla hücreye yayılan alanlarda hücre filtresi yerine enlem/boylam aralığı kullanılır
+const maxGridCells = 400
+
+// NearbyMotor motor ve aranan noktaya olan uzaklığı
+type NearbyMotor struct {
+	Motorbike      models.Motorbike
+	DistanceMeters float64
+}
+
 type IMotorService interface {
 	CreateMotor(ctx context.Context, motorbike *models.Motorbike) error
 	UpdateMotor(ctx context.Context, motorbike *models.Motorbike) error
 	DeleteMotor(ctx context.Context, motorbikeID int) error
 	UpdatePhotosForMotor(ctx context.Context, newPhotos []models.MotorbikePhoto, motorbikeID int) error
 	AddPhotosToMotor(ctx context.Context, photos []models.MotorbikePhoto) error
+	DeletePhoto(ctx context.Context, motorbikeID int, photoID int) (*models.MotorbikePhoto, error)
 	GetPhotosByID(ctx context.Context, motorbikeID string, photos *[]models.MotorbikePhoto) error
 	GetAllMotors(ctx context.Context) (*[]models.Motorbike, error)
 	GetMotorByID(ctx context.Context, motorbikeID int) (*models.Motorbike, error)
-	GetMotorsForStatus(ctx context.Context, status string) (*[]models.Motorbike, error)
+	GetMotorsForStatus(ctx context.Context, status string, area ...geo.BBox) (*[]models.Motorbike, error)
+	GetNearbyMotors(ctx context.Context, status string, center geo.Point, radiusMeters float64, limit int) ([]NearbyMotor, error)
+	GetMotorsInBBox(ctx context.Context, status string, box geo.BBox, center geo.Point, limit int) ([]NearbyMotor, error)
 }
 type MotorService struct {
 	DB *gorm.DB
@@ -31,8 +46,11 @@ func (s *MotorService) CreateMotor(ctx context.Context, motorbike *models.Motorb
 	return s.DB.WithContext(ctx).Create(motorbike).Error
 }
 
+// UpdateMotor cihazın bildirdiği alanları (batarya, son görülme) ve kullanım sayaçlarını değiştirmez,
+// bunlar yalnızca telemetriyle ve sürüş bitişinde güncellenir
 func (s *MotorService) UpdateMotor(ctx context.Context, motorbike *models.Motorbike) error {
-	return s.DB.WithContext(ctx).Save(motorbike).Error
+	// kilit durumu ve telemetri yalnızca cihazdan güncellenir
+	return s.DB.WithContext(ctx).Omit("lock_status", "battery_level", "last_seen_at", "total_distance_meters", "total_ride_seconds").Save(motorbike).Error
 }
 
 func (s *MotorService) DeleteMotor(ctx context.Context, motorbikeID int) error {
@@ -58,9 +76,10 @@ func (s *MotorService) DeleteMotor(ctx context.Context, motorbikeID int) error {
 	return nil
 }
 
+// UpdatePhotosForMotor adresle eklenen fotoğrafları değiştirir, yüklenen (storage) fotoğraflara dokunmaz
 func (s *MotorService) UpdatePhotosForMotor(ctx context.Context, newPhotos []models.MotorbikePhoto, motorbikeID int) error {
 	// Önce mevcut fotoğrafları sil
-	if err := s.DB.WithContext(ctx).Where("motorbike_id = ?", motorbikeID).Delete(&models.MotorbikePhoto{}).Error; err != nil {
+	if err := s.DB.WithContext(ctx).Where("motorbike_id = ? AND photo_url NOT LIKE ?", motorbikeID, storage.Scheme+"%").Delete(&models.MotorbikePhoto{}).Error; err != nil {
 		return err
 	}
 
@@ -79,6 +98,20 @@ func (s *MotorService) AddPhotosToMotor(ctx context.Context, photos []models.Mot
 	return s.DB.WithContext(ctx).Create(&photos).Error
 }
 
+// DeletePhoto motorun fotoğrafını siler ve silinen kaydı döner, dosyanın silinmesi çağırana bırakılır
+func (s *MotorService) DeletePhoto(ctx context.Context, motorbikeID int, photoID int) (*models.MotorbikePhoto, error) {
+	var photo models.MotorbikePhoto
+	if err := s.DB.WithContext(ctx).Where("id = ? AND motorbike_id = ?", photoID, motorbikeID).First(&photo).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.DB.WithContext(ctx).Delete(&photo).Error; err != nil {
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
 func (s *MotorService) GetAllMotors(ctx context.Context) (*[]models.Motorbike, error) {
 	var motors []models.Motorbike
 	if err := s.DB.WithContext(ctx).Find(&motors).Error; err != nil {
@@ -101,11 +134,88 @@ func (s *MotorService) GetMotorByID(ctx context.Context, motorbikeID int) (*mode
 	return &motor, nil
 }
 
-func (s *MotorService) GetMotorsForStatus(ctx context.Context, status string) (*[]models.Motorbike, error) {
+// GetMotorsForStatus verilen durumdaki motorları döner, alan verilmişse yalnızca o dikdörtgen içindekiler gelir.
+// Alan küçükse önce grid_cell indeksiyle aday hücreler seçilir, ardından kesin enlem/boylam aralığı uygulanır.
+func (s *MotorService) GetMotorsForStatus(ctx context.Context, status string, area ...geo.BBox) (*[]models.Motorbike, error) {
 	var motors []models.Motorbike
-	if err := s.DB.WithContext(ctx).Where("status = ?", status).Find(&motors).Error; err != nil {
+
+	query := s.DB.WithContext(ctx).Where("status = ?", status)
+	for _, box := range area {
+		if cells, ok := geo.GridCells(box, maxGridCells); ok {
+			query = query.Where("grid_cell IN ?", cells)
+		}
+		query = query.Where("location_latitude BETWEEN ? AND ? AND location_longitude BETWEEN ? AND ?",
+			box.MinLat, box.MaxLat, box.MinLng, box.MaxLng)
+	}
+
+	if err := query.Find(&motors).Error; err != nil {
 		return nil, err
 	}
 
 	return &motors, nil
 }
+
+// GetNearbyMotors merkeze radiusMeters mesafedeki motorları yakından uzağa sıralı döner
+func (s *MotorService) GetNearbyMotors(ctx context.Context, status string, center geo.Point, radiusMeters float64, limit int) ([]NearbyMotor, error) {
+	motors, err := s.GetMotorsForStatus(ctx, status, geo.BBoxAround(center.Lat, center.Lng, radiusMeters))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sortByDistance(ctx, *motors, center, radiusMeters, limit)
+}
+
+// GetMotorsInBBox dikdörtgen içindeki motorları center noktasına yakından uzağa sıralı döner
+func (s *MotorService) GetMotorsInBBox(ctx context.Context, status string, box geo.BBox, center geo.Point, limit int) ([]NearbyMotor, error) {
+	motors, err := s.GetMotorsForStatus(ctx, status, box)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sortByDistance(ctx, *motors, center, 0, limit)
+}
+
+// sortByDistance haversine mesafesine göre sıralar, radiusMeters > 0 ise dışarıda kalanları atar
+// ve yalnızca döndürülecek motorların fotoğraflarını tek sorguda yükler.
+func (s *MotorService) sortByDistance(ctx context.Context, motors []models.Motorbike, center geo.Point, radiusMeters float64, limit int) ([]NearbyMotor, error) {
+	var result []NearbyMotor
+	for _, motor := range motors {
+		distance := geo.Haversine(center.Lat, center.Lng, motor.LocationLatitude, motor.LocationLongitude)
+		if radiusMeters > 0 && distance > radiusMeters {
+			continue
+		}
+		result = append(result, NearbyMotor{Motorbike: motor, DistanceMeters: distance})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DistanceMeters < result[j].DistanceMeters
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	ids := make([]int64, 0, len(result))
+	for _, r := range result {
+		ids = append(ids, r.Motorbike.ID)
+	}
+
+	var photos []models.MotorbikePhoto
+	if err := s.DB.WithContext(ctx).Where("motorbike_id IN ?", ids).Find(&photos).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		for _, photo := range photos {
+			if int64(photo.MotorbikeID) == result[i].Motorbike.ID {
+				result[i].Motorbike.Photos = append(result[i].Motorbike.Photos, photo)
+			}
+		}
+	}
+
+	return result, nil
+}
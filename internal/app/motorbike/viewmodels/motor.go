@@ -1,7 +1,9 @@
 package viewmodels
 
 import (
+	"math"
 	"motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/pkg/storage"
 	"time"
 )
 
@@ -15,7 +17,7 @@ type BikeCreateVM struct {
 	Model             string          `json:"model" validate:"required,max=100"`
 	LocationLatitude  float64         `json:"location_latitude" validate:"required,numeric"`
 	LocationLongitude float64         `json:"location_longitude" validate:"required,numeric"`
-	Status            string          `json:"status" validate:"required,oneof=available maintenance rented"`
+	Status            string          `json:"status" validate:"required,oneof=available maintenance rented reserved"`
 	Photos            []PhotoCreateVM `json:"photos"`
 	LockStatus        string          `json:"lock_status" validate:"required,oneof=locked unlocked"`
 }
@@ -48,9 +50,9 @@ type BikeUpdateVM struct {
 	Model             string          `json:"model" validate:"required,max=100"`
 	LocationLatitude  float64         `json:"location_latitude" validate:"required,numeric"`
 	LocationLongitude float64         `json:"location_longitude" validate:"required,numeric"`
-	Status            string          `json:"status" validate:"required,oneof=available maintenance rented"`
+	Status            string          `json:"status" validate:"required,oneof=available maintenance rented reserved"`
 	Photos            []PhotoCreateVM `json:"photos"`
-	LockStatus        string          `json:"lock_status" validate:"required,oneof=locked unlocked"`
+	// lock_status güncellenemez, yalnızca cihaz bildirdiğinde değişir (adminler /motorbike/:id/command kullanır)
 }
 
 // Güncellenmiş Motorbike modeline dönüştürme
@@ -59,7 +61,6 @@ func (vm BikeUpdateVM) ToDBModel(m models.Motorbike) models.Motorbike {
 	m.LocationLatitude = vm.LocationLatitude
 	m.LocationLongitude = vm.LocationLongitude
 	m.Status = models.MotorBikeStatus(vm.Status)
-	m.LockStatus = models.LockStatus(vm.LockStatus)
 	return m
 }
 
@@ -82,6 +83,14 @@ type PhotoDetailVM struct {
 	PhotoURL    string `json:"photo_url"`
 }
 
+func NewPhotoDetailVM(photo models.MotorbikePhoto) PhotoDetailVM {
+	return PhotoDetailVM{
+		ID:          int(photo.ID),
+		MotorbikeID: photo.MotorbikeID,
+		PhotoURL:    storage.URL(photo.PhotoURL),
+	}
+}
+
 // Motorbike detayları için view model
 type BikeDetailVM struct {
 	ID                int             `json:"id"`
@@ -91,17 +100,17 @@ type BikeDetailVM struct {
 	Status            string          `json:"status"`
 	Photos            []PhotoDetailVM `json:"photos"`
 	LockStatus        string          `json:"lock_status"`
+	BatteryLevel      *float64        `json:"battery_level"`
+	LastSeenAt        *time.Time      `json:"last_seen_at"`
+	TotalDistanceKm   float64         `json:"total_distance_km"`
+	TotalRideHours    float64         `json:"total_ride_hours"`
 }
 
 // Motorbike modelini detay view modeline dönüştürme
 func NewBikeDetailVM(motorbike models.Motorbike, photos []models.MotorbikePhoto) BikeDetailVM {
 	var photoVMs []PhotoDetailVM
 	for _, photo := range photos {
-		photoVMs = append(photoVMs, PhotoDetailVM{
-			ID:          int(photo.ID),
-			MotorbikeID: photo.MotorbikeID,
-			PhotoURL:    photo.PhotoURL,
-		})
+		photoVMs = append(photoVMs, NewPhotoDetailVM(photo))
 	}
 
 	return BikeDetailVM{
@@ -112,6 +121,10 @@ func NewBikeDetailVM(motorbike models.Motorbike, photos []models.MotorbikePhoto)
 		Status:            motorbike.Status.String(),
 		Photos:            photoVMs,
 		LockStatus:        motorbike.LockStatus.String(),
+		BatteryLevel:      motorbike.BatteryLevel,
+		LastSeenAt:        motorbike.LastSeenAt,
+		TotalDistanceKm:   math.Round(motorbike.TotalDistanceM/10) / 100,
+		TotalRideHours:    math.Round(float64(motorbike.TotalRideSeconds)/36) / 100,
 	}
 }
 
@@ -123,3 +136,16 @@ func formatNullableTime(t *time.Time) *string {
 	formattedTime := t.Format("2006-01-02 15:04:05")
 	return &formattedTime
 }
+
+// Yakındaki motorlar için view model, aranan noktaya uzaklığı metre cinsinden içerir
+type NearbyBikeVM struct {
+	BikeDetailVM
+	DistanceMeters float64 `json:"distance_m"`
+}
+
+func NewNearbyBikeVM(motorbike models.Motorbike, distanceMeters float64) NearbyBikeVM {
+	return NearbyBikeVM{
+		BikeDetailVM:   NewBikeDetailVM(motorbike, motorbike.Photos),
+		DistanceMeters: math.Round(distanceMeters),
+	}
+}
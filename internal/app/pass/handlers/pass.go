@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	passService "motorbike-rental-backend/internal/app/pass/services"
+	"motorbike-rental-backend/internal/app/pass/viewmodels"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type PassHandler struct {
+	passService passService.IPassService
+}
+
+func NewPassHandler(s passService.IPassService) PassHandler {
+	return PassHandler{passService: s}
+}
+
+// satıştaki paketleri döner
+func (h PassHandler) GetActiveProducts(ctx *app.Ctx) error {
+	products, err := h.passService.GetActiveProducts(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Abonelik paketleri getirilemedi!")
+	}
+
+	var productDetails []viewmodels.PassProductDetailVM
+	for _, product := range *products {
+		productDetails = append(productDetails, viewmodels.PassProductDetailVM{}.ToViewModel(product))
+	}
+
+	return ctx.SuccessResponse(productDetails, len(productDetails))
+}
+
+func (h PassHandler) GetAllProducts(ctx *app.Ctx) error {
+	products, err := h.passService.GetAllProducts(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Abonelik paketleri getirilemedi!")
+	}
+
+	var productDetails []viewmodels.PassProductDetailVM
+	for _, product := range *products {
+		productDetails = append(productDetails, viewmodels.PassProductDetailVM{}.ToViewModel(product))
+	}
+
+	return ctx.SuccessResponse(productDetails, len(productDetails))
+}
+
+func (h PassHandler) CreateProduct(ctx *app.Ctx) error {
+	var vm viewmodels.PassProductCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	product := vm.ToDBModel()
+	if err := h.passService.CreateProduct(ctx.Context(), &product); err != nil {
+		return errorsx.InternalError(err, "Abonelik paketi oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Abonelik paketi eklendi!", "id": product.ID})
+}
+
+func (h PassHandler) UpdateProduct(ctx *app.Ctx) error {
+	var vm viewmodels.PassProductUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	product, err := h.passService.GetProductByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Abonelik paketi bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Abonelik paketi getirilirken hata oluştu!")
+	}
+
+	updatedProduct := vm.ToDBModel(*product)
+	if err = h.passService.UpdateProduct(ctx.Context(), &updatedProduct); err != nil {
+		return errorsx.InternalError(err, "Abonelik paketi güncellenirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Abonelik paketi güncellendi!"})
+}
+
+func (h PassHandler) DeleteProduct(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.passService.DeleteProduct(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir abonelik paketi zaten yok!")
+		}
+		return errorsx.InternalError(err, "Abonelik paketi silinirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Abonelik paketi silindi!"})
+}
+
+// paket bedeli sürüş ödemeleriyle aynı sağlayıcıdan (varsayılan cüzdan) tahsil edilir
+func (h PassHandler) PurchasePass(ctx *app.Ctx) error {
+	var vm viewmodels.PassPurchaseVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	pass, err := h.passService.PurchasePass(ctx.Context(), uint(ctx.GetUserID()), vm.ProductID)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Abonelik paketi bulunamadı!")
+		}
+		if errorsx.Is(err, passService.ErrPassProductInactive) {
+			return errorsx.BadRequestError("Bu paket artık satılmıyor!")
+		}
+		if errorsx.Is(err, paymentService.ErrInsufficientFunds) || errorsx.Is(err, paymentService.ErrPaymentDeclined) {
+			return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödeme alınamadı, lütfen bakiyenizi kontrol edin!"})
+		}
+		return errorsx.InternalError(err, "Abonelik paketi satın alınamadı!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Abonelik paketi satın alındı!", "pass": viewmodels.UserPassVM{}.ToViewModel(*pass)})
+}
+
+func (h PassHandler) GetMyPasses(ctx *app.Ctx) error {
+	passes, err := h.passService.GetUserPasses(ctx.Context(), uint(ctx.GetUserID()))
+	if err != nil {
+		return errorsx.InternalError(err, "Abonelik paketleri getirilemedi!")
+	}
+
+	var passDetails []viewmodels.UserPassVM
+	for _, pass := range *passes {
+		passDetails = append(passDetails, viewmodels.UserPassVM{}.ToViewModel(pass))
+	}
+
+	return ctx.SuccessResponse(passDetails, len(passDetails))
+}
+
+// (adminler için) paket sahiplerini döner -> /passes/holders?product_id=2&all=true
+// varsayılan olarak yalnızca şu an geçerli paketler döner, all=true ile süresi dolanlar da gelir
+func (h PassHandler) GetPassHolders(ctx *app.Ctx) error {
+	productID := ctx.QueryInt("product_id", 0)
+	activeOnly := !ctx.QueryBool("all", false)
+
+	passes, err := h.passService.GetPassHolders(ctx.Context(), productID, activeOnly)
+	if err != nil {
+		return errorsx.InternalError(err, "Paket sahipleri getirilemedi!")
+	}
+
+	var holders []viewmodels.PassHolderVM
+	for _, pass := range *passes {
+		holders = append(holders, viewmodels.PassHolderVM{}.ToViewModel(pass))
+	}
+
+	return ctx.SuccessResponse(holders, len(holders))
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	modelUser "motorbike-rental-backend/internal/app/user-and-auth/models"
+	"time"
+)
+
+// PassProduct satın alınabilen abonelik paketi (günlük, haftalık, aylık vb.)
+type PassProduct struct {
+	BaseModel
+	Name               string  `gorm:"type:varchar(100);not null"`
+	Description        string  `gorm:"type:varchar(255)"`
+	DurationDays       int     `gorm:"not null"`
+	Price              float64 `gorm:"not null"`
+	FreeUnlock         bool    `gorm:"not null"` // kilit açma ücreti alınmaz
+	FreeMinutesPerRide int     `gorm:"not null"` // her sürüşte ücretsiz dakika
+	IsActive           bool    `gorm:"not null"` // pasif paketler satılmaz, satılmış paketler süresi dolana kadar geçerlidir
+}
+
+// UserPass kullanıcının satın aldığı paket. Geçerlilik aralığı satın alırken sabitlenir,
+// paketin sonradan değişmesi satılmış paketlerin süresini etkilemez.
+type UserPass struct {
+	BaseModel
+	UserID        uint      `gorm:"not null"`
+	PassProductID uint      `gorm:"not null"`
+	StartsAt      time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	PricePaid     float64   `gorm:"not null"`
+	PaymentID     *uint     // tahsilat kaydı
+
+	User    modelUser.User `gorm:"foreignKey:UserID"`
+	Product PassProduct    `gorm:"foreignKey:PassProductID"`
+}
+
+func (PassProduct) TableName() string {
+	return "pass_products"
+}
+
+func (UserPass) TableName() string {
+	return "user_passes"
+}
+
+// IsActiveAt paketin verilen anda geçerli olup olmadığını döner
+func (p UserPass) IsActiveAt(t time.Time) bool {
+	return !t.Before(p.StartsAt) && t.Before(p.ExpiresAt)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"math"
+	"motorbike-rental-backend/internal/app/pass/models"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+)
+
+// Benefit paketin sürüş ücretinden düşeceği tutarı hesaplar: kilit açma ücreti ve her sürüşteki ücretsiz dakikalar.
+// Ücretsiz dakikalar çarpanlar uygulanmış ortalama dakika ücretinden düşülür, molalar hariçtir.
+func Benefit(p models.PassProduct, q pricingService.Quote) float64 {
+	if q.Total <= 0 {
+		return 0
+	}
+
+	var benefit float64
+	if p.FreeUnlock {
+		benefit += q.UnlockFee
+	}
+
+	activeMinutes := float64(q.Minutes - q.PausedMinutes)
+	if p.FreeMinutesPerRide > 0 && activeMinutes > 0 {
+		benefit += math.Min(float64(p.FreeMinutesPerRide), activeMinutes) * (q.TimeCost / activeMinutes)
+	}
+
+	return round2(math.Min(benefit, q.Total))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/pass/models"
+	paymentModel "motorbike-rental-backend/internal/app/payment/models"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	"time"
+)
+
+var ErrPassProductInactive = errors.New("pass product is not on sale")
+
+type IPassService interface {
+	GetAllProducts(ctx context.Context) (*[]models.PassProduct, error)
+	GetActiveProducts(ctx context.Context) (*[]models.PassProduct, error)
+	GetProductByID(ctx context.Context, id int) (*models.PassProduct, error)
+	CreateProduct(ctx context.Context, product *models.PassProduct) error
+	UpdateProduct(ctx context.Context, product *models.PassProduct) error
+	DeleteProduct(ctx context.Context, id int) error
+	PurchasePass(ctx context.Context, userID uint, productID int) (*models.UserPass, error)
+	GetUserPasses(ctx context.Context, userID uint) (*[]models.UserPass, error)
+	GetPassHolders(ctx context.Context, productID int, activeOnly bool) (*[]models.UserPass, error)
+	BestPassFor(ctx context.Context, userID uint, at time.Time, q pricingService.Quote) (*models.UserPass, float64, error)
+}
+
+type PassService struct {
+	DB             *gorm.DB
+	paymentService paymentService.IPaymentService
+}
+
+func NewPassService(db *gorm.DB, paymentService paymentService.IPaymentService) IPassService {
+	return &PassService{DB: db, paymentService: paymentService}
+}
+
+func (s *PassService) GetAllProducts(ctx context.Context) (*[]models.PassProduct, error) {
+	var products []models.PassProduct
+	if err := s.DB.WithContext(ctx).Order("id").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return &products, nil
+}
+
+func (s *PassService) GetActiveProducts(ctx context.Context) (*[]models.PassProduct, error) {
+	var products []models.PassProduct
+	if err := s.DB.WithContext(ctx).Where("is_active = ?", true).Order("price").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return &products, nil
+}
+
+func (s *PassService) GetProductByID(ctx context.Context, id int) (*models.PassProduct, error) {
+	var product models.PassProduct
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&product).Error; err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (s *PassService) CreateProduct(ctx context.Context, product *models.PassProduct) error {
+	return s.DB.WithContext(ctx).Create(product).Error
+}
+
+func (s *PassService) UpdateProduct(ctx context.Context, product *models.PassProduct) error {
+	return s.DB.WithContext(ctx).Save(product).Error
+}
+
+func (s *PassService) DeleteProduct(ctx context.Context, id int) error {
+	var product models.PassProduct
+	if err := s.DB.WithContext(ctx).First(&product, id).Error; err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Delete(&product).Error
+}
+
+// PurchasePass paket bedelini tahsil eder ve kullanıcıya paketi tanımlar. Kullanıcının aynı paketten
+// süresi dolmamış bir paketi varsa yeni paket onun bitişinden başlar (süre uzatma).
+// Paket kaydı yazılamazsa tahsil edilen tutar iade edilir.
+func (s *PassService) PurchasePass(ctx context.Context, userID uint, productID int) (*models.UserPass, error) {
+	product, err := s.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsActive {
+		return nil, ErrPassProductInactive
+	}
+
+	startsAt := time.Now().UTC()
+	var latest models.UserPass
+	err = s.DB.WithContext(ctx).
+		Where("user_id = ? AND pass_product_id = ? AND expires_at > ?", userID, product.ID, startsAt).
+		Order("expires_at DESC").
+		First(&latest).Error
+	if err == nil {
+		startsAt = latest.ExpiresAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	pass := models.UserPass{
+		UserID:        userID,
+		PassProductID: uint(product.ID),
+		StartsAt:      startsAt,
+		ExpiresAt:     startsAt.AddDate(0, 0, product.DurationDays),
+		PricePaid:     product.Price,
+	}
+
+	if product.Price > 0 {
+		payment, err := s.paymentService.Charge(ctx, userID, paymentModel.PaymentForPass, product.Price, fmt.Sprintf("pass-%d-user-%d", product.ID, userID))
+		if err != nil {
+			return nil, err
+		}
+		paymentID := uint(payment.ID)
+		pass.PaymentID = &paymentID
+
+		if err = s.DB.WithContext(ctx).Create(&pass).Error; err != nil {
+			_, _ = s.paymentService.RefundPayment(ctx, int(payment.ID), payment.CapturedAmount)
+			return nil, err
+		}
+	} else if err = s.DB.WithContext(ctx).Create(&pass).Error; err != nil {
+		return nil, err
+	}
+
+	pass.Product = *product
+	return &pass, nil
+}
+
+func (s *PassService) GetUserPasses(ctx context.Context, userID uint) (*[]models.UserPass, error) {
+	var passes []models.UserPass
+	if err := s.DB.WithContext(ctx).Preload("Product", unscoped).Where("user_id = ?", userID).Order("expires_at DESC").Find(&passes).Error; err != nil {
+		return nil, err
+	}
+
+	return &passes, nil
+}
+
+// GetPassHolders paket sahiplerini döner, productID 0 ise tüm paketler
+func (s *PassService) GetPassHolders(ctx context.Context, productID int, activeOnly bool) (*[]models.UserPass, error) {
+	query := s.DB.WithContext(ctx).Preload("User").Preload("Product", unscoped)
+	if productID > 0 {
+		query = query.Where("pass_product_id = ?", productID)
+	}
+	if activeOnly {
+		now := time.Now().UTC()
+		query = query.Where("starts_at <= ? AND expires_at > ?", now, now)
+	}
+
+	var passes []models.UserPass
+	if err := query.Order("expires_at DESC").Find(&passes).Error; err != nil {
+		return nil, err
+	}
+
+	return &passes, nil
+}
+
+// BestPassFor kullanıcının verilen anda geçerli paketlerinden ücrete en çok indirim sağlayanı ve indirim tutarını döner.
+// Geçerli paket yoksa nil döner.
+func (s *PassService) BestPassFor(ctx context.Context, userID uint, at time.Time, q pricingService.Quote) (*models.UserPass, float64, error) {
+	var passes []models.UserPass
+	if err := s.DB.WithContext(ctx).Preload("Product", unscoped).
+		Where("user_id = ? AND starts_at <= ? AND expires_at > ?", userID, at, at).
+		Order("id").
+		Find(&passes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var best *models.UserPass
+	var bestBenefit float64
+	for i, pass := range passes {
+		if benefit := Benefit(pass.Product, q); best == nil || benefit > bestBenefit {
+			best = &passes[i]
+			bestBenefit = benefit
+		}
+	}
+
+	return best, bestBenefit, nil
+}
+
+// satılmış paketler ürün silinse de süresi dolana kadar geçerlidir, ürün silinmiş olsa da yüklenir
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
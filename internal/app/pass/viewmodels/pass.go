@@ -0,0 +1,116 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/pass/models"
+	"time"
+)
+
+// Abonelik paketi oluşturma için view model
+type PassProductCreateVM struct {
+	Name               string  `json:"name" validate:"required,max=100"`
+	Description        string  `json:"description" validate:"max=255"`
+	DurationDays       int     `json:"duration_days" validate:"required,gt=0,lte=366"`
+	Price              float64 `json:"price" validate:"gte=0"`
+	FreeUnlock         bool    `json:"free_unlock"`
+	FreeMinutesPerRide int     `json:"free_minutes_per_ride" validate:"gte=0"`
+	IsActive           bool    `json:"is_active"`
+}
+
+func (vm PassProductCreateVM) ToDBModel() models.PassProduct {
+	return models.PassProduct{
+		Name:               vm.Name,
+		Description:        vm.Description,
+		DurationDays:       vm.DurationDays,
+		Price:              vm.Price,
+		FreeUnlock:         vm.FreeUnlock,
+		FreeMinutesPerRide: vm.FreeMinutesPerRide,
+		IsActive:           vm.IsActive,
+	}
+}
+
+// Abonelik paketi güncelleme için view model, satılmış paketlerin süresi değişmez
+type PassProductUpdateVM struct {
+	Name               string  `json:"name" validate:"required,max=100"`
+	Description        string  `json:"description" validate:"max=255"`
+	DurationDays       int     `json:"duration_days" validate:"required,gt=0,lte=366"`
+	Price              float64 `json:"price" validate:"gte=0"`
+	FreeUnlock         bool    `json:"free_unlock"`
+	FreeMinutesPerRide int     `json:"free_minutes_per_ride" validate:"gte=0"`
+	IsActive           bool    `json:"is_active"`
+}
+
+func (vm PassProductUpdateVM) ToDBModel(m models.PassProduct) models.PassProduct {
+	m.Name = vm.Name
+	m.Description = vm.Description
+	m.DurationDays = vm.DurationDays
+	m.Price = vm.Price
+	m.FreeUnlock = vm.FreeUnlock
+	m.FreeMinutesPerRide = vm.FreeMinutesPerRide
+	m.IsActive = vm.IsActive
+	return m
+}
+
+// Abonelik paketi detayları için view model
+type PassProductDetailVM struct {
+	ID                 int64   `json:"id"`
+	Name               string  `json:"name"`
+	Description        string  `json:"description"`
+	DurationDays       int     `json:"duration_days"`
+	Price              float64 `json:"price"`
+	FreeUnlock         bool    `json:"free_unlock"`
+	FreeMinutesPerRide int     `json:"free_minutes_per_ride"`
+	IsActive           bool    `json:"is_active"`
+}
+
+func (vm PassProductDetailVM) ToViewModel(m models.PassProduct) PassProductDetailVM {
+	vm.ID = m.ID
+	vm.Name = m.Name
+	vm.Description = m.Description
+	vm.DurationDays = m.DurationDays
+	vm.Price = m.Price
+	vm.FreeUnlock = m.FreeUnlock
+	vm.FreeMinutesPerRide = m.FreeMinutesPerRide
+	vm.IsActive = m.IsActive
+	return vm
+}
+
+// Paket satın alma için view model
+type PassPurchaseVM struct {
+	ProductID int `json:"product_id" validate:"required,gt=0"`
+}
+
+// Kullanıcının paketi için view model
+type UserPassVM struct {
+	ID        int64               `json:"id"`
+	Product   PassProductDetailVM `json:"product"`
+	StartsAt  time.Time           `json:"starts_at"`
+	ExpiresAt time.Time           `json:"expires_at"`
+	PricePaid float64             `json:"price_paid"`
+	IsActive  bool                `json:"is_active"`
+}
+
+func (vm UserPassVM) ToViewModel(m models.UserPass) UserPassVM {
+	vm.ID = m.ID
+	vm.Product = PassProductDetailVM{}.ToViewModel(m.Product)
+	vm.StartsAt = m.StartsAt
+	vm.ExpiresAt = m.ExpiresAt
+	vm.PricePaid = m.PricePaid
+	vm.IsActive = m.IsActiveAt(time.Now())
+	return vm
+}
+
+// Paket sahipleri için view model (admin)
+type PassHolderVM struct {
+	UserPassVM
+	UserID   uint   `json:"user_id"`
+	UserName string `json:"user_name"`
+	Email    string `json:"email"`
+}
+
+func (vm PassHolderVM) ToViewModel(m models.UserPass) PassHolderVM {
+	vm.UserPassVM = UserPassVM{}.ToViewModel(m)
+	vm.UserID = m.UserID
+	vm.UserName = m.User.String()
+	vm.Email = m.User.Email
+	return vm
+}
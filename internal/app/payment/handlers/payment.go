@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	"motorbike-rental-backend/internal/app/payment/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type PaymentHandler struct {
+	paymentService paymentService.IPaymentService
+}
+
+func NewPaymentHandler(s paymentService.IPaymentService) PaymentHandler {
+	return PaymentHandler{paymentService: s}
+}
+
+// giriş yapmış kullanıcının cüzdan bakiyesi ve hareketleri
+func (h PaymentHandler) GetMyWallet(ctx *app.Ctx) error {
+	userID := uint(ctx.GetUserID())
+
+	wallet, err := h.paymentService.GetWallet(ctx.Context(), userID)
+	if err != nil {
+		return errorsx.InternalError(err, "Cüzdan getirilemedi!")
+	}
+
+	entries, err := h.paymentService.GetWalletEntries(ctx.Context(), userID)
+	if err != nil {
+		return errorsx.InternalError(err, "Cüzdan hareketleri getirilemedi!")
+	}
+
+	return ctx.SuccessResponse(viewmodels.WalletDetailVM{}.ToViewModel(*wallet, *entries), 1)
+}
+
+// kart sağlayıcısından çekim yapıp cüzdana bakiye yükler
+func (h PaymentHandler) TopUpWallet(ctx *app.Ctx) error {
+	var vm viewmodels.TopUpVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	wallet, err := h.paymentService.TopUpWallet(ctx.Context(), uint(ctx.GetUserID()), vm.Amount)
+	if err != nil {
+		if errorsx.Is(err, paymentService.ErrPaymentDeclined) {
+			return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödeme reddedildi!"})
+		}
+		if errorsx.Is(err, paymentService.ErrTopUpUnavailable) {
+			return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Bakiye yükleme şu anda kullanılamıyor!"})
+		}
+		return errorsx.InternalError(err, "Bakiye yüklenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bakiye yüklendi!", "balance": wallet.Balance})
+}
+
+func (h PaymentHandler) GetAllPayments(ctx *app.Ctx) error {
+	payments, err := h.paymentService.GetAllPayments(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Ödemeler getirilemedi!")
+	}
+
+	var paymentDetails []viewmodels.PaymentDetailVM
+	for _, payment := range *payments {
+		paymentDetails = append(paymentDetails, viewmodels.PaymentDetailVM{}.ToViewModel(payment))
+	}
+
+	return ctx.SuccessResponse(paymentDetails, len(paymentDetails))
+}
+
+func (h PaymentHandler) GetPaymentsByRideID(ctx *app.Ctx) error {
+	rideID, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	payments, err := h.paymentService.GetPaymentsByRideID(ctx.Context(), rideID)
+	if err != nil {
+		return errorsx.InternalError(err, "Ödemeler getirilemedi!")
+	}
+
+	var paymentDetails []viewmodels.PaymentDetailVM
+	for _, payment := range *payments {
+		paymentDetails = append(paymentDetails, viewmodels.PaymentDetailVM{}.ToViewModel(payment))
+	}
+
+	return ctx.SuccessResponse(paymentDetails, len(paymentDetails))
+}
+
+// (adminler için) tahsil edilmiş sürüş veya abonelik ödemesinin tamamını veya bir kısmını iade eder
+func (h PaymentHandler) RefundPayment(ctx *app.Ctx) error {
+	var vm viewmodels.RefundVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	payment, err := h.paymentService.RefundPayment(ctx.Context(), id, vm.Amount)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Ödeme bulunamadı!")
+		}
+		if errorsx.Is(err, paymentService.ErrNotRefundable) {
+			return errorsx.ConflictError("Bu ödeme iade edilemez!")
+		}
+		if errorsx.Is(err, paymentService.ErrRefundExceedsCaptured) {
+			return errorsx.BadRequestError("İade tutarı tahsil edilen tutarı aşıyor!")
+		}
+		return errorsx.InternalError(err, "İade yapılamadı!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "İade yapıldı!", "refunded_amount": payment.RefundedAmount})
+}
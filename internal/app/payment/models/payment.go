@@ -0,0 +1,71 @@
+package models
+
+type PaymentPurpose string
+
+const (
+	PaymentForRide  PaymentPurpose = "ride"
+	PaymentForTopUp PaymentPurpose = "topup" // cüzdana kartla yükleme
+	PaymentForPass  PaymentPurpose = "pass"  // abonelik paketi satın alma
+	PaymentForFine  PaymentPurpose = "fine"  // kural ihlali cezası
+)
+
+type PaymentStatus string
+
+const (
+	PaymentAuthorized PaymentStatus = "authorized" // provizyon alındı, henüz tahsil edilmedi
+	PaymentCaptured   PaymentStatus = "captured"
+	PaymentFailed     PaymentStatus = "failed"
+	PaymentVoided     PaymentStatus = "voided" // provizyon iptal edildi
+	PaymentRefunded   PaymentStatus = "refunded"
+)
+
+// Payment ödeme sağlayıcısına yapılan her tahsilat girişiminin kaydı
+type Payment struct {
+	BaseModel
+	UserID          uint           `gorm:"not null"`
+	RideID          *uint          // sürüş ödemesi ise ilgili sürüş
+	Purpose         PaymentPurpose `gorm:"type:varchar(20);not null"`
+	Provider        string         `gorm:"type:varchar(20);not null"`
+	AuthorizationID string         `gorm:"type:varchar(64)"` // sağlayıcının provizyon numarası
+	Amount          float64        `gorm:"not null"`         // istenen tutar
+	CapturedAmount  float64        `gorm:"not null"`
+	RefundedAmount  float64        `gorm:"not null"`
+	Status          PaymentStatus  `gorm:"type:varchar(20);not null"`
+	FailureReason   string         `gorm:"type:varchar(255)"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+func (p PaymentPurpose) String() string {
+	switch p {
+	case PaymentForRide:
+		return "ride"
+	case PaymentForTopUp:
+		return "topup"
+	case PaymentForPass:
+		return "pass"
+	case PaymentForFine:
+		return "fine"
+	default:
+		return "unknown"
+	}
+}
+
+func (p PaymentStatus) String() string {
+	switch p {
+	case PaymentAuthorized:
+		return "authorized"
+	case PaymentCaptured:
+		return "captured"
+	case PaymentFailed:
+		return "failed"
+	case PaymentVoided:
+		return "voided"
+	case PaymentRefunded:
+		return "refunded"
+	default:
+		return "unknown"
+	}
+}
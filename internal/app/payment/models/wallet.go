@@ -0,0 +1,78 @@
+package models
+
+// Wallet kullanıcının ön ödemeli cüzdanı. Kullanılabilir bakiye = Balance - Held.
+type Wallet struct {
+	BaseModel
+	UserID  uint    `gorm:"not null;uniqueIndex"`
+	Balance float64 `gorm:"not null"`
+	Held    float64 `gorm:"not null"` // açık provizyonların toplamı
+}
+
+type WalletEntryType string
+
+const (
+	EntryTopUp   WalletEntryType = "topup"
+	EntryHold    WalletEntryType = "hold"
+	EntryRelease WalletEntryType = "release" // provizyonun tahsil edilmeyen kısmı serbest bırakıldı
+	EntryCapture WalletEntryType = "capture"
+	EntryRefund  WalletEntryType = "refund"
+)
+
+// WalletEntry cüzdan hareket defteri, kayıtlar güncellenmez sadece eklenir
+type WalletEntry struct {
+	BaseModel
+	WalletID     uint            `gorm:"not null"`
+	Type         WalletEntryType `gorm:"type:varchar(20);not null"`
+	Amount       float64         `gorm:"not null"`
+	BalanceAfter float64         `gorm:"not null"`
+	HeldAfter    float64         `gorm:"not null"`
+	Reference    string          `gorm:"type:varchar(100)"` // ride-12, topup-3 gibi
+}
+
+type WalletHoldStatus string
+
+const (
+	HoldAuthorized WalletHoldStatus = "authorized"
+	HoldCaptured   WalletHoldStatus = "captured"
+	HoldVoided     WalletHoldStatus = "voided"
+)
+
+// WalletHold cüzdan sağlayıcısının provizyon kaydı
+type WalletHold struct {
+	BaseModel
+	WalletID       uint             `gorm:"not null"`
+	Amount         float64          `gorm:"not null"`
+	CapturedAmount float64          `gorm:"not null"`
+	RefundedAmount float64          `gorm:"not null"`
+	Status         WalletHoldStatus `gorm:"type:varchar(20);not null"`
+	Reference      string           `gorm:"type:varchar(100)"`
+}
+
+func (Wallet) TableName() string {
+	return "wallets"
+}
+
+func (WalletEntry) TableName() string {
+	return "wallet_entries"
+}
+
+func (WalletHold) TableName() string {
+	return "wallet_holds"
+}
+
+func (t WalletEntryType) String() string {
+	switch t {
+	case EntryTopUp:
+		return "topup"
+	case EntryHold:
+		return "hold"
+	case EntryRelease:
+		return "release"
+	case EntryCapture:
+		return "capture"
+	case EntryRefund:
+		return "refund"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// FakeProvider bellekte çalışan sahte ödeme sağlayıcısı. Yerel geliştirme ve testler içindir;
+// DeclineAbove sıfırdan büyükse bu tutarın üzerindeki provizyonlar reddedilir.
+type FakeProvider struct {
+	DeclineAbove float64
+
+	mu             sync.Mutex
+	seq            int
+	authorizations map[string]*fakeAuthorization
+}
+
+type fakeAuthorization struct {
+	userID   uint
+	amount   float64
+	captured float64
+	refunded float64
+	closed   bool
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{authorizations: map[string]*fakeAuthorization{}}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Authorize(_ context.Context, userID uint, amount float64, _ string) (string, error) {
+	if amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.DeclineAbove > 0 && amount > p.DeclineAbove {
+		return "", ErrPaymentDeclined
+	}
+
+	p.seq++
+	id := "fake-" + strconv.Itoa(p.seq)
+	p.authorizations[id] = &fakeAuthorization{userID: userID, amount: amount}
+	return id, nil
+}
+
+func (p *FakeProvider) Capture(_ context.Context, authorizationID string, amount float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+	if auth.closed {
+		return ErrAuthorizationClosed
+	}
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+	if amount > auth.amount {
+		return ErrCaptureExceedsHold
+	}
+
+	auth.captured = amount
+	auth.closed = true
+	return nil
+}
+
+func (p *FakeProvider) Void(_ context.Context, authorizationID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+	if auth.closed {
+		return ErrAuthorizationClosed
+	}
+
+	auth.closed = true
+	return nil
+}
+
+func (p *FakeProvider) Refund(_ context.Context, authorizationID string, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok || !auth.closed || auth.captured == 0 {
+		return ErrAuthorizationNotFound
+	}
+	if round2(auth.refunded+amount) > auth.captured {
+		return ErrRefundExceedsCaptured
+	}
+
+	auth.refunded = round2(auth.refunded + amount)
+	return nil
+}
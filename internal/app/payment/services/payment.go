@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/payment/models"
+)
+
+type IPaymentService interface {
+	GetAllPayments(ctx context.Context) (*[]models.Payment, error)
+	GetPaymentByID(ctx context.Context, id int) (*models.Payment, error)
+	GetPaymentsByRideID(ctx context.Context, rideID int) (*[]models.Payment, error)
+	AuthorizeRideHold(ctx context.Context, userID uint, amount float64) (*models.Payment, error)
+	VoidPayment(ctx context.Context, paymentID int64) error
+	ChargeRide(ctx context.Context, userID, rideID uint, amount float64) (*models.Payment, error)
+	Charge(ctx context.Context, userID uint, purpose models.PaymentPurpose, amount float64, reference string) (*models.Payment, error)
+	RefundPayment(ctx context.Context, id int, amount float64) (*models.Payment, error)
+	RefundRide(ctx context.Context, rideID uint, amount float64) (float64, error)
+	GetWallet(ctx context.Context, userID uint) (*models.Wallet, error)
+	GetWalletEntries(ctx context.Context, userID uint) (*[]models.WalletEntry, error)
+	TopUpWallet(ctx context.Context, userID uint, amount float64) (*models.Wallet, error)
+}
+
+type PaymentService struct {
+	DB          *gorm.DB
+	provider    PaymentProvider // sürüş ödemelerinin tahsil edildiği sağlayıcı
+	cardGateway PaymentProvider // cüzdan yüklemelerinde karttan çekim yapan sağlayıcı, nil ise yükleme kapalı
+	wallet      *WalletProvider
+}
+
+func NewPaymentService(db *gorm.DB, provider PaymentProvider, cardGateway PaymentProvider) IPaymentService {
+	return &PaymentService{DB: db, provider: provider, cardGateway: cardGateway, wallet: NewWalletProvider(db)}
+}
+
+func (s *PaymentService) GetAllPayments(ctx context.Context) (*[]models.Payment, error) {
+	var payments []models.Payment
+	if err := s.DB.WithContext(ctx).Order("id DESC").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	return &payments, nil
+}
+
+func (s *PaymentService) GetPaymentByID(ctx context.Context, id int) (*models.Payment, error) {
+	var payment models.Payment
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&payment).Error; err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (s *PaymentService) GetPaymentsByRideID(ctx context.Context, rideID int) (*[]models.Payment, error) {
+	var payments []models.Payment
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("id").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	return &payments, nil
+}
+
+// AuthorizeRideHold sürüş başlamadan önce sağlayıcıdan provizyon alır. Provizyon alınamazsa kayıt 'failed'
+// olarak yazılır ve hata döner, bu durumda sürüş başlatılmamalıdır.
+func (s *PaymentService) AuthorizeRideHold(ctx context.Context, userID uint, amount float64) (*models.Payment, error) {
+	payment := &models.Payment{
+		UserID:   userID,
+		Purpose:  models.PaymentForRide,
+		Provider: s.provider.Name(),
+		Amount:   round2(amount),
+		Status:   models.PaymentAuthorized,
+	}
+
+	authorizationID, err := s.provider.Authorize(ctx, userID, payment.Amount, fmt.Sprintf("ride-hold-user-%d", userID))
+	if err != nil {
+		payment.Status = models.PaymentFailed
+		payment.FailureReason = err.Error()
+	}
+	payment.AuthorizationID = authorizationID
+
+	if createErr := s.DB.WithContext(ctx).Create(payment).Error; createErr != nil {
+		if err == nil {
+			_ = s.provider.Void(ctx, authorizationID)
+			err = createErr
+		}
+	}
+
+	return payment, err
+}
+
+// VoidPayment açık provizyonu iptal eder (sürüş başlatılamadıysa)
+func (s *PaymentService) VoidPayment(ctx context.Context, paymentID int64) error {
+	payment, err := s.GetPaymentByID(ctx, int(paymentID))
+	if err != nil {
+		return err
+	}
+
+	if payment.Status != models.PaymentAuthorized {
+		return ErrAuthorizationClosed
+	}
+
+	provider, err := s.providerFor(payment.Provider)
+	if err != nil {
+		return err
+	}
+	if err = provider.Void(ctx, payment.AuthorizationID); err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Model(payment).Update("status", models.PaymentVoided).Error
+}
+
+// ChargeRide sürüşün toplam ücretini tahsil eder. Sürüşe bağlı açık provizyon varsa önce ondan tahsil edilir
+// (fazlası serbest bırakılır), provizyonu aşan veya daha önce tahsil edilemeyen kısım için yeni bir tahsilat yapılır.
+// Her tahsilat denemesi payments tablosuna yazılır, başarısız olursa hata döner.
+func (s *PaymentService) ChargeRide(ctx context.Context, userID, rideID uint, amount float64) (*models.Payment, error) {
+	var payments []models.Payment
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("id").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	remaining := round2(amount)
+	var hold *models.Payment
+	for i, p := range payments {
+		remaining = round2(remaining - (p.CapturedAmount - p.RefundedAmount))
+		if p.Status == models.PaymentAuthorized {
+			hold = &payments[i]
+		}
+	}
+
+	var last *models.Payment
+
+	if hold != nil {
+		// provizyon, PAYMENT_PROVIDER sonradan değişmiş olsa da açıldığı sağlayıcıdan tahsil edilir
+		provider, err := s.providerFor(hold.Provider)
+		if err != nil {
+			return hold, err
+		}
+		capture := min(max(remaining, 0), hold.Amount)
+		if err = provider.Capture(ctx, hold.AuthorizationID, capture); err != nil {
+			return hold, err
+		}
+
+		hold.Status = models.PaymentCaptured
+		hold.CapturedAmount = capture
+		if err := s.DB.WithContext(ctx).Model(hold).Updates(map[string]interface{}{
+			"status":          hold.Status,
+			"captured_amount": hold.CapturedAmount,
+		}).Error; err != nil {
+			return nil, err
+		}
+
+		remaining = round2(remaining - capture)
+		last = hold
+	}
+
+	if remaining <= 0 {
+		if last == nil {
+			// ücretsiz sürüş (promosyon vb.) sağlayıcıya gitmeden ödenmiş sayılır
+			last = &models.Payment{UserID: userID, RideID: &rideID, Purpose: models.PaymentForRide, Provider: s.provider.Name(), Status: models.PaymentCaptured}
+			return last, s.DB.WithContext(ctx).Create(last).Error
+		}
+		return last, nil
+	}
+
+	payment := &models.Payment{
+		UserID:   userID,
+		RideID:   &rideID,
+		Purpose:  models.PaymentForRide,
+		Provider: s.provider.Name(),
+		Amount:   remaining,
+	}
+
+	err := s.authorizeAndCapture(ctx, s.provider, payment, fmt.Sprintf("ride-%d", rideID))
+	if createErr := s.DB.WithContext(ctx).Create(payment).Error; createErr != nil && err == nil {
+		return nil, createErr
+	}
+
+	return payment, err
+}
+
+// Charge sürüşe bağlı olmayan ödemeleri (abonelik paketi, ceza) sürüş ödemeleriyle aynı sağlayıcıdan tek adımda tahsil eder
+func (s *PaymentService) Charge(ctx context.Context, userID uint, purpose models.PaymentPurpose, amount float64, reference string) (*models.Payment, error) {
+	payment := &models.Payment{
+		UserID:   userID,
+		Purpose:  purpose,
+		Provider: s.provider.Name(),
+		Amount:   round2(amount),
+	}
+
+	err := s.authorizeAndCapture(ctx, s.provider, payment, reference)
+	if createErr := s.DB.WithContext(ctx).Create(payment).Error; createErr != nil && err == nil {
+		return nil, createErr
+	}
+
+	return payment, err
+}
+
+// RefundRide sürüş için tahsil edilmiş ödemelerden en yenisinden başlayarak verilen tutara kadar iade yapar.
+// İade edilebilir tutar daha azsa (ödeme hiç alınamadıysa vb.) yalnızca o kadarı iade edilir, iade edilen tutar döner.
+func (s *PaymentService) RefundRide(ctx context.Context, rideID uint, amount float64) (float64, error) {
+	var payments []models.Payment
+	if err := s.DB.WithContext(ctx).
+		Where("ride_id = ? AND status IN ?", rideID, []models.PaymentStatus{models.PaymentCaptured, models.PaymentRefunded}).
+		Order("id DESC").
+		Find(&payments).Error; err != nil {
+		return 0, err
+	}
+
+	remaining := round2(amount)
+	var refunded float64
+	for _, p := range payments {
+		if remaining <= 0 {
+			break
+		}
+
+		refund := min(remaining, round2(p.CapturedAmount-p.RefundedAmount))
+		if refund <= 0 {
+			continue
+		}
+
+		if _, err := s.RefundPayment(ctx, int(p.ID), refund); err != nil {
+			return refunded, err
+		}
+		refunded = round2(refunded + refund)
+		remaining = round2(remaining - refund)
+	}
+
+	return refunded, nil
+}
+
+// RefundPayment tahsil edilmiş ödemenin tamamını veya bir kısmını iade eder
+func (s *PaymentService) RefundPayment(ctx context.Context, id int, amount float64) (*models.Payment, error) {
+	payment, err := s.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// cüzdan yüklemeleri iade edilemez, cüzdandaki bakiye sürüşlerde kullanılmış olabilir
+	if payment.Purpose == models.PaymentForTopUp {
+		return nil, ErrNotRefundable
+	}
+	if payment.Status != models.PaymentCaptured && payment.Status != models.PaymentRefunded {
+		return nil, ErrNotRefundable
+	}
+	if round2(payment.RefundedAmount+amount) > payment.CapturedAmount {
+		return nil, ErrRefundExceedsCaptured
+	}
+
+	provider, err := s.providerFor(payment.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = provider.Refund(ctx, payment.AuthorizationID, amount); err != nil {
+		return nil, err
+	}
+
+	payment.RefundedAmount = round2(payment.RefundedAmount + amount)
+	payment.Status = models.PaymentRefunded
+	if err = s.DB.WithContext(ctx).Model(payment).Updates(map[string]interface{}{
+		"refunded_amount": payment.RefundedAmount,
+		"status":          payment.Status,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (s *PaymentService) GetWallet(ctx context.Context, userID uint) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := s.DB.WithContext(ctx).Where("user_id = ?", userID).First(&wallet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// cüzdan ilk yüklemede oluşturulur, o zamana kadar bakiye sıfır
+		return &models.Wallet{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (s *PaymentService) GetWalletEntries(ctx context.Context, userID uint) (*[]models.WalletEntry, error) {
+	var entries []models.WalletEntry
+	if err := s.DB.WithContext(ctx).
+		Joins("JOIN wallets ON wallets.id = wallet_entries.wallet_id").
+		Where("wallets.user_id = ?", userID).
+		Order("wallet_entries.id DESC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return &entries, nil
+}
+
+// TopUpWallet kart sağlayıcısından çekim yapar ve tutarı cüzdana ekler, kart sağlayıcısı yoksa ErrTopUpUnavailable döner
+func (s *PaymentService) TopUpWallet(ctx context.Context, userID uint, amount float64) (*models.Wallet, error) {
+	if s.cardGateway == nil {
+		return nil, ErrTopUpUnavailable
+	}
+
+	payment := &models.Payment{
+		UserID:   userID,
+		Purpose:  models.PaymentForTopUp,
+		Provider: s.cardGateway.Name(),
+		Amount:   round2(amount),
+	}
+
+	err := s.authorizeAndCapture(ctx, s.cardGateway, payment, fmt.Sprintf("topup-user-%d", userID))
+	if createErr := s.DB.WithContext(ctx).Create(payment).Error; createErr != nil && err == nil {
+		err = createErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wallet.Credit(ctx, userID, payment.CapturedAmount, fmt.Sprintf("topup-%d", payment.ID))
+}
+
+// authorizeAndCapture ödemeyi tek adımda tahsil eder, sonucu payment üzerine yazar (kaydetmez)
+func (s *PaymentService) authorizeAndCapture(ctx context.Context, provider PaymentProvider, payment *models.Payment, reference string) error {
+	authorizationID, err := provider.Authorize(ctx, payment.UserID, payment.Amount, reference)
+	if err != nil {
+		payment.Status = models.PaymentFailed
+		payment.FailureReason = err.Error()
+		return err
+	}
+	payment.AuthorizationID = authorizationID
+
+	if err = provider.Capture(ctx, authorizationID, payment.Amount); err != nil {
+		_ = provider.Void(ctx, authorizationID)
+		payment.Status = models.PaymentFailed
+		payment.FailureReason = err.Error()
+		return err
+	}
+
+	payment.Status = models.PaymentCaptured
+	payment.CapturedAmount = payment.Amount
+	return nil
+}
+
+// providerFor ödemenin yapıldığı sağlayıcıyı döner (tahsilat, iptal ve iade aynı sağlayıcıdan yapılmalı).
+// Kart sağlayıcısı ayarlanmamış olabilir; ödemenin sağlayıcısı artık yapılandırılmamışsa ErrUnknownPaymentProvider döner.
+func (s *PaymentService) providerFor(name string) (PaymentProvider, error) {
+	for _, provider := range []PaymentProvider{s.provider, s.cardGateway, s.wallet} {
+		if provider != nil && provider.Name() == name {
+			return provider, nil
+		}
+	}
+	return nil, ErrUnknownPaymentProvider
+}
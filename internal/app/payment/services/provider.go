@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"math"
+)
+
+var (
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrPaymentDeclined        = errors.New("payment declined")
+	ErrAuthorizationNotFound  = errors.New("authorization not found")
+	ErrAuthorizationClosed    = errors.New("authorization already captured or voided")
+	ErrCaptureExceedsHold     = errors.New("capture amount exceeds authorization")
+	ErrRefundExceedsCaptured  = errors.New("refund amount exceeds captured amount")
+	ErrInvalidAmount          = errors.New("amount must be positive")
+	ErrNotRefundable          = errors.New("payment is not refundable")
+	ErrUnknownPaymentProvider = errors.New("unknown payment provider")
+	ErrTopUpUnavailable       = errors.New("no card gateway configured for wallet top-ups")
+)
+
+// PaymentProvider ödeme sağlayıcılarının ortak arayüzü. Akış: Authorize (provizyon) -> Capture (tahsilat) veya Void (iptal),
+// tahsil edilen tutar Refund ile iade edilir. Capture provizyondan düşük bir tutarla çağrılırsa kalan kısım serbest bırakılır.
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, userID uint, amount float64, reference string) (authorizationID string, err error)
+	Capture(ctx context.Context, authorizationID string, amount float64) error
+	Void(ctx context.Context, authorizationID string) error
+	Refund(ctx context.Context, authorizationID string, amount float64) error
+}
+
+// NewProvider PAYMENT_PROVIDER ayarına göre sağlayıcıyı oluşturur
+func NewProvider(name string, db *gorm.DB) (PaymentProvider, error) {
+	switch name {
+	case "wallet":
+		return NewWalletProvider(db), nil
+	case "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, ErrUnknownPaymentProvider
+	}
+}
+
+// NewCardGateway PAYMENT_CARD_GATEWAY ayarına göre cüzdan yüklemelerinin kart sağlayıcısını oluşturur.
+// Ayar boşsa nil döner ve cüzdan yükleme kapalıdır; sahte sağlayıcı her tutarı onayladığı için yalnızca açıkça seçilirse kullanılır.
+func NewCardGateway(name string) (PaymentProvider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, ErrUnknownPaymentProvider
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/payment/models"
+	"strconv"
+)
+
+// WalletProvider kullanıcının ön ödemeli cüzdanından tahsilat yapar. Provizyonlar wallet_holds tablosunda tutulur,
+// her bakiye değişikliği wallet_entries defterine yazılır. Cüzdan satırı her işlemde kilitlenir.
+type WalletProvider struct {
+	DB *gorm.DB
+}
+
+func NewWalletProvider(db *gorm.DB) *WalletProvider {
+	return &WalletProvider{DB: db}
+}
+
+func (p *WalletProvider) Name() string {
+	return "wallet"
+}
+
+func (p *WalletProvider) Authorize(ctx context.Context, userID uint, amount float64, reference string) (string, error) {
+	if amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+
+	var hold models.WalletHold
+	err := p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		wallet, err := lockWallet(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if round2(wallet.Balance-wallet.Held) < amount {
+			return ErrInsufficientFunds
+		}
+
+		hold = models.WalletHold{WalletID: uint(wallet.ID), Amount: amount, Status: models.HoldAuthorized, Reference: reference}
+		if err = tx.Create(&hold).Error; err != nil {
+			return err
+		}
+
+		wallet.Held = round2(wallet.Held + amount)
+		return writeEntry(tx, wallet, models.EntryHold, amount, reference)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(hold.ID, 10), nil
+}
+
+func (p *WalletProvider) Capture(ctx context.Context, authorizationID string, amount float64) error {
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hold, wallet, err := lockHold(tx, authorizationID)
+		if err != nil {
+			return err
+		}
+
+		if hold.Status != models.HoldAuthorized {
+			return ErrAuthorizationClosed
+		}
+		if amount < 0 {
+			return ErrInvalidAmount
+		}
+		if amount > hold.Amount {
+			return ErrCaptureExceedsHold
+		}
+
+		wallet.Held = round2(wallet.Held - hold.Amount)
+		if released := round2(hold.Amount - amount); released > 0 {
+			if err = writeEntry(tx, wallet, models.EntryRelease, released, hold.Reference); err != nil {
+				return err
+			}
+		}
+
+		if amount > 0 {
+			wallet.Balance = round2(wallet.Balance - amount)
+			if err = writeEntry(tx, wallet, models.EntryCapture, amount, hold.Reference); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(hold).Updates(map[string]interface{}{
+			"status":          models.HoldCaptured,
+			"captured_amount": amount,
+		}).Error
+	})
+}
+
+func (p *WalletProvider) Void(ctx context.Context, authorizationID string) error {
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hold, wallet, err := lockHold(tx, authorizationID)
+		if err != nil {
+			return err
+		}
+
+		if hold.Status != models.HoldAuthorized {
+			return ErrAuthorizationClosed
+		}
+
+		wallet.Held = round2(wallet.Held - hold.Amount)
+		if err = writeEntry(tx, wallet, models.EntryRelease, hold.Amount, hold.Reference); err != nil {
+			return err
+		}
+
+		return tx.Model(hold).Update("status", models.HoldVoided).Error
+	})
+}
+
+func (p *WalletProvider) Refund(ctx context.Context, authorizationID string, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hold, wallet, err := lockHold(tx, authorizationID)
+		if err != nil {
+			return err
+		}
+
+		if hold.Status != models.HoldCaptured {
+			return ErrAuthorizationNotFound
+		}
+		if round2(hold.RefundedAmount+amount) > hold.CapturedAmount {
+			return ErrRefundExceedsCaptured
+		}
+
+		wallet.Balance = round2(wallet.Balance + amount)
+		if err = writeEntry(tx, wallet, models.EntryRefund, amount, hold.Reference); err != nil {
+			return err
+		}
+
+		return tx.Model(hold).Update("refunded_amount", round2(hold.RefundedAmount+amount)).Error
+	})
+}
+
+// Credit cüzdana bakiye ekler (kartla yükleme sonrası)
+func (p *WalletProvider) Credit(ctx context.Context, userID uint, amount float64, reference string) (*models.Wallet, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var wallet *models.Wallet
+	err := p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if wallet, err = lockWallet(tx, userID); err != nil {
+			return err
+		}
+
+		wallet.Balance = round2(wallet.Balance + amount)
+		return writeEntry(tx, wallet, models.EntryTopUp, amount, reference)
+	})
+
+	return wallet, err
+}
+
+// lockWallet kullanıcının cüzdanını satır kilidiyle okur, cüzdan yoksa oluşturur
+func lockWallet(tx *gorm.DB, userID uint) (*models.Wallet, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Wallet{UserID: userID}).Error; err != nil {
+		return nil, err
+	}
+
+	var wallet models.Wallet
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func lockHold(tx *gorm.DB, authorizationID string) (*models.WalletHold, *models.Wallet, error) {
+	id, err := strconv.ParseInt(authorizationID, 10, 64)
+	if err != nil {
+		return nil, nil, ErrAuthorizationNotFound
+	}
+
+	var hold models.WalletHold
+	if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAuthorizationNotFound
+		}
+		return nil, nil, err
+	}
+
+	var wallet models.Wallet
+	if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", hold.WalletID).First(&wallet).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return &hold, &wallet, nil
+}
+
+// writeEntry cüzdanın güncel bakiyesini kaydeder ve deftere hareketi ekler
+func writeEntry(tx *gorm.DB, wallet *models.Wallet, entryType models.WalletEntryType, amount float64, reference string) error {
+	if err := tx.Model(wallet).Updates(map[string]interface{}{
+		"balance": wallet.Balance,
+		"held":    wallet.Held,
+	}).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&models.WalletEntry{
+		WalletID:     uint(wallet.ID),
+		Type:         entryType,
+		Amount:       amount,
+		BalanceAfter: wallet.Balance,
+		HeldAfter:    wallet.Held,
+		Reference:    reference,
+	}).Error
+}
@@ -0,0 +1,87 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/payment/models"
+	"time"
+)
+
+type TopUpVM struct {
+	Amount float64 `json:"amount" validate:"required,gt=0,lte=5000"`
+}
+
+type RefundVM struct {
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+}
+
+type WalletEntryVM struct {
+	ID           int64     `json:"id"`
+	Type         string    `json:"type"`
+	Amount       float64   `json:"amount"`
+	BalanceAfter float64   `json:"balance_after"`
+	HeldAfter    float64   `json:"held_after"`
+	Reference    string    `json:"reference"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Cüzdan detayları için view model
+type WalletDetailVM struct {
+	UserID    uint            `json:"user_id"`
+	Balance   float64         `json:"balance"`
+	Held      float64         `json:"held"`
+	Available float64         `json:"available"`
+	Entries   []WalletEntryVM `json:"entries"`
+}
+
+func (vm WalletDetailVM) ToViewModel(w models.Wallet, entries []models.WalletEntry) WalletDetailVM {
+	vm.UserID = w.UserID
+	vm.Balance = w.Balance
+	vm.Held = w.Held
+	vm.Available = w.Balance - w.Held
+
+	vm.Entries = []WalletEntryVM{}
+	for _, e := range entries {
+		vm.Entries = append(vm.Entries, WalletEntryVM{
+			ID:           e.ID,
+			Type:         e.Type.String(),
+			Amount:       e.Amount,
+			BalanceAfter: e.BalanceAfter,
+			HeldAfter:    e.HeldAfter,
+			Reference:    e.Reference,
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+
+	return vm
+}
+
+// Ödeme detayları için view model
+type PaymentDetailVM struct {
+	ID              int64     `json:"id"`
+	UserID          uint      `json:"user_id"`
+	RideID          *uint     `json:"ride_id"`
+	Purpose         string    `json:"purpose"`
+	Provider        string    `json:"provider"`
+	AuthorizationID string    `json:"authorization_id"`
+	Amount          float64   `json:"amount"`
+	CapturedAmount  float64   `json:"captured_amount"`
+	RefundedAmount  float64   `json:"refunded_amount"`
+	Status          string    `json:"status"`
+	FailureReason   string    `json:"failure_reason"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (vm PaymentDetailVM) ToViewModel(p models.Payment) PaymentDetailVM {
+	vm.ID = p.ID
+	vm.UserID = p.UserID
+	vm.RideID = p.RideID
+	vm.Purpose = p.Purpose.String()
+	vm.Provider = p.Provider
+	vm.AuthorizationID = p.AuthorizationID
+	vm.Amount = p.Amount
+	vm.CapturedAmount = p.CapturedAmount
+	vm.RefundedAmount = p.RefundedAmount
+	vm.Status = p.Status.String()
+	vm.FailureReason = p.FailureReason
+	vm.CreatedAt = p.CreatedAt
+	return vm
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	penaltyService "motorbike-rental-backend/internal/app/penalty/services"
+	"motorbike-rental-backend/internal/app/penalty/viewmodels"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type PenaltyHandler struct {
+	penaltyService penaltyService.IPenaltyService
+	rideService    rideService.IRideService
+}
+
+func NewPenaltyHandler(s penaltyService.IPenaltyService, r rideService.IRideService) PenaltyHandler {
+	return PenaltyHandler{penaltyService: s, rideService: r}
+}
+
+func (h PenaltyHandler) GetAllPenaltyTypes(ctx *app.Ctx) error {
+	penaltyTypes, err := h.penaltyService.GetAllPenaltyTypes(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Ceza türleri getirilemedi!")
+	}
+
+	var penaltyTypeDetails []viewmodels.PenaltyTypeDetailVM
+	for _, penaltyType := range *penaltyTypes {
+		penaltyTypeDetails = append(penaltyTypeDetails, viewmodels.PenaltyTypeDetailVM{}.ToViewModel(penaltyType))
+	}
+
+	return ctx.SuccessResponse(penaltyTypeDetails, len(penaltyTypeDetails))
+}
+
+func (h PenaltyHandler) CreatePenaltyType(ctx *app.Ctx) error {
+	var vm viewmodels.PenaltyTypeCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	penaltyType := vm.ToDBModel()
+	if _, err := h.penaltyService.GetPenaltyTypeByCode(ctx.Context(), penaltyType.Code); err == nil {
+		return errorsx.ConflictError("Bu kodla bir ceza türü zaten var!")
+	} else if !errorsx.Is(err, gorm.ErrRecordNotFound) {
+		return errorsx.InternalError(err, "Ceza türü kodu kontrol edilemedi!")
+	}
+
+	if err := h.penaltyService.CreatePenaltyType(ctx.Context(), &penaltyType); err != nil {
+		return errorsx.InternalError(err, "Ceza türü oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Ceza türü eklendi!", "id": penaltyType.ID})
+}
+
+func (h PenaltyHandler) UpdatePenaltyType(ctx *app.Ctx) error {
+	var vm viewmodels.PenaltyTypeUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	penaltyType, err := h.penaltyService.GetPenaltyTypeByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Ceza türü bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Ceza türü getirilirken hata oluştu!")
+	}
+
+	updatedPenaltyType := vm.ToDBModel(*penaltyType)
+	if err = h.penaltyService.UpdatePenaltyType(ctx.Context(), &updatedPenaltyType); err != nil {
+		return errorsx.InternalError(err, "Ceza türü güncellenirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Ceza türü güncellendi!"})
+}
+
+func (h PenaltyHandler) DeletePenaltyType(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.penaltyService.DeletePenaltyType(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir ceza türü zaten yok!")
+		}
+		return errorsx.InternalError(err, "Ceza türü silinirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Ceza türü silindi!"})
+}
+
+// kullanıcının cezalarını döner -> /fines/me?status=unpaid
+func (h PenaltyHandler) GetMyFines(ctx *app.Ctx) error {
+	userID := uint(ctx.GetUserID())
+	fines, err := h.penaltyService.GetFines(ctx.Context(), userID, ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "Cezalar getirilemedi!")
+	}
+
+	outstanding, err := h.penaltyService.GetOutstandingAmount(ctx.Context(), userID)
+	if err != nil {
+		return errorsx.InternalError(err, "Cezalar getirilemedi!")
+	}
+
+	var fineDetails []viewmodels.FineDetailVM
+	for _, fine := range *fines {
+		fineDetails = append(fineDetails, viewmodels.FineDetailVM{}.ToViewModel(fine))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": fineDetails, "count": len(fineDetails), "outstanding_amount": outstanding})
+}
+
+// kullanıcı cezasını öder, tutar sürüş ödemeleriyle aynı sağlayıcıdan (varsayılan cüzdan) tahsil edilir
+func (h PenaltyHandler) PayFine(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	fine, err := h.penaltyService.GetFineByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Ceza bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Ceza getirilirken hata oluştu!")
+	}
+
+	if fine.UserID != uint(ctx.GetUserID()) {
+		return errorsx.ForbiddenError("Bu ceza size ait değil!")
+	}
+
+	if err = h.penaltyService.PayFine(ctx.Context(), fine); err != nil {
+		if errorsx.Is(err, penaltyService.ErrFineNotPayable) {
+			return errorsx.ConflictError("Bu ceza ödenmiş veya iptal edilmiş!")
+		}
+		if errorsx.Is(err, paymentService.ErrInsufficientFunds) || errorsx.Is(err, paymentService.ErrPaymentDeclined) {
+			return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödeme alınamadı, lütfen bakiyenizi kontrol edin!"})
+		}
+		return errorsx.InternalError(err, "Ceza ödenemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Ceza ödendi!", "fine": viewmodels.FineDetailVM{}.ToViewModel(*fine)})
+}
+
+// (adminler için) cezaları döner -> /fines?status=unpaid&user_id=5
+func (h PenaltyHandler) GetAllFines(ctx *app.Ctx) error {
+	userID := ctx.QueryInt("user_id", 0)
+	if userID < 0 {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	fines, err := h.penaltyService.GetFines(ctx.Context(), uint(userID), ctx.Query("status"))
+	if err != nil {
+		return errorsx.InternalError(err, "Cezalar getirilemedi!")
+	}
+
+	var fineDetails []viewmodels.FineDetailVM
+	for _, fine := range *fines {
+		fineDetails = append(fineDetails, viewmodels.FineDetailVM{}.ToViewModel(fine))
+	}
+
+	return ctx.SuccessResponse(fineDetails, len(fineDetails))
+}
+
+// (adminler için) kullanıcıya veya sürüşe ceza keser
+func (h PenaltyHandler) IssueFine(ctx *app.Ctx) error {
+	var vm viewmodels.FineIssueVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	fine := vm.ToDBModel()
+
+	// sürüş verildiyse ceza sürüşün kullanıcısına kesilir
+	if vm.RideID != nil {
+		ride, err := h.rideService.GetRideByID(ctx.Context(), int(*vm.RideID))
+		if err != nil {
+			if errorsx.Is(err, gorm.ErrRecordNotFound) {
+				return errorsx.NotFoundError("Sürüş bulunamadı!")
+			}
+			return errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+		}
+		if vm.UserID != 0 && vm.UserID != ride.UserID {
+			return errorsx.BadRequestError("Sürüş bu kullanıcıya ait değil!")
+		}
+		fine.UserID = ride.UserID
+	}
+
+	adminID := uint(ctx.GetUserID())
+	fine.IssuedBy = &adminID
+
+	if err := h.penaltyService.IssueFine(ctx.Context(), &fine); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Ceza türü bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Ceza kesilirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Ceza kesildi!", "id": fine.ID, "amount": fine.Amount})
+}
+
+// (adminler için) ödenmemiş cezayı iptal eder
+func (h PenaltyHandler) WaiveFine(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.penaltyService.WaiveFine(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Ceza bulunamadı!")
+		}
+		if errorsx.Is(err, penaltyService.ErrFineNotUnpaid) {
+			return errorsx.ConflictError("Yalnızca ödenmemiş cezalar iptal edilebilir!")
+		}
+		return errorsx.InternalError(err, "Ceza iptal edilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Ceza iptal edildi!"})
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Otomatik kesilen cezaların tür kodları, tutarları admin tarafından penalty_types tablosunda ayarlanır
+const (
+	PenaltyUnlockedBike = "unlocked_bike" // motor kilitlenmeden bırakıldı
+	PenaltyNoParking    = "no_parking"    // park yasağı olan bölgede veya hizmet alanı dışında bırakıldı
+	PenaltyMissingPhoto = "missing_photo" // sürüş sonu fotoğrafı yüklenmedi
+)
+
+// PenaltyType ceza türü ve varsayılan tutarı
+type PenaltyType struct {
+	BaseModel
+	Code        string  `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Name        string  `gorm:"type:varchar(100);not null"`
+	Description string  `gorm:"type:varchar(255)"`
+	Amount      float64 `gorm:"not null"`
+	IsActive    bool    `gorm:"not null"` // pasif türler için otomatik ceza kesilmez
+}
+
+type FineStatus string
+
+const (
+	FineUnpaid FineStatus = "unpaid"
+	FinePaid   FineStatus = "paid"
+	FineWaived FineStatus = "waived" // admin tarafından silindi
+)
+
+// Fine kullanıcıya kesilen ceza. IssuedBy boşsa ceza arka plan işi tarafından otomatik kesilmiştir;
+// otomatik cezalar sürüş ve tür başına bir kez kesilir.
+type Fine struct {
+	BaseModel
+	UserID        uint       `gorm:"not null"`
+	RideID        *uint      // sürüşle ilgili değilse boş
+	PenaltyTypeID uint       `gorm:"not null"`
+	Amount        float64    `gorm:"not null"`
+	Reason        string     `gorm:"type:varchar(500)"`
+	Status        FineStatus `gorm:"type:varchar(20);not null"`
+	IssuedBy      *uint
+	PaymentID     *uint
+	PaidAt        *time.Time
+
+	PenaltyType PenaltyType `gorm:"foreignKey:PenaltyTypeID"`
+}
+
+func (PenaltyType) TableName() string {
+	return "penalty_types"
+}
+
+func (Fine) TableName() string {
+	return "fines"
+}
+
+func (s FineStatus) String() string {
+	switch s {
+	case FineUnpaid:
+		return "unpaid"
+	case FinePaid:
+		return "paid"
+	case FineWaived:
+		return "waived"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	modelBike "motorbike-rental-backend/internal/app/motorbike/models"
+	paymentModel "motorbike-rental-backend/internal/app/payment/models"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	"motorbike-rental-backend/internal/app/penalty/models"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+	zoneService "motorbike-rental-backend/internal/app/zone/services"
+	"time"
+)
+
+var (
+	ErrFineNotPayable = errors.New("fine is not payable")
+	ErrFineNotUnpaid  = errors.New("fine is not unpaid")
+)
+
+type IPenaltyService interface {
+	GetAllPenaltyTypes(ctx context.Context) (*[]models.PenaltyType, error)
+	GetPenaltyTypeByID(ctx context.Context, id int) (*models.PenaltyType, error)
+	GetPenaltyTypeByCode(ctx context.Context, code string) (*models.PenaltyType, error)
+	CreatePenaltyType(ctx context.Context, penaltyType *models.PenaltyType) error
+	UpdatePenaltyType(ctx context.Context, penaltyType *models.PenaltyType) error
+	DeletePenaltyType(ctx context.Context, id int) error
+	GetFines(ctx context.Context, userID uint, status string) (*[]models.Fine, error)
+	GetFineByID(ctx context.Context, id int) (*models.Fine, error)
+	IssueFine(ctx context.Context, fine *models.Fine) error
+	PayFine(ctx context.Context, fine *models.Fine) error
+	WaiveFine(ctx context.Context, id int) error
+	GetOutstandingAmount(ctx context.Context, userID uint) (float64, error)
+	IssueAutomaticFines(ctx context.Context, now time.Time) (int, error)
+}
+
+type PenaltyService struct {
+	DB             *gorm.DB
+	paymentService paymentService.IPaymentService
+	zoneService    zoneService.IZoneService
+	gracePeriod    time.Duration // sürüş bitirme adımında bu süreden fazla bekleyen sürüşler için otomatik ceza kesilir
+}
+
+func NewPenaltyService(db *gorm.DB, paymentService paymentService.IPaymentService, zoneService zoneService.IZoneService, gracePeriod time.Duration) IPenaltyService {
+	return &PenaltyService{DB: db, paymentService: paymentService, zoneService: zoneService, gracePeriod: gracePeriod}
+}
+
+func (s *PenaltyService) GetAllPenaltyTypes(ctx context.Context) (*[]models.PenaltyType, error) {
+	var penaltyTypes []models.PenaltyType
+	if err := s.DB.WithContext(ctx).Order("id").Find(&penaltyTypes).Error; err != nil {
+		return nil, err
+	}
+
+	return &penaltyTypes, nil
+}
+
+func (s *PenaltyService) GetPenaltyTypeByID(ctx context.Context, id int) (*models.PenaltyType, error) {
+	var penaltyType models.PenaltyType
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&penaltyType).Error; err != nil {
+		return nil, err
+	}
+
+	return &penaltyType, nil
+}
+
+func (s *PenaltyService) GetPenaltyTypeByCode(ctx context.Context, code string) (*models.PenaltyType, error) {
+	var penaltyType models.PenaltyType
+	if err := s.DB.WithContext(ctx).Where("code = ?", code).First(&penaltyType).Error; err != nil {
+		return nil, err
+	}
+
+	return &penaltyType, nil
+}
+
+func (s *PenaltyService) CreatePenaltyType(ctx context.Context, penaltyType *models.PenaltyType) error {
+	return s.DB.WithContext(ctx).Create(penaltyType).Error
+}
+
+func (s *PenaltyService) UpdatePenaltyType(ctx context.Context, penaltyType *models.PenaltyType) error {
+	return s.DB.WithContext(ctx).Save(penaltyType).Error
+}
+
+func (s *PenaltyService) DeletePenaltyType(ctx context.Context, id int) error {
+	var penaltyType models.PenaltyType
+	if err := s.DB.WithContext(ctx).First(&penaltyType, id).Error; err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Delete(&penaltyType).Error
+}
+
+// GetFines cezaları döner, userID 0 ise tüm kullanıcılar, status boşsa tüm durumlar
+func (s *PenaltyService) GetFines(ctx context.Context, userID uint, status string) (*[]models.Fine, error) {
+	query := s.DB.WithContext(ctx).Preload("PenaltyType", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var fines []models.Fine
+	if err := query.Order("id DESC").Find(&fines).Error; err != nil {
+		return nil, err
+	}
+
+	return &fines, nil
+}
+
+func (s *PenaltyService) GetFineByID(ctx context.Context, id int) (*models.Fine, error) {
+	var fine models.Fine
+	if err := s.DB.WithContext(ctx).Preload("PenaltyType", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ?", id).First(&fine).Error; err != nil {
+		return nil, err
+	}
+
+	return &fine, nil
+}
+
+// IssueFine cezayı 'unpaid' olarak kaydeder, tutar ve açıklama verilmemişse ceza türününkiler kullanılır
+func (s *PenaltyService) IssueFine(ctx context.Context, fine *models.Fine) error {
+	penaltyType, err := s.GetPenaltyTypeByID(ctx, int(fine.PenaltyTypeID))
+	if err != nil {
+		return err
+	}
+	if fine.Amount <= 0 {
+		fine.Amount = penaltyType.Amount
+	}
+	if fine.Reason == "" {
+		fine.Reason = penaltyType.Name
+	}
+
+	fine.Status = models.FineUnpaid
+	return s.DB.WithContext(ctx).Create(fine).Error
+}
+
+// PayFine ceza tutarını tahsil eder ve cezayı 'paid' yapar. Ceza bu arada başka bir istekle ödendiyse
+// veya silindiyse tahsilat iade edilir ve ErrFineNotPayable döner.
+func (s *PenaltyService) PayFine(ctx context.Context, fine *models.Fine) error {
+	if fine.Status != models.FineUnpaid {
+		return ErrFineNotPayable
+	}
+
+	payment, err := s.paymentService.Charge(ctx, fine.UserID, paymentModel.PaymentForFine, fine.Amount, fmt.Sprintf("fine-%d", fine.ID))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	paymentID := uint(payment.ID)
+	result := s.DB.WithContext(ctx).Model(&models.Fine{}).
+		Where("id = ? AND status = ?", fine.ID, models.FineUnpaid).
+		Updates(map[string]interface{}{
+			"status":     models.FinePaid,
+			"payment_id": paymentID,
+			"paid_at":    now,
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		_, _ = s.paymentService.RefundPayment(ctx, int(payment.ID), payment.CapturedAmount)
+		if result.Error != nil {
+			return result.Error
+		}
+		return ErrFineNotPayable
+	}
+
+	fine.Status = models.FinePaid
+	fine.PaymentID = &paymentID
+	fine.PaidAt = &now
+	return nil
+}
+
+// WaiveFine ödenmemiş cezayı iptal eder (admin)
+func (s *PenaltyService) WaiveFine(ctx context.Context, id int) error {
+	result := s.DB.WithContext(ctx).Model(&models.Fine{}).
+		Where("id = ? AND status = ?", id, models.FineUnpaid).
+		Update("status", models.FineWaived)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.GetFineByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrFineNotUnpaid
+	}
+
+	return nil
+}
+
+// GetOutstandingAmount kullanıcının ödenmemiş cezalarının toplamını döner
+func (s *PenaltyService) GetOutstandingAmount(ctx context.Context, userID uint) (float64, error) {
+	var total float64
+	if err := s.DB.WithContext(ctx).Model(&models.Fine{}).
+		Where("user_id = ? AND status = ?", userID, models.FineUnpaid).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// IssueAutomaticFines bitirme adımında gracePeriod süresinden uzun bekleyen (motoru bırakılmış) sürüşler için ceza keser:
+// awaiting_lock -> unlocked_bike, fotoğrafı yüklenmemiş awaiting_photo -> missing_photo; motor park yasağı olan bölgede veya
+// hizmet alanı dışındaysa ayrıca no_parking. Bekleme süresi bitirme adımına giriş zamanından (end_step_at) sayılır,
+// GPS izi gibi güncellemeler süreyi uzatmaz. Her sürüş için her türden yalnızca bir otomatik ceza kesilir.
+func (s *PenaltyService) IssueAutomaticFines(ctx context.Context, now time.Time) (int, error) {
+	var rides []modelRide.Ride
+	if err := s.DB.WithContext(ctx).Preload("Motorbike").
+		Where("status IN ? AND end_step_at < ?", []modelRide.RideStatus{modelRide.RideAwaitingLock, modelRide.RideAwaitingPhoto}, now.Add(-s.gracePeriod)).
+		Find(&rides).Error; err != nil {
+		return 0, err
+	}
+	if len(rides) == 0 {
+		return 0, nil
+	}
+
+	penaltyTypes, err := s.activePenaltyTypes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, ride := range rides {
+		// fotoğrafı yüklenmiş ama bitirilemeyen sürüş (ör. park yasağı) için missing_photo kesilmez
+		var codes []string
+		if ride.Status == modelRide.RideAwaitingLock {
+			codes = append(codes, models.PenaltyUnlockedBike)
+		} else if ride.EndPhotoURL == "" {
+			codes = append(codes, models.PenaltyMissingPhoto)
+		}
+
+		if s.isParkedIllegally(ctx, ride.Motorbike) {
+			codes = append(codes, models.PenaltyNoParking)
+		}
+
+		for _, code := range codes {
+			penaltyType, ok := penaltyTypes[code]
+			if !ok {
+				continue
+			}
+
+			issued, err := s.issueAutomaticFine(ctx, ride, penaltyType)
+			if err != nil {
+				return count, err
+			}
+			if issued {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// issueAutomaticFine sürüş ve tür için otomatik ceza yoksa keser, unique index sayesinde tekrar kesilmez
+func (s *PenaltyService) issueAutomaticFine(ctx context.Context, ride modelRide.Ride, penaltyType models.PenaltyType) (bool, error) {
+	rideID := uint(ride.ID)
+	fine := models.Fine{
+		UserID:        ride.UserID,
+		RideID:        &rideID,
+		PenaltyTypeID: uint(penaltyType.ID),
+		Amount:        penaltyType.Amount,
+		Reason:        penaltyType.Name,
+		Status:        models.FineUnpaid,
+	}
+
+	result := s.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&fine)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (s *PenaltyService) activePenaltyTypes(ctx context.Context) (map[string]models.PenaltyType, error) {
+	var penaltyTypes []models.PenaltyType
+	if err := s.DB.WithContext(ctx).Where("is_active = ?", true).Find(&penaltyTypes).Error; err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]models.PenaltyType, len(penaltyTypes))
+	for _, penaltyType := range penaltyTypes {
+		byCode[penaltyType.Code] = penaltyType
+	}
+
+	return byCode, nil
+}
+
+// isParkedIllegally motorun bilinen son konumunun sürüşün bitirilemeyeceği bir yerde olup olmadığını döner
+func (s *PenaltyService) isParkedIllegally(ctx context.Context, motor modelBike.Motorbike) bool {
+	_, err := s.zoneService.CheckParking(ctx, motor.LocationLatitude, motor.LocationLongitude)
+	return errors.Is(err, zoneService.ErrNoParkingZone) || errors.Is(err, zoneService.ErrOutsideOperatingArea)
+}
@@ -0,0 +1,115 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/penalty/models"
+	"strings"
+	"time"
+)
+
+// Ceza türü oluşturma için view model
+type PenaltyTypeCreateVM struct {
+	Code        string  `json:"code" validate:"required,max=50"`
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description string  `json:"description" validate:"max=255"`
+	Amount      float64 `json:"amount" validate:"required,gt=0"`
+	IsActive    bool    `json:"is_active"`
+}
+
+func (vm PenaltyTypeCreateVM) ToDBModel() models.PenaltyType {
+	return models.PenaltyType{
+		Code:        strings.ToLower(strings.TrimSpace(vm.Code)),
+		Name:        vm.Name,
+		Description: vm.Description,
+		Amount:      vm.Amount,
+		IsActive:    vm.IsActive,
+	}
+}
+
+// Ceza türü güncelleme için view model, otomatik cezalar koda bağlı olduğundan kod değiştirilemez
+type PenaltyTypeUpdateVM struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description string  `json:"description" validate:"max=255"`
+	Amount      float64 `json:"amount" validate:"required,gt=0"`
+	IsActive    bool    `json:"is_active"`
+}
+
+func (vm PenaltyTypeUpdateVM) ToDBModel(m models.PenaltyType) models.PenaltyType {
+	m.Name = vm.Name
+	m.Description = vm.Description
+	m.Amount = vm.Amount
+	m.IsActive = vm.IsActive
+	return m
+}
+
+// Ceza türü detayları için view model
+type PenaltyTypeDetailVM struct {
+	ID          int64   `json:"id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	IsActive    bool    `json:"is_active"`
+}
+
+func (vm PenaltyTypeDetailVM) ToViewModel(m models.PenaltyType) PenaltyTypeDetailVM {
+	vm.ID = m.ID
+	vm.Code = m.Code
+	vm.Name = m.Name
+	vm.Description = m.Description
+	vm.Amount = m.Amount
+	vm.IsActive = m.IsActive
+	return vm
+}
+
+// Admin tarafından ceza kesmek için view model, tutar verilmezse ceza türünün tutarı kullanılır.
+// Sürüş verilirse ceza sürüşün kullanıcısına kesilir.
+type FineIssueVM struct {
+	UserID        uint     `json:"user_id" validate:"required_without=RideID"`
+	RideID        *uint    `json:"ride_id" validate:"omitempty,gt=0"`
+	PenaltyTypeID uint     `json:"penalty_type_id" validate:"required,gt=0"`
+	Amount        *float64 `json:"amount" validate:"omitempty,gt=0"`
+	Reason        string   `json:"reason" validate:"max=500"`
+}
+
+func (vm FineIssueVM) ToDBModel() models.Fine {
+	fine := models.Fine{
+		UserID:        vm.UserID,
+		RideID:        vm.RideID,
+		PenaltyTypeID: vm.PenaltyTypeID,
+		Reason:        vm.Reason,
+	}
+	if vm.Amount != nil {
+		fine.Amount = *vm.Amount
+	}
+	return fine
+}
+
+// Ceza detayları için view model
+type FineDetailVM struct {
+	ID          int64      `json:"id"`
+	UserID      uint       `json:"user_id"`
+	RideID      *uint      `json:"ride_id"`
+	PenaltyCode string     `json:"penalty_code"`
+	PenaltyName string     `json:"penalty_name"`
+	Amount      float64    `json:"amount"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	Automatic   bool       `json:"automatic"`
+	PaidAt      *time.Time `json:"paid_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (vm FineDetailVM) ToViewModel(m models.Fine) FineDetailVM {
+	vm.ID = m.ID
+	vm.UserID = m.UserID
+	vm.RideID = m.RideID
+	vm.PenaltyCode = m.PenaltyType.Code
+	vm.PenaltyName = m.PenaltyType.Name
+	vm.Amount = m.Amount
+	vm.Reason = m.Reason
+	vm.Status = m.Status.String()
+	vm.Automatic = m.IssuedBy == nil
+	vm.PaidAt = m.PaidAt
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
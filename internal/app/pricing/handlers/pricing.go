@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	bikeService "motorbike-rental-backend/internal/app/motorbike/services"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	"motorbike-rental-backend/internal/app/pricing/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+	"strconv"
+	"time"
+)
+
+type PricingHandler struct {
+	pricingService pricingService.IPricingService
+	bikeService    bikeService.IMotorService
+}
+
+func NewPricingHandler(s pricingService.IPricingService, m bikeService.IMotorService) PricingHandler {
+	return PricingHandler{pricingService: s, bikeService: m}
+}
+
+func (h PricingHandler) GetAllTariffs(ctx *app.Ctx) error {
+	tariffs, err := h.pricingService.GetAllTariffs(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Tarifeler getirilemedi!")
+	}
+
+	var tariffDetails []viewmodels.TariffDetailVM
+	for _, tariff := range *tariffs {
+		tariffDetails = append(tariffDetails, viewmodels.TariffDetailVM{}.ToViewModel(tariff))
+	}
+
+	return ctx.SuccessResponse(tariffDetails, len(tariffDetails))
+}
+
+func (h PricingHandler) GetTariffByID(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	tariff, err := h.pricingService.GetTariffByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Tarife bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Tarife getirilirken hata oluştu!")
+	}
+
+	return ctx.SuccessResponse(viewmodels.TariffDetailVM{}.ToViewModel(*tariff), 1)
+}
+
+func (h PricingHandler) CreateTariff(ctx *app.Ctx) error {
+	var vm viewmodels.TariffCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	tariff := vm.ToDBModel()
+	if err := h.pricingService.CreateTariff(ctx.Context(), &tariff); err != nil {
+		return errorsx.InternalError(err, "Tarife oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Tarife eklendi!"})
+}
+
+func (h PricingHandler) UpdateTariff(ctx *app.Ctx) error {
+	var vm viewmodels.TariffUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	tariff, err := h.pricingService.GetTariffByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Tarife bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Tarife getirilirken hata oluştu!")
+	}
+
+	updatedTariff := vm.ToDBModel(*tariff)
+	if err = h.pricingService.UpdateTariff(ctx.Context(), &updatedTariff); err != nil {
+		return errorsx.InternalError(err, "Tarife güncellenirken hata oluştu!")
+	}
+
+	// çarpanlar girilmişse eskilerinin yerine yazılır (motor fotoğraflarında olduğu gibi)
+	if len(vm.Multipliers) > 0 {
+		if err = h.pricingService.UpdateMultipliersForTariff(ctx.Context(), vm.ToMultiplierModels(id), id); err != nil {
+			return errorsx.InternalError(err, "Tarife çarpanları güncellenirken hata oluştu!")
+		}
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Tarife güncellendi!"})
+}
+
+func (h PricingHandler) DeleteTariff(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.pricingService.DeleteTariff(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir tarife zaten yok!")
+		}
+		return errorsx.InternalError(err, "Tarife silinirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Tarife silindi!"})
+}
+
+// verilen motor ve süre için tahmini ücreti döner -> /pricing/quote?motorbike_id=3&minutes=25
+func (h PricingHandler) GetQuote(ctx *app.Ctx) error {
+	motorbikeID, err := strconv.Atoi(ctx.Query("motorbike_id"))
+	if err != nil {
+		return errorsx.BadRequestError("Lütfen geçerli bir motorbike_id girin!")
+	}
+
+	minutes, err := strconv.Atoi(ctx.Query("minutes"))
+	if err != nil || minutes < 0 {
+		return errorsx.BadRequestError("Lütfen geçerli bir minutes değeri girin!")
+	}
+
+	motor, err := h.bikeService.GetMotorByID(ctx.Context(), motorbikeID)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir motorsiklet yok!")
+		}
+		return errorsx.InternalError(err, "Motorsiklet sorgulama sırasında bir hata oluştu!")
+	}
+
+	start := time.Now().UTC()
+	quote, err := h.pricingService.Quote(ctx.Context(), motor.Model, start, start.Add(time.Duration(minutes)*time.Minute), nil)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Bu motor için tanımlı bir tarife yok!")
+		}
+		return errorsx.InternalError(err, "Ücret hesaplanırken hata oluştu!")
+	}
+
+	return ctx.SuccessResponse(viewmodels.QuoteVM{}.ToViewModel(motorbikeID, *quote), 1)
+}
@@ -0,0 +1,54 @@
+package models
+
+type DayType string
+
+const (
+	DayAll     DayType = "all"
+	DayWeekday DayType = "weekday"
+	DayWeekend DayType = "weekend"
+)
+
+// Tariff modeli -> MotorbikeModel boş ise varsayılan tarife olarak kullanılır
+type Tariff struct {
+	BaseModel
+	Name           string             `gorm:"type:varchar(100);not null"`
+	MotorbikeModel string             `gorm:"type:varchar(100);not null"`
+	UnlockFee      float64            `gorm:"not null"` // Kilit açma ücreti (TL)
+	PerMinuteRate  float64            `gorm:"not null"` // Dakika başı ücret (TL)
+	PausedRate     float64            `gorm:"not null"` // Mola (park) sırasında dakika başı ücret (TL), çarpan uygulanmaz
+	MinimumCharge  float64            `gorm:"not null"` // Minimum sürüş ücreti (TL), 0 ise yok
+	DailyCap       float64            `gorm:"not null"` // Günlük ücret tavanı (TL), 0 ise yok
+	IsActive       bool               `gorm:"not null"`
+	Multipliers    []TariffMultiplier `gorm:"foreignKey:TariffID"`
+}
+
+// TariffMultiplier saat aralığı ve gün tipine göre dakika ücretini çarpar (ör. gece %20 fazla, hafta sonu %50 fazla)
+type TariffMultiplier struct {
+	BaseModel
+	TariffID   uint    `gorm:"not null"`
+	DayType    DayType `gorm:"type:varchar(10);not null"`
+	StartHour  int     `gorm:"not null"` // dahil
+	EndHour    int     `gorm:"not null"` // hariç, StartHour'dan küçükse gece yarısını geçer (22 -> 6 gibi), eşitse tüm gün
+	Multiplier float64 `gorm:"not null"`
+}
+
+func (Tariff) TableName() string {
+	return "tariffs"
+}
+
+func (TariffMultiplier) TableName() string {
+	return "tariff_multipliers"
+}
+
+func (d DayType) String() string {
+	switch d {
+	case DayAll:
+		return "all"
+	case DayWeekday:
+		return "weekday"
+	case DayWeekend:
+		return "weekend"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"math"
+	"motorbike-rental-backend/internal/app/pricing/models"
+	"time"
+)
+
+// Quote bir sürüşün ücret dökümüdür. FinishRide ve /pricing/quote aynı hesaplamayı kullanır.
+type Quote struct {
+	TariffID      int64
+	TariffName    string
+	Minutes       int
+	UnlockFee     float64
+	PerMinuteRate float64
+	PausedRate    float64
+	PausedMinutes int
+	TimeCost      float64 // çarpanlar uygulanmış dakika ücreti (molalar hariç)
+	PausedCost    float64 // mola dakikalarının ücreti
+	CapDiscount   float64 // günlük tavan nedeniyle düşülen tutar
+	MinimumTopUp  float64 // minimum ücrete tamamlamak için eklenen tutar
+	Total         float64
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Interval sürüş içindeki bir mola aralığıdır, End'i bilinmeyen mola gönderilmez
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Calculate tarifeyi start-end aralığına uygular. Her dakika kendi saatine göre çarpan alır,
+// mola aralıklarına düşen dakikalar ise çarpansız mola ücretinden hesaplanır.
+// Günlük tavan her takvim günü için (loc saat diliminde) ayrı uygulanır.
+func Calculate(t models.Tariff, start, end time.Time, pauses []Interval, loc *time.Location) Quote {
+	q := Quote{
+		TariffID:      t.ID,
+		TariffName:    t.Name,
+		UnlockFee:     t.UnlockFee,
+		PerMinuteRate: t.PerMinuteRate,
+		PausedRate:    t.PausedRate,
+		StartTime:     start,
+		EndTime:       end,
+	}
+
+	if end.After(start) {
+		q.Minutes = int(end.Sub(start).Minutes())
+	}
+
+	// gün bazında biriken ücret, kilit açma ücreti ilk güne yazılır
+	dayCosts := map[string]float64{}
+	var days []string
+
+	addCost := func(day string, cost float64) {
+		if _, ok := dayCosts[day]; !ok {
+			days = append(days, day)
+		}
+		dayCosts[day] += cost
+	}
+
+	addCost(start.In(loc).Format("2006-01-02"), t.UnlockFee)
+
+	for i := 0; i < q.Minutes; i++ {
+		at := start.Add(time.Duration(i) * time.Minute).In(loc)
+
+		if inPause(pauses, at) {
+			q.PausedMinutes++
+			q.PausedCost += t.PausedRate
+			addCost(at.Format("2006-01-02"), t.PausedRate)
+			continue
+		}
+
+		cost := t.PerMinuteRate * multiplierAt(t.Multipliers, at)
+		q.TimeCost += cost
+		addCost(at.Format("2006-01-02"), cost)
+	}
+
+	for _, day := range days {
+		cost := dayCosts[day]
+		if t.DailyCap > 0 && cost > t.DailyCap {
+			q.CapDiscount += cost - t.DailyCap
+			cost = t.DailyCap
+		}
+		q.Total += cost
+	}
+
+	if t.MinimumCharge > 0 && q.Total < t.MinimumCharge {
+		q.MinimumTopUp = t.MinimumCharge - q.Total
+		q.Total = t.MinimumCharge
+	}
+
+	q.TimeCost = round2(q.TimeCost)
+	q.PausedCost = round2(q.PausedCost)
+	q.CapDiscount = round2(q.CapDiscount)
+	q.MinimumTopUp = round2(q.MinimumTopUp)
+	q.Total = round2(q.Total)
+
+	return q
+}
+
+// multiplierAt verilen zamana uyan çarpanlardan en yükseğini döner, hiçbiri uymuyorsa 1
+func multiplierAt(multipliers []models.TariffMultiplier, at time.Time) float64 {
+	result := 1.0
+	matched := false
+
+	weekend := at.Weekday() == time.Saturday || at.Weekday() == time.Sunday
+	hour := at.Hour()
+
+	for _, m := range multipliers {
+		if m.DayType == models.DayWeekday && weekend {
+			continue
+		}
+		if m.DayType == models.DayWeekend && !weekend {
+			continue
+		}
+
+		var inRange bool
+		switch {
+		case m.StartHour == m.EndHour: // tüm gün
+			inRange = true
+		case m.StartHour < m.EndHour:
+			inRange = hour >= m.StartHour && hour < m.EndHour
+		default:
+			inRange = hour >= m.StartHour || hour < m.EndHour
+		}
+		if !inRange {
+			continue
+		}
+
+		if !matched || m.Multiplier > result {
+			result = m.Multiplier
+			matched = true
+		}
+	}
+
+	return result
+}
+
+// inPause dakikanın başladığı an bir mola aralığındaysa true döner
+func inPause(pauses []Interval, at time.Time) bool {
+	for _, p := range pauses {
+		if !at.Before(p.Start) && at.Before(p.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"motorbike-rental-backend/internal/app/pricing/models"
+	"testing"
+	"time"
+)
+
+// 19 Ekim 2026 pazartesi, 24 Ekim 2026 cumartesi
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestMultiplierAt(t *testing.T) {
+	rushHour := models.TariffMultiplier{DayType: models.DayWeekday, StartHour: 7, EndHour: 10, Multiplier: 1.5}
+	night := models.TariffMultiplier{DayType: models.DayAll, StartHour: 22, EndHour: 6, Multiplier: 1.2}
+	weekend := models.TariffMultiplier{DayType: models.DayWeekend, StartHour: 0, EndHour: 24, Multiplier: 2}
+	allDay := models.TariffMultiplier{DayType: models.DayAll, StartHour: 5, EndHour: 5, Multiplier: 1.3}
+	discount := models.TariffMultiplier{DayType: models.DayAll, StartHour: 13, EndHour: 15, Multiplier: 0.8}
+
+	tests := []struct {
+		name        string
+		multipliers []models.TariffMultiplier
+		at          time.Time
+		want        float64
+	}{
+		{"çarpan yok", nil, at(19, 8, 0), 1},
+		{"aralık başı dahil", []models.TariffMultiplier{rushHour}, at(19, 7, 0), 1.5},
+		{"aralık içi", []models.TariffMultiplier{rushHour}, at(19, 9, 59), 1.5},
+		{"aralık sonu hariç", []models.TariffMultiplier{rushHour}, at(19, 10, 0), 1},
+		{"hafta içi çarpanı hafta sonu uygulanmaz", []models.TariffMultiplier{rushHour}, at(24, 8, 0), 1},
+		{"gece yarısını geçen aralık, gece yarısından önce", []models.TariffMultiplier{night}, at(19, 23, 0), 1.2},
+		{"gece yarısını geçen aralık, gece yarısından sonra", []models.TariffMultiplier{night}, at(19, 5, 59), 1.2},
+		{"gece yarısını geçen aralığın sonu hariç", []models.TariffMultiplier{night}, at(19, 6, 0), 1},
+		{"gece yarısını geçen aralığın dışı", []models.TariffMultiplier{night}, at(19, 12, 0), 1},
+		{"hafta sonu tüm gün", []models.TariffMultiplier{weekend}, at(24, 23, 59), 2},
+		{"hafta sonu çarpanı hafta içi uygulanmaz", []models.TariffMultiplier{weekend}, at(19, 12, 0), 1},
+		{"başlangıç ve bitiş eşitse tüm gün, başlangıç saati", []models.TariffMultiplier{allDay}, at(19, 5, 0), 1.3},
+		{"başlangıç ve bitiş eşitse tüm gün, başlangıçtan önce", []models.TariffMultiplier{allDay}, at(19, 4, 59), 1.3},
+		{"çakışan çarpanlardan en yükseği", []models.TariffMultiplier{night, weekend}, at(24, 23, 0), 2},
+		{"tek uyan indirim çarpanı", []models.TariffMultiplier{discount}, at(19, 14, 0), 0.8},
+		{"indirim ve zam çakışırsa zam", []models.TariffMultiplier{discount, allDay}, at(19, 14, 0), 1.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := multiplierAt(tt.multipliers, tt.at); got != tt.want {
+				t.Errorf("multiplierAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name         string
+		tariff       models.Tariff
+		start, end   time.Time
+		wantMinutes  int
+		wantTimeCost float64
+		wantCap      float64
+		wantTopUp    float64
+		wantTotal    float64
+	}{
+		{
+			name:         "açılış ve dakika ücreti",
+			tariff:       models.Tariff{UnlockFee: 5, PerMinuteRate: 1},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 10),
+			wantMinutes:  10,
+			wantTimeCost: 10,
+			wantTotal:    15,
+		},
+		{
+			name:         "eksik dakika faturalanmaz",
+			tariff:       models.Tariff{PerMinuteRate: 1},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 10).Add(59 * time.Second),
+			wantMinutes:  10,
+			wantTimeCost: 10,
+			wantTotal:    10,
+		},
+		{
+			name:        "bitiş başlangıçtan önceyse yalnızca açılış ücreti",
+			tariff:      models.Tariff{UnlockFee: 5, PerMinuteRate: 1},
+			start:       at(19, 12, 0),
+			end:         at(19, 11, 0),
+			wantMinutes: 0,
+			wantTotal:   5,
+		},
+		{
+			name: "çarpan dakika bazında uygulanır",
+			tariff: models.Tariff{PerMinuteRate: 1, Multipliers: []models.TariffMultiplier{
+				{DayType: models.DayAll, StartHour: 13, EndHour: 14, Multiplier: 2},
+			}},
+			start:        at(19, 12, 50),
+			end:          at(19, 13, 10),
+			wantMinutes:  20,
+			wantTimeCost: 30,
+			wantTotal:    30,
+		},
+		{
+			name:         "minimum ücrete tamamlanır",
+			tariff:       models.Tariff{UnlockFee: 5, PerMinuteRate: 1, MinimumCharge: 20},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 10),
+			wantMinutes:  10,
+			wantTimeCost: 10,
+			wantTopUp:    5,
+			wantTotal:    20,
+		},
+		{
+			name:         "minimumu aşan sürüşe ekleme yapılmaz",
+			tariff:       models.Tariff{UnlockFee: 5, PerMinuteRate: 1, MinimumCharge: 10},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 10),
+			wantMinutes:  10,
+			wantTimeCost: 10,
+			wantTotal:    15,
+		},
+		{
+			name:         "günlük tavan açılış ücretini de kapsar",
+			tariff:       models.Tariff{UnlockFee: 5, PerMinuteRate: 1, DailyCap: 100},
+			start:        at(19, 10, 0),
+			end:          at(19, 13, 20),
+			wantMinutes:  200,
+			wantTimeCost: 200,
+			wantCap:      105,
+			wantTotal:    100,
+		},
+		{
+			name:         "tavan her takvim günü için ayrı uygulanır",
+			tariff:       models.Tariff{PerMinuteRate: 1, DailyCap: 50},
+			start:        at(19, 23, 0),
+			end:          at(20, 1, 0),
+			wantMinutes:  120,
+			wantTimeCost: 120,
+			wantCap:      20,
+			wantTotal:    100,
+		},
+		{
+			name:         "minimum tavandan yüksekse tavandan sonra minimuma tamamlanır",
+			tariff:       models.Tariff{UnlockFee: 5, PerMinuteRate: 1, DailyCap: 10, MinimumCharge: 20},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 30),
+			wantMinutes:  30,
+			wantTimeCost: 30,
+			wantCap:      25,
+			wantTopUp:    10,
+			wantTotal:    20,
+		},
+		{
+			name:         "kuruşlar yuvarlanır",
+			tariff:       models.Tariff{UnlockFee: 2.5, PerMinuteRate: 0.35},
+			start:        at(19, 12, 0),
+			end:          at(19, 12, 7),
+			wantMinutes:  7,
+			wantTimeCost: 2.45,
+			wantTotal:    4.95,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Calculate(tt.tariff, tt.start, tt.end, nil, time.UTC)
+
+			if q.Minutes != tt.wantMinutes {
+				t.Errorf("Minutes = %d, want %d", q.Minutes, tt.wantMinutes)
+			}
+			if q.TimeCost != tt.wantTimeCost {
+				t.Errorf("TimeCost = %v, want %v", q.TimeCost, tt.wantTimeCost)
+			}
+			if q.CapDiscount != tt.wantCap {
+				t.Errorf("CapDiscount = %v, want %v", q.CapDiscount, tt.wantCap)
+			}
+			if q.MinimumTopUp != tt.wantTopUp {
+				t.Errorf("MinimumTopUp = %v, want %v", q.MinimumTopUp, tt.wantTopUp)
+			}
+			if q.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", q.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+// Çarpanlar PRICING_TIMEZONE saatine göre uygulanır, UTC saatine göre değil
+func TestCalculateUsesLocation(t *testing.T) {
+	istanbul := time.FixedZone("TRT", 3*60*60)
+	tariff := models.Tariff{PerMinuteRate: 1, Multipliers: []models.TariffMultiplier{
+		{DayType: models.DayAll, StartHour: 22, EndHour: 6, Multiplier: 2},
+	}}
+
+	// UTC 19:00-19:10 İstanbul'da 22:00-22:10
+	q := Calculate(tariff, at(19, 19, 0), at(19, 19, 10), nil, istanbul)
+	if q.TimeCost != 20 {
+		t.Errorf("TimeCost = %v, want 20", q.TimeCost)
+	}
+}
+
+func TestCalculatePauses(t *testing.T) {
+	tariff := models.Tariff{PerMinuteRate: 1, PausedRate: 0.2, Multipliers: []models.TariffMultiplier{
+		{DayType: models.DayAll, StartHour: 12, EndHour: 12, Multiplier: 2},
+	}}
+
+	tests := []struct {
+		name           string
+		tariff         models.Tariff
+		pauses         []Interval
+		wantPaused     int
+		wantPausedCost float64
+		wantTimeCost   float64
+		wantTotal      float64
+	}{
+		{
+			name:         "mola yok",
+			tariff:       tariff,
+			wantTimeCost: 120,
+			wantTotal:    120,
+		},
+		{
+			name:           "mola dakikalarına çarpan uygulanmaz",
+			tariff:         tariff,
+			pauses:         []Interval{{Start: at(19, 12, 10), End: at(19, 12, 20)}},
+			wantPaused:     10,
+			wantPausedCost: 2,
+			wantTimeCost:   100,
+			wantTotal:      102,
+		},
+		{
+			name:           "dakikanın başı moladaysa dakika molada sayılır",
+			tariff:         tariff,
+			pauses:         []Interval{{Start: at(19, 12, 10).Add(30 * time.Second), End: at(19, 12, 12).Add(30 * time.Second)}},
+			wantPaused:     2,
+			wantPausedCost: 0.4,
+			wantTimeCost:   116,
+			wantTotal:      116.4,
+		},
+		{
+			name:   "birden fazla mola",
+			tariff: tariff,
+			pauses: []Interval{
+				{Start: at(19, 12, 0), End: at(19, 12, 5)},
+				{Start: at(19, 12, 55), End: at(19, 13, 0)},
+			},
+			wantPaused:     10,
+			wantPausedCost: 2,
+			wantTimeCost:   100,
+			wantTotal:      102,
+		},
+		{
+			name:           "sürüş dışında kalan mola kısmı faturalanmaz",
+			tariff:         tariff,
+			pauses:         []Interval{{Start: at(19, 11, 0), End: at(19, 12, 3)}},
+			wantPaused:     3,
+			wantPausedCost: 0.6,
+			wantTimeCost:   114,
+			wantTotal:      114.6,
+		},
+		{
+			name:           "mola ücreti günlük tavana dahildir",
+			tariff:         models.Tariff{PerMinuteRate: 1, PausedRate: 0.5, DailyCap: 40},
+			pauses:         []Interval{{Start: at(19, 12, 0), End: at(19, 12, 30)}},
+			wantPaused:     30,
+			wantPausedCost: 15,
+			wantTimeCost:   30,
+			wantTotal:      40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Calculate(tt.tariff, at(19, 12, 0), at(19, 13, 0), tt.pauses, time.UTC)
+
+			if q.PausedMinutes != tt.wantPaused {
+				t.Errorf("PausedMinutes = %d, want %d", q.PausedMinutes, tt.wantPaused)
+			}
+			if q.PausedCost != tt.wantPausedCost {
+				t.Errorf("PausedCost = %v, want %v", q.PausedCost, tt.wantPausedCost)
+			}
+			if q.TimeCost != tt.wantTimeCost {
+				t.Errorf("TimeCost = %v, want %v", q.TimeCost, tt.wantTimeCost)
+			}
+			if q.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", q.Total, tt.wantTotal)
+			}
+		})
+	}
+}
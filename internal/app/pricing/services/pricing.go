@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/pricing/models"
+	"time"
+)
+
+type IPricingService interface {
+	GetAllTariffs(ctx context.Context) (*[]models.Tariff, error)
+	GetTariffByID(ctx context.Context, id int) (*models.Tariff, error)
+	CreateTariff(ctx context.Context, tariff *models.Tariff) error
+	UpdateTariff(ctx context.Context, tariff *models.Tariff) error
+	UpdateMultipliersForTariff(ctx context.Context, multipliers []models.TariffMultiplier, tariffID int) error
+	DeleteTariff(ctx context.Context, id int) error
+	GetTariffForModel(ctx context.Context, motorbikeModel string) (*models.Tariff, error)
+	Quote(ctx context.Context, motorbikeModel string, start, end time.Time, pauses []Interval) (*Quote, error)
+}
+
+type PricingService struct {
+	DB       *gorm.DB
+	location *time.Location
+}
+
+func NewPricingService(db *gorm.DB, timezone string) IPricingService {
+	// saat/gün çarpanları yerel saate göre uygulanır, tz verisi yoksa TR saatine (UTC+3) düşüyoruz
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.FixedZone("TRT", 3*60*60)
+	}
+	return &PricingService{DB: db, location: loc}
+}
+
+func (s *PricingService) GetAllTariffs(ctx context.Context) (*[]models.Tariff, error) {
+	var tariffs []models.Tariff
+	if err := s.DB.WithContext(ctx).Preload("Multipliers").Find(&tariffs).Error; err != nil {
+		return nil, err
+	}
+
+	return &tariffs, nil
+}
+
+func (s *PricingService) GetTariffByID(ctx context.Context, id int) (*models.Tariff, error) {
+	var tariff models.Tariff
+	if err := s.DB.WithContext(ctx).Preload("Multipliers").Where("id = ?", id).First(&tariff).Error; err != nil {
+		return nil, err
+	}
+
+	return &tariff, nil
+}
+
+func (s *PricingService) CreateTariff(ctx context.Context, tariff *models.Tariff) error {
+	return s.DB.WithContext(ctx).Create(tariff).Error
+}
+
+func (s *PricingService) UpdateTariff(ctx context.Context, tariff *models.Tariff) error {
+	return s.DB.WithContext(ctx).Omit("Multipliers").Save(tariff).Error
+}
+
+func (s *PricingService) UpdateMultipliersForTariff(ctx context.Context, multipliers []models.TariffMultiplier, tariffID int) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Önce mevcut çarpanları sil
+		if err := tx.Where("tariff_id = ?", tariffID).Delete(&models.TariffMultiplier{}).Error; err != nil {
+			return err
+		}
+
+		// Yeni çarpanları ekle
+		for _, multiplier := range multipliers {
+			multiplier.TariffID = uint(tariffID)
+			if err := tx.Create(&multiplier).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *PricingService) DeleteTariff(ctx context.Context, id int) error {
+	var tariff models.Tariff
+	if err := s.DB.WithContext(ctx).First(&tariff, id).Error; err != nil {
+		return err
+	}
+
+	if err := s.DB.WithContext(ctx).Where("tariff_id = ?", id).Delete(&models.TariffMultiplier{}).Error; err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Delete(&tariff).Error
+}
+
+// GetTariffForModel önce motor modeline özel aktif tarifeyi, yoksa varsayılan (model'i boş) tarifeyi döner
+func (s *PricingService) GetTariffForModel(ctx context.Context, motorbikeModel string) (*models.Tariff, error) {
+	var tariff models.Tariff
+
+	err := s.DB.WithContext(ctx).Preload("Multipliers").
+		Where("is_active = ? AND motorbike_model = ?", true, motorbikeModel).
+		Order("id DESC").First(&tariff).Error
+	if err == nil {
+		return &tariff, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err = s.DB.WithContext(ctx).Preload("Multipliers").
+		Where("is_active = ? AND motorbike_model = ''", true).
+		Order("id DESC").First(&tariff).Error; err != nil {
+		return nil, err
+	}
+
+	return &tariff, nil
+}
+
+func (s *PricingService) Quote(ctx context.Context, motorbikeModel string, start, end time.Time, pauses []Interval) (*Quote, error) {
+	tariff, err := s.GetTariffForModel(ctx, motorbikeModel)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := Calculate(*tariff, start, end, pauses, s.location)
+	return &quote, nil
+}
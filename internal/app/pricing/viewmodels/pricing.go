@@ -0,0 +1,169 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/pricing/models"
+	"motorbike-rental-backend/internal/app/pricing/services"
+	"time"
+)
+
+type TariffMultiplierVM struct {
+	DayType    string  `json:"day_type" validate:"required,oneof=all weekday weekend"`
+	StartHour  int     `json:"start_hour" validate:"gte=0,lte=23"`
+	EndHour    int     `json:"end_hour" validate:"gte=0,lte=24"`
+	Multiplier float64 `json:"multiplier" validate:"required,gt=0"`
+}
+
+// Tarife oluşturma için view model
+type TariffCreateVM struct {
+	Name           string               `json:"name" validate:"required,max=100"`
+	MotorbikeModel string               `json:"motorbike_model" validate:"max=100"` // boş bırakılırsa varsayılan tarife
+	UnlockFee      float64              `json:"unlock_fee" validate:"gte=0"`
+	PerMinuteRate  float64              `json:"per_minute_rate" validate:"gte=0"`
+	PausedRate     float64              `json:"paused_rate" validate:"gte=0"`
+	MinimumCharge  float64              `json:"minimum_charge" validate:"gte=0"`
+	DailyCap       float64              `json:"daily_cap" validate:"gte=0"`
+	IsActive       bool                 `json:"is_active"`
+	Multipliers    []TariffMultiplierVM `json:"multipliers" validate:"dive"`
+}
+
+func (vm TariffCreateVM) ToDBModel() models.Tariff {
+	return models.Tariff{
+		Name:           vm.Name,
+		MotorbikeModel: vm.MotorbikeModel,
+		UnlockFee:      vm.UnlockFee,
+		PerMinuteRate:  vm.PerMinuteRate,
+		PausedRate:     vm.PausedRate,
+		MinimumCharge:  vm.MinimumCharge,
+		DailyCap:       vm.DailyCap,
+		IsActive:       vm.IsActive,
+		Multipliers:    toMultiplierModels(vm.Multipliers, 0),
+	}
+}
+
+// Tarife güncelleme için view model
+type TariffUpdateVM struct {
+	Name           string               `json:"name" validate:"required,max=100"`
+	MotorbikeModel string               `json:"motorbike_model" validate:"max=100"`
+	UnlockFee      float64              `json:"unlock_fee" validate:"gte=0"`
+	PerMinuteRate  float64              `json:"per_minute_rate" validate:"gte=0"`
+	PausedRate     float64              `json:"paused_rate" validate:"gte=0"`
+	MinimumCharge  float64              `json:"minimum_charge" validate:"gte=0"`
+	DailyCap       float64              `json:"daily_cap" validate:"gte=0"`
+	IsActive       bool                 `json:"is_active"`
+	Multipliers    []TariffMultiplierVM `json:"multipliers" validate:"dive"`
+}
+
+func (vm TariffUpdateVM) ToDBModel(m models.Tariff) models.Tariff {
+	m.Name = vm.Name
+	m.MotorbikeModel = vm.MotorbikeModel
+	m.UnlockFee = vm.UnlockFee
+	m.PerMinuteRate = vm.PerMinuteRate
+	m.PausedRate = vm.PausedRate
+	m.MinimumCharge = vm.MinimumCharge
+	m.DailyCap = vm.DailyCap
+	m.IsActive = vm.IsActive
+	return m
+}
+
+func (vm TariffUpdateVM) ToMultiplierModels(tariffID int) []models.TariffMultiplier {
+	return toMultiplierModels(vm.Multipliers, uint(tariffID))
+}
+
+func toMultiplierModels(vms []TariffMultiplierVM, tariffID uint) []models.TariffMultiplier {
+	var multipliers []models.TariffMultiplier
+	for _, m := range vms {
+		multipliers = append(multipliers, models.TariffMultiplier{
+			TariffID:   tariffID,
+			DayType:    models.DayType(m.DayType),
+			StartHour:  m.StartHour,
+			EndHour:    m.EndHour,
+			Multiplier: m.Multiplier,
+		})
+	}
+	return multipliers
+}
+
+type TariffMultiplierDetailVM struct {
+	ID         int64   `json:"id"`
+	DayType    string  `json:"day_type"`
+	StartHour  int     `json:"start_hour"`
+	EndHour    int     `json:"end_hour"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Tarife detayları için view model
+type TariffDetailVM struct {
+	ID             int64                      `json:"id"`
+	Name           string                     `json:"name"`
+	MotorbikeModel string                     `json:"motorbike_model"`
+	UnlockFee      float64                    `json:"unlock_fee"`
+	PerMinuteRate  float64                    `json:"per_minute_rate"`
+	PausedRate     float64                    `json:"paused_rate"`
+	MinimumCharge  float64                    `json:"minimum_charge"`
+	DailyCap       float64                    `json:"daily_cap"`
+	IsActive       bool                       `json:"is_active"`
+	Multipliers    []TariffMultiplierDetailVM `json:"multipliers"`
+}
+
+func (vm TariffDetailVM) ToViewModel(m models.Tariff) TariffDetailVM {
+	vm.ID = m.ID
+	vm.Name = m.Name
+	vm.MotorbikeModel = m.MotorbikeModel
+	vm.UnlockFee = m.UnlockFee
+	vm.PerMinuteRate = m.PerMinuteRate
+	vm.PausedRate = m.PausedRate
+	vm.MinimumCharge = m.MinimumCharge
+	vm.DailyCap = m.DailyCap
+	vm.IsActive = m.IsActive
+
+	vm.Multipliers = nil
+	for _, multiplier := range m.Multipliers {
+		vm.Multipliers = append(vm.Multipliers, TariffMultiplierDetailVM{
+			ID:         multiplier.ID,
+			DayType:    multiplier.DayType.String(),
+			StartHour:  multiplier.StartHour,
+			EndHour:    multiplier.EndHour,
+			Multiplier: multiplier.Multiplier,
+		})
+	}
+
+	return vm
+}
+
+// Ücret tahmini (quote) için view model
+type QuoteVM struct {
+	MotorbikeID   int       `json:"motorbike_id"`
+	TariffID      int64     `json:"tariff_id"`
+	TariffName    string    `json:"tariff_name"`
+	Minutes       int       `json:"minutes"`
+	UnlockFee     float64   `json:"unlock_fee"`
+	PerMinuteRate float64   `json:"per_minute_rate"`
+	PausedRate    float64   `json:"paused_rate"`
+	PausedMinutes int       `json:"paused_minutes"`
+	TimeCost      float64   `json:"time_cost"`
+	PausedCost    float64   `json:"paused_cost"`
+	CapDiscount   float64   `json:"cap_discount"`
+	MinimumTopUp  float64   `json:"minimum_top_up"`
+	Total         float64   `json:"total"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+}
+
+func (vm QuoteVM) ToViewModel(motorbikeID int, q services.Quote) QuoteVM {
+	vm.MotorbikeID = motorbikeID
+	vm.TariffID = q.TariffID
+	vm.TariffName = q.TariffName
+	vm.Minutes = q.Minutes
+	vm.UnlockFee = q.UnlockFee
+	vm.PerMinuteRate = q.PerMinuteRate
+	vm.PausedRate = q.PausedRate
+	vm.PausedMinutes = q.PausedMinutes
+	vm.TimeCost = q.TimeCost
+	vm.PausedCost = q.PausedCost
+	vm.CapDiscount = q.CapDiscount
+	vm.MinimumTopUp = q.MinimumTopUp
+	vm.Total = q.Total
+	vm.StartTime = q.StartTime
+	vm.EndTime = q.EndTime
+	return vm
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/promotion/models"
+	promotionService "motorbike-rental-backend/internal/app/promotion/services"
+	"motorbike-rental-backend/internal/app/promotion/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type PromotionHandler struct {
+	promotionService promotionService.IPromotionService
+}
+
+func NewPromotionHandler(s promotionService.IPromotionService) PromotionHandler {
+	return PromotionHandler{promotionService: s}
+}
+
+func (h PromotionHandler) GetAllPromotions(ctx *app.Ctx) error {
+	promotions, err := h.promotionService.GetAllPromotions(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Kampanya kodları getirilemedi!")
+	}
+
+	var promotionDetails []viewmodels.PromotionDetailVM
+	for _, promotion := range *promotions {
+		promotionDetails = append(promotionDetails, viewmodels.PromotionDetailVM{}.ToViewModel(promotion))
+	}
+
+	return ctx.SuccessResponse(promotionDetails, len(promotionDetails))
+}
+
+func (h PromotionHandler) GetPromotionByID(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	promotion, err := h.promotionService.GetPromotionByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Kampanya kodu bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Kampanya kodu getirilirken hata oluştu!")
+	}
+
+	return ctx.SuccessResponse(viewmodels.PromotionDetailVM{}.ToViewModel(*promotion), 1)
+}
+
+func (h PromotionHandler) CreatePromotion(ctx *app.Ctx) error {
+	var vm viewmodels.PromotionCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	promotion := vm.ToDBModel()
+	if err := checkPromotionRules(promotion); err != nil {
+		return err
+	}
+
+	if err := h.checkCodeAvailable(ctx, promotion.Code, 0); err != nil {
+		return err
+	}
+
+	if err := h.promotionService.CreatePromotion(ctx.Context(), &promotion); err != nil {
+		return errorsx.InternalError(err, "Kampanya kodu oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Kampanya kodu eklendi!", "id": promotion.ID, "code": promotion.Code})
+}
+
+func (h PromotionHandler) UpdatePromotion(ctx *app.Ctx) error {
+	var vm viewmodels.PromotionUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	promotion, err := h.promotionService.GetPromotionByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Kampanya kodu bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Kampanya kodu getirilirken hata oluştu!")
+	}
+
+	updatedPromotion := vm.ToDBModel(*promotion)
+	if err = checkPromotionRules(updatedPromotion); err != nil {
+		return err
+	}
+
+	if err = h.checkCodeAvailable(ctx, updatedPromotion.Code, promotion.ID); err != nil {
+		return err
+	}
+
+	if err = h.promotionService.UpdatePromotion(ctx.Context(), &updatedPromotion); err != nil {
+		return errorsx.InternalError(err, "Kampanya kodu güncellenirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Kampanya kodu güncellendi!"})
+}
+
+func (h PromotionHandler) DeletePromotion(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.promotionService.DeletePromotion(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir kampanya kodu zaten yok!")
+		}
+		return errorsx.InternalError(err, "Kampanya kodu silinirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Kampanya kodu silindi!"})
+}
+
+// kullanıcı kodu hesabına ekler, sürüşte kod verilmezse bitişte hesaptaki kodlardan en avantajlısı uygulanır
+func (h PromotionHandler) AttachPromotion(ctx *app.Ctx) error {
+	var vm viewmodels.PromotionAttachVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	userPromotion, err := h.promotionService.AttachToUser(ctx.Context(), vm.Code, uint(ctx.GetUserID()))
+	if err != nil {
+		if errorsx.Is(err, promotionService.ErrPromotionAlreadyAttached) {
+			return errorsx.ConflictError("Bu kod zaten hesabınızda!")
+		}
+		return PromotionError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Kampanya kodu hesabınıza eklendi!", "promotion": viewmodels.UserPromotionVM{}.ToViewModel(*userPromotion)})
+}
+
+func (h PromotionHandler) GetMyPromotions(ctx *app.Ctx) error {
+	userPromotions, err := h.promotionService.GetUserPromotions(ctx.Context(), uint(ctx.GetUserID()))
+	if err != nil {
+		return errorsx.InternalError(err, "Kampanya kodları getirilemedi!")
+	}
+
+	var promotions []viewmodels.UserPromotionVM
+	for _, userPromotion := range *userPromotions {
+		promotions = append(promotions, viewmodels.UserPromotionVM{}.ToViewModel(userPromotion))
+	}
+
+	return ctx.SuccessResponse(promotions, len(promotions))
+}
+
+// PromotionError kod doğrulama hatalarını kullanıcıya gösterilecek hataya çevirir (sürüş başlatmada da kullanılır)
+func PromotionError(err error) error {
+	switch {
+	case errorsx.Is(err, gorm.ErrRecordNotFound):
+		return errorsx.NotFoundError("Geçersiz kampanya kodu!")
+	case errorsx.Is(err, promotionService.ErrPromotionInactive):
+		return errorsx.BadRequestError("Kampanya kodu aktif değil!")
+	case errorsx.Is(err, promotionService.ErrPromotionExpired):
+		return errorsx.BadRequestError("Kampanya kodunun süresi dolmuş!")
+	case errorsx.Is(err, promotionService.ErrPromotionNotStarted):
+		return errorsx.BadRequestError("Kampanya henüz başlamadı!")
+	case errorsx.Is(err, promotionService.ErrPromotionUsageLimit):
+		return errorsx.BadRequestError("Kampanya kodunun kullanım limiti doldu!")
+	case errorsx.Is(err, promotionService.ErrPromotionUserLimit):
+		return errorsx.BadRequestError("Bu kodu kullanım hakkınız doldu!")
+	case errorsx.Is(err, promotionService.ErrPromotionNotEligible):
+		return errorsx.BadRequestError("Bu kod yalnızca ilk sürüşte kullanılabilir!")
+	default:
+		return errorsx.InternalError(err, "Kampanya kodu kontrol edilemedi!")
+	}
+}
+
+func checkPromotionRules(p models.Promotion) error {
+	if p.Type == models.PromotionPercentage && (p.Value <= 0 || p.Value > 100) {
+		return errorsx.BadRequestError("Yüzde indirim 0 ile 100 arasında olmalı!")
+	}
+	if (p.Type == models.PromotionFixedAmount || p.Type == models.PromotionFreeMinutes) && p.Value <= 0 {
+		return errorsx.BadRequestError("İndirim değeri 0'dan büyük olmalı!")
+	}
+	if p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidUntil.After(*p.ValidFrom) {
+		return errorsx.BadRequestError("Bitiş tarihi başlangıç tarihinden sonra olmalı!")
+	}
+	return nil
+}
+
+// aynı kod başka bir kampanyada kullanılıyorsa çakışma döner
+func (h PromotionHandler) checkCodeAvailable(ctx *app.Ctx, code string, id int64) error {
+	existing, err := h.promotionService.GetPromotionByCode(ctx.Context(), code)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errorsx.InternalError(err, "Kampanya kodu kontrol edilemedi!")
+	}
+	if existing.ID != id {
+		return errorsx.ConflictError("Bu kod zaten kullanılıyor!")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+type PromotionType string
+
+const (
+	PromotionPercentage    PromotionType = "percentage"      // sürüş ücretinden Value yüzdesi kadar indirim
+	PromotionFixedAmount   PromotionType = "fixed_amount"    // sürüş ücretinden Value TL indirim
+	PromotionFreeMinutes   PromotionType = "free_minutes"    // Value dakika ücretsiz sürüş
+	PromotionFirstRideFree PromotionType = "first_ride_free" // ilk sürüş ücretsiz, Value > 0 ise en fazla Value TL
+)
+
+// Promotion kampanya kodu. İndirim sürüş ücretine (park ek ücreti hariç) uygulanır.
+// MaxUses ve MaxUsesPerUser 0 ise sınırsızdır; ValidFrom/ValidUntil boşsa o yönde süre sınırı yoktur.
+type Promotion struct {
+	BaseModel
+	Code           string        `gorm:"type:varchar(50);not null;uniqueIndex"` // büyük harfe çevrilerek saklanır
+	Description    string        `gorm:"type:varchar(255)"`
+	Type           PromotionType `gorm:"type:varchar(30);not null"`
+	Value          float64       `gorm:"not null"`
+	MaxDiscount    float64       `gorm:"not null"` // percentage için üst sınır (TL), 0 ise sınırsız
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxUses        int  `gorm:"not null"`
+	MaxUsesPerUser int  `gorm:"not null"`
+	UsedCount      int  `gorm:"not null"` // kullanım kaydıyla aynı transaction içinde artırılır
+	IsActive       bool `gorm:"not null"`
+}
+
+// UserPromotion kullanıcının hesabına eklediği kod, sürüşte kod verilmemişse bitişte bu kodlardan en avantajlısı uygulanır
+type UserPromotion struct {
+	BaseModel
+	UserID      uint `gorm:"not null;uniqueIndex:idx_user_promotions_user_promotion"`
+	PromotionID uint `gorm:"not null;uniqueIndex:idx_user_promotions_user_promotion"`
+
+	Promotion Promotion `gorm:"foreignKey:PromotionID"`
+}
+
+// PromotionRedemption bir kodun bir sürüşte kullanıldığının kaydı, sürüş başına en fazla bir kod kullanılır
+type PromotionRedemption struct {
+	BaseModel
+	PromotionID uint    `gorm:"not null"`
+	UserID      uint    `gorm:"not null"`
+	RideID      uint    `gorm:"not null;uniqueIndex"`
+	Discount    float64 `gorm:"not null"`
+
+	Promotion Promotion `gorm:"foreignKey:PromotionID"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+func (UserPromotion) TableName() string {
+	return "user_promotions"
+}
+
+func (PromotionRedemption) TableName() string {
+	return "promotion_redemptions"
+}
+
+func (t PromotionType) String() string {
+	switch t {
+	case PromotionPercentage:
+		return "percentage"
+	case PromotionFixedAmount:
+		return "fixed_amount"
+	case PromotionFreeMinutes:
+		return "free_minutes"
+	case PromotionFirstRideFree:
+		return "first_ride_free"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"math"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	"motorbike-rental-backend/internal/app/promotion/models"
+)
+
+// Discount kodun ücret dökümüne uygulanmasıyla düşülecek tutarı hesaplar.
+// İndirim sürüş ücretini (q.Total) geçemez, park ek ücreti indirime dahil edilmez.
+func Discount(p models.Promotion, q pricingService.Quote) float64 {
+	fare := q.Total
+	if fare <= 0 {
+		return 0
+	}
+
+	var discount float64
+	switch p.Type {
+	case models.PromotionPercentage:
+		discount = fare * p.Value / 100
+		if p.MaxDiscount > 0 {
+			discount = math.Min(discount, p.MaxDiscount)
+		}
+	case models.PromotionFixedAmount:
+		discount = p.Value
+	case models.PromotionFreeMinutes:
+		// ücretsiz dakikalar çarpanlar uygulanmış ortalama dakika ücretinden düşülür, molalar hariç
+		activeMinutes := float64(q.Minutes - q.PausedMinutes)
+		if activeMinutes <= 0 {
+			return 0
+		}
+		discount = math.Min(p.Value, activeMinutes) * (q.TimeCost / activeMinutes)
+	case models.PromotionFirstRideFree:
+		discount = fare
+		if p.Value > 0 {
+			discount = math.Min(discount, p.Value)
+		}
+	}
+
+	return round2(math.Max(0, math.Min(discount, fare)))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
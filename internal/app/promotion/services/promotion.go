@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	"motorbike-rental-backend/internal/app/promotion/models"
+	modelRide "motorbike-rental-backend/internal/app/ride/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPromotionInactive        = errors.New("promotion is not active")
+	ErrPromotionNotStarted      = errors.New("promotion has not started yet")
+	ErrPromotionExpired         = errors.New("promotion has expired")
+	ErrPromotionUsageLimit      = errors.New("promotion usage limit reached")
+	ErrPromotionUserLimit       = errors.New("promotion usage limit reached for user")
+	ErrPromotionNotEligible     = errors.New("user is not eligible for promotion")
+	ErrPromotionAlreadyAttached = errors.New("promotion is already attached to user")
+)
+
+type IPromotionService interface {
+	GetAllPromotions(ctx context.Context) (*[]models.Promotion, error)
+	GetPromotionByID(ctx context.Context, id int) (*models.Promotion, error)
+	GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) error
+	UpdatePromotion(ctx context.Context, promotion *models.Promotion) error
+	DeletePromotion(ctx context.Context, id int) error
+	ValidateForUser(ctx context.Context, code string, userID uint) (*models.Promotion, error)
+	AttachToUser(ctx context.Context, code string, userID uint) (*models.UserPromotion, error)
+	GetUserPromotions(ctx context.Context, userID uint) (*[]models.UserPromotion, error)
+	RedeemForRide(ctx context.Context, ride modelRide.Ride, q pricingService.Quote) (*models.PromotionRedemption, error)
+	ReleaseRedemption(ctx context.Context, redemption *models.PromotionRedemption) error
+}
+
+type PromotionService struct {
+	DB *gorm.DB
+}
+
+func NewPromotionService(db *gorm.DB) IPromotionService {
+	return &PromotionService{DB: db}
+}
+
+// NormalizeCode kodları büyük harfe çevirir, kullanıcı "yaz25" veya " YAZ25 " girse de aynı kod bulunur
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (s *PromotionService) GetAllPromotions(ctx context.Context) (*[]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := s.DB.WithContext(ctx).Order("id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	return &promotions, nil
+}
+
+func (s *PromotionService) GetPromotionByID(ctx context.Context, id int) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&promotion).Error; err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (s *PromotionService) GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := s.DB.WithContext(ctx).Where("code = ?", NormalizeCode(code)).First(&promotion).Error; err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (s *PromotionService) CreatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	promotion.Code = NormalizeCode(promotion.Code)
+	return s.DB.WithContext(ctx).Create(promotion).Error
+}
+
+func (s *PromotionService) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	promotion.Code = NormalizeCode(promotion.Code)
+	// used_count kullanım kayıtlarıyla birlikte güncellenir, admin güncellemesi üzerine yazmamalı
+	return s.DB.WithContext(ctx).Omit("used_count").Save(promotion).Error
+}
+
+func (s *PromotionService) DeletePromotion(ctx context.Context, id int) error {
+	var promotion models.Promotion
+	if err := s.DB.WithContext(ctx).First(&promotion, id).Error; err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Delete(&promotion).Error
+}
+
+// ValidateForUser kodun şu an kullanıcı tarafından kullanılabilir olduğunu kontrol eder (sürüş başlatma ve koda ekleme için).
+// Kesin kontrol RedeemForRide içinde kilit altında tekrar yapılır.
+func (s *PromotionService) ValidateForUser(ctx context.Context, code string, userID uint) (*models.Promotion, error) {
+	promotion, err := s.GetPromotionByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkPromotion(s.DB.WithContext(ctx), *promotion, userID, 0, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *PromotionService) AttachToUser(ctx context.Context, code string, userID uint) (*models.UserPromotion, error) {
+	promotion, err := s.ValidateForUser(ctx, code, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userPromotion := models.UserPromotion{UserID: userID, PromotionID: uint(promotion.ID)}
+	result := s.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&userPromotion)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrPromotionAlreadyAttached
+	}
+
+	userPromotion.Promotion = *promotion
+	return &userPromotion, nil
+}
+
+func (s *PromotionService) GetUserPromotions(ctx context.Context, userID uint) (*[]models.UserPromotion, error) {
+	var userPromotions []models.UserPromotion
+	if err := s.DB.WithContext(ctx).Preload("Promotion").Where("user_id = ?", userID).Order("id").Find(&userPromotions).Error; err != nil {
+		return nil, err
+	}
+
+	return &userPromotions, nil
+}
+
+// RedeemForRide sürüşe indirim uygular ve kullanımı kaydeder. Sürüş başlatılırken kod verildiyse yalnızca o kod,
+// verilmediyse kullanıcının hesabına eklediği kodlardan en yüksek indirimi sağlayan kullanılabilir kod uygulanır.
+// Kod satırı kilitlenerek limitler tekrar kontrol edilir, kullanım kaydı ve sayaç aynı transaction içinde yazılır.
+// Uygulanabilir kod yoksa nil döner. Sürüş için daha önce kayıt yapıldıysa (bitirme tekrar deneniyorsa) o kayıt döner.
+func (s *PromotionService) RedeemForRide(ctx context.Context, ride modelRide.Ride, q pricingService.Quote) (*models.PromotionRedemption, error) {
+	var candidates []models.Promotion
+	if ride.PromotionID != nil {
+		promotion, err := s.GetPromotionByID(ctx, int(*ride.PromotionID))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if promotion != nil {
+			candidates = append(candidates, *promotion)
+		}
+	} else {
+		userPromotions, err := s.GetUserPromotions(ctx, ride.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, up := range *userPromotions {
+			candidates = append(candidates, up.Promotion)
+		}
+	}
+
+	// en yüksek indirim önce denenir, eşitlikte önce eklenen kod
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return Discount(candidates[i], q) > Discount(candidates[j], q)
+	})
+
+	var redemption *models.PromotionRedemption
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.PromotionRedemption
+		err := tx.Preload("Promotion").Where("ride_id = ?", ride.ID).First(&existing).Error
+		if err == nil {
+			redemption = &existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		for _, candidate := range candidates {
+			var promotion models.Promotion
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", candidate.ID).First(&promotion).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					continue
+				}
+				return err
+			}
+
+			// geçerlilik aralığı sürüşün başladığı ana göre kontrol edilir
+			if err := s.checkPromotion(tx, promotion, ride.UserID, uint(ride.ID), ride.StartTime); err != nil {
+				if isPromotionError(err) {
+					continue
+				}
+				return err
+			}
+
+			discount := Discount(promotion, q)
+			if discount <= 0 {
+				continue
+			}
+
+			r := models.PromotionRedemption{PromotionID: uint(promotion.ID), UserID: ride.UserID, RideID: uint(ride.ID), Discount: discount}
+			if err := tx.Create(&r).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&promotion).Update("used_count", gorm.Expr("used_count + 1")).Error; err != nil {
+				return err
+			}
+
+			r.Promotion = promotion
+			redemption = &r
+			return nil
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// ReleaseRedemption sürüş bitirilemediğinde kullanımı geri alır, kod tekrar kullanılabilir olur
+func (s *PromotionService) ReleaseRedemption(ctx context.Context, redemption *models.PromotionRedemption) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ?", redemption.ID).Delete(&models.PromotionRedemption{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Promotion{}).Where("id = ? AND used_count > 0", redemption.PromotionID).
+			Update("used_count", gorm.Expr("used_count - 1")).Error
+	})
+}
+
+// checkPromotion kodun aktifliğini, geçerlilik aralığını, genel ve kullanıcı başı limitlerini ve
+// ilk sürüş koşulunu kontrol eder. rideID verilmişse o sürüşe ait kayıtlar sayılmaz.
+func (s *PromotionService) checkPromotion(tx *gorm.DB, p models.Promotion, userID, rideID uint, at time.Time) error {
+	if !p.IsActive {
+		return ErrPromotionInactive
+	}
+	if p.ValidFrom != nil && at.Before(*p.ValidFrom) {
+		return ErrPromotionNotStarted
+	}
+	if p.ValidUntil != nil && at.After(*p.ValidUntil) {
+		return ErrPromotionExpired
+	}
+	if p.MaxUses > 0 && p.UsedCount >= p.MaxUses {
+		return ErrPromotionUsageLimit
+	}
+
+	if p.MaxUsesPerUser > 0 {
+		var used int64
+		if err := tx.Model(&models.PromotionRedemption{}).
+			Where("promotion_id = ? AND user_id = ? AND ride_id <> ?", p.ID, userID, rideID).
+			Count(&used).Error; err != nil {
+			return err
+		}
+		if used >= int64(p.MaxUsesPerUser) {
+			return ErrPromotionUserLimit
+		}
+	}
+
+	if p.Type == models.PromotionFirstRideFree {
+		var finished int64
+		if err := tx.Model(&modelRide.Ride{}).
+			Where("user_id = ? AND status = ? AND id <> ?", userID, modelRide.RideFinished, rideID).
+			Count(&finished).Error; err != nil {
+			return err
+		}
+		if finished > 0 {
+			return ErrPromotionNotEligible
+		}
+	}
+
+	return nil
+}
+
+func isPromotionError(err error) bool {
+	return errors.Is(err, ErrPromotionInactive) || errors.Is(err, ErrPromotionNotStarted) ||
+		errors.Is(err, ErrPromotionExpired) || errors.Is(err, ErrPromotionUsageLimit) ||
+		errors.Is(err, ErrPromotionUserLimit) || errors.Is(err, ErrPromotionNotEligible)
+}
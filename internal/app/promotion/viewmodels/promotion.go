@@ -0,0 +1,126 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/promotion/models"
+	"time"
+)
+
+// Kampanya kodu oluşturma için view model
+type PromotionCreateVM struct {
+	Code           string     `json:"code" validate:"required,max=50"`
+	Description    string     `json:"description" validate:"max=255"`
+	Type           string     `json:"type" validate:"required,oneof=percentage fixed_amount free_minutes first_ride_free"`
+	Value          float64    `json:"value" validate:"gte=0"`
+	MaxDiscount    float64    `json:"max_discount" validate:"gte=0"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	MaxUses        int        `json:"max_uses" validate:"gte=0"`          // 0 ise sınırsız
+	MaxUsesPerUser int        `json:"max_uses_per_user" validate:"gte=0"` // 0 ise sınırsız
+	IsActive       bool       `json:"is_active"`
+}
+
+func (vm PromotionCreateVM) ToDBModel() models.Promotion {
+	return models.Promotion{
+		Code:           vm.Code,
+		Description:    vm.Description,
+		Type:           models.PromotionType(vm.Type),
+		Value:          vm.Value,
+		MaxDiscount:    vm.MaxDiscount,
+		ValidFrom:      vm.ValidFrom,
+		ValidUntil:     vm.ValidUntil,
+		MaxUses:        vm.MaxUses,
+		MaxUsesPerUser: vm.MaxUsesPerUser,
+		IsActive:       vm.IsActive,
+	}
+}
+
+// Kampanya kodu güncelleme için view model, kullanım sayısı güncellenmez
+type PromotionUpdateVM struct {
+	Code           string     `json:"code" validate:"required,max=50"`
+	Description    string     `json:"description" validate:"max=255"`
+	Type           string     `json:"type" validate:"required,oneof=percentage fixed_amount free_minutes first_ride_free"`
+	Value          float64    `json:"value" validate:"gte=0"`
+	MaxDiscount    float64    `json:"max_discount" validate:"gte=0"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	MaxUses        int        `json:"max_uses" validate:"gte=0"`
+	MaxUsesPerUser int        `json:"max_uses_per_user" validate:"gte=0"`
+	IsActive       bool       `json:"is_active"`
+}
+
+func (vm PromotionUpdateVM) ToDBModel(m models.Promotion) models.Promotion {
+	m.Code = vm.Code
+	m.Description = vm.Description
+	m.Type = models.PromotionType(vm.Type)
+	m.Value = vm.Value
+	m.MaxDiscount = vm.MaxDiscount
+	m.ValidFrom = vm.ValidFrom
+	m.ValidUntil = vm.ValidUntil
+	m.MaxUses = vm.MaxUses
+	m.MaxUsesPerUser = vm.MaxUsesPerUser
+	m.IsActive = vm.IsActive
+	return m
+}
+
+// Kampanya kodu detayları için view model (admin)
+type PromotionDetailVM struct {
+	ID             int64      `json:"id"`
+	Code           string     `json:"code"`
+	Description    string     `json:"description"`
+	Type           string     `json:"type"`
+	Value          float64    `json:"value"`
+	MaxDiscount    float64    `json:"max_discount"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+	MaxUses        int        `json:"max_uses"`
+	MaxUsesPerUser int        `json:"max_uses_per_user"`
+	UsedCount      int        `json:"used_count"`
+	IsActive       bool       `json:"is_active"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (vm PromotionDetailVM) ToViewModel(m models.Promotion) PromotionDetailVM {
+	vm.ID = m.ID
+	vm.Code = m.Code
+	vm.Description = m.Description
+	vm.Type = m.Type.String()
+	vm.Value = m.Value
+	vm.MaxDiscount = m.MaxDiscount
+	vm.ValidFrom = m.ValidFrom
+	vm.ValidUntil = m.ValidUntil
+	vm.MaxUses = m.MaxUses
+	vm.MaxUsesPerUser = m.MaxUsesPerUser
+	vm.UsedCount = m.UsedCount
+	vm.IsActive = m.IsActive
+	vm.CreatedAt = m.CreatedAt
+	vm.UpdatedAt = m.UpdatedAt
+	return vm
+}
+
+// Kullanıcının hesabına kod eklemesi için view model
+type PromotionAttachVM struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
+
+// Kullanıcının hesabındaki kodlar için view model, limit bilgileri gösterilmez
+type UserPromotionVM struct {
+	Code        string     `json:"code"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Value       float64    `json:"value"`
+	MaxDiscount float64    `json:"max_discount"`
+	ValidUntil  *time.Time `json:"valid_until"`
+	AttachedAt  time.Time  `json:"attached_at"`
+}
+
+func (vm UserPromotionVM) ToViewModel(m models.UserPromotion) UserPromotionVM {
+	vm.Code = m.Promotion.Code
+	vm.Description = m.Promotion.Description
+	vm.Type = m.Promotion.Type.String()
+	vm.Value = m.Promotion.Value
+	vm.MaxDiscount = m.Promotion.MaxDiscount
+	vm.ValidUntil = m.Promotion.ValidUntil
+	vm.AttachedAt = m.CreatedAt
+	return vm
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	reservationService "motorbike-rental-backend/internal/app/reservation/services"
+	"motorbike-rental-backend/internal/app/reservation/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type ReservationHandler struct {
+	reservationService reservationService.IReservationService
+}
+
+func NewReservationHandler(s reservationService.IReservationService) ReservationHandler {
+	return ReservationHandler{reservationService: s}
+}
+
+// müsait bir motoru giriş yapmış kullanıcı adına belirli bir süre için ayırır
+func (h ReservationHandler) CreateReservation(ctx *app.Ctx) error {
+	var vm viewmodels.ReservationCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	reservation := vm.ToDBModel(uint(ctx.GetUserID()))
+
+	if err := h.reservationService.CreateReservation(ctx.Context(), &reservation); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir motorsiklet yok!")
+		}
+		if errorsx.Is(err, reservationService.ErrBikeNotAvailable) {
+			return errorsx.ConflictError("Bu Motorbisiklet şu anda müsait değil!")
+		}
+		if errorsx.Is(err, reservationService.ErrActiveReservationExists) {
+			return errorsx.ConflictError("Zaten aktif bir rezervasyonunuz var!")
+		}
+		return errorsx.InternalError(err, "Rezervasyon oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Rezervasyon oluşturuldu!", "id": reservation.ID, "expires_at": reservation.ExpiresAt})
+}
+
+func (h ReservationHandler) CancelReservation(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if _, err = h.reservationService.CancelReservation(ctx.Context(), id, int(ctx.GetUserID())); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Rezervasyon bulunamadı!")
+		}
+		if errorsx.Is(err, reservationService.ErrReservationNotActive) {
+			return errorsx.BadRequestError("Bu rezervasyon aktif değil!")
+		}
+		return errorsx.InternalError(err, "Rezervasyon iptal edilirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Rezervasyon iptal edildi!"})
+}
+
+func (h ReservationHandler) GetMyReservations(ctx *app.Ctx) error {
+	reservations, err := h.reservationService.GetReservationsByUserID(ctx.Context(), int(ctx.GetUserID()))
+	if err != nil {
+		return errorsx.InternalError(err, "Rezervasyonlar getirilemedi!")
+	}
+
+	var reservationDetails []viewmodels.ReservationDetailVM
+	for _, reservation := range *reservations {
+		reservationDetails = append(reservationDetails, viewmodels.ReservationDetailVM{}.ToViewModel(reservation))
+	}
+
+	return ctx.SuccessResponse(reservationDetails, len(reservationDetails))
+}
+
+func (h ReservationHandler) GetAllReservations(ctx *app.Ctx) error {
+	reservations, err := h.reservationService.GetAllReservations(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Rezervasyonlar getirilemedi!")
+	}
+
+	var reservationDetails []viewmodels.ReservationDetailVM
+	for _, reservation := range *reservations {
+		reservationDetails = append(reservationDetails, viewmodels.ReservationDetailVM{}.ToViewModel(reservation))
+	}
+
+	return ctx.SuccessResponse(reservationDetails, len(reservationDetails))
+}
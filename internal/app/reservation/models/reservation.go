@@ -0,0 +1,48 @@
+package models
+
+import (
+	modelMotor "motorbike-rental-backend/internal/app/motorbike/models"
+	modelUser "motorbike-rental-backend/internal/app/user-and-auth/models"
+	"time"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationConverted ReservationStatus = "converted" // aynı kullanıcı CreateRide çağırdı, sürüşe dönüştü
+	ReservationCancelled ReservationStatus = "cancelled"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation motoru ExpiresAt zamanına kadar kullanıcı adına 'reserved' durumunda tutar
+type Reservation struct {
+	BaseModel
+	UserID      uint              `gorm:"not null"`
+	MotorbikeID uint              `gorm:"not null"`
+	ExpiresAt   time.Time         `gorm:"not null"`
+	Status      ReservationStatus `gorm:"type:varchar(20);not null"`
+	RideID      *uint             // sürüşe dönüştüyse oluşturulan sürüş
+
+	User      modelUser.User       `gorm:"foreignKey:UserID"`
+	Motorbike modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
+}
+
+func (Reservation) TableName() string {
+	return "reservations"
+}
+
+func (r ReservationStatus) String() string {
+	switch r {
+	case ReservationActive:
+		return "active"
+	case ReservationConverted:
+		return "converted"
+	case ReservationCancelled:
+		return "cancelled"
+	case ReservationExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
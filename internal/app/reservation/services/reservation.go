@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	modelBike "motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/internal/app/reservation/models"
+	"time"
+)
+
+var (
+	ErrBikeNotAvailable        = errors.New("motorbike is not available")
+	ErrActiveReservationExists = errors.New("user already has an active reservation")
+	ErrReservationNotActive    = errors.New("reservation is not active")
+)
+
+type IReservationService interface {
+	GetAllReservations(ctx context.Context) (*[]models.Reservation, error)
+	GetReservationByID(ctx context.Context, id int) (*models.Reservation, error)
+	GetReservationsByUserID(ctx context.Context, userID int) (*[]models.Reservation, error)
+	GetActiveReservation(ctx context.Context, userID, motorbikeID int) (*models.Reservation, error)
+	CreateReservation(ctx context.Context, reservation *models.Reservation) error
+	CancelReservation(ctx context.Context, id int, userID int) (*models.Reservation, error)
+	ExpireReservations(ctx context.Context, now time.Time) (int, error)
+}
+
+type ReservationService struct {
+	DB           *gorm.DB
+	holdDuration time.Duration
+}
+
+func NewReservationService(db *gorm.DB, holdDuration time.Duration) IReservationService {
+	return &ReservationService{DB: db, holdDuration: holdDuration}
+}
+
+func (s *ReservationService) GetAllReservations(ctx context.Context) (*[]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := s.DB.WithContext(ctx).Preload("User").Preload("Motorbike").Order("id DESC").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	return &reservations, nil
+}
+
+func (s *ReservationService) GetReservationByID(ctx context.Context, id int) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := s.DB.WithContext(ctx).Preload("User").Preload("Motorbike").Where("id = ?", id).First(&reservation).Error; err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (s *ReservationService) GetReservationsByUserID(ctx context.Context, userID int) (*[]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := s.DB.WithContext(ctx).Preload("Motorbike").Where("user_id = ?", userID).Order("id DESC").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	return &reservations, nil
+}
+
+// GetActiveReservation kullanıcının motor için süresi dolmamış aktif rezervasyonunu döner
+func (s *ReservationService) GetActiveReservation(ctx context.Context, userID, motorbikeID int) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := s.DB.WithContext(ctx).
+		Where("user_id = ? AND motorbike_id = ? AND status = ? AND expires_at > ?", userID, motorbikeID, models.ReservationActive, time.Now()).
+		First(&reservation).Error; err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// CreateReservation motoru kilitleyerek (FOR UPDATE) müsaitliğini kontrol eder, 'reserved' yapar ve rezervasyonu aynı transaction içinde oluşturur
+func (s *ReservationService) CreateReservation(ctx context.Context, reservation *models.Reservation) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Reservation{}).
+			Where("user_id = ? AND status = ?", reservation.UserID, models.ReservationActive).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrActiveReservationExists
+		}
+
+		var motor modelBike.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", reservation.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		if motor.Status != modelBike.BikeAvailable {
+			return ErrBikeNotAvailable
+		}
+
+		if err := tx.Model(&motor).Update("status", modelBike.BikeReserved).Error; err != nil {
+			return err
+		}
+
+		reservation.Status = models.ReservationActive
+		reservation.ExpiresAt = time.Now().Add(s.holdDuration)
+
+		// sayım kilitsiz yapıldığı için eşzamanlı isteklerden ikincisi unique index'e takılır (idx_reservations_active_user)
+		if err := tx.Omit(clause.Associations).Create(reservation).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrActiveReservationExists
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
+// CancelReservation kullanıcının aktif rezervasyonunu iptal eder ve motoru tekrar 'available' yapar
+func (s *ReservationService) CancelReservation(ctx context.Context, id int, userID int) (*models.Reservation, error) {
+	var reservation models.Reservation
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", id, userID).
+			First(&reservation).Error; err != nil {
+			return err
+		}
+
+		if reservation.Status != models.ReservationActive {
+			return ErrReservationNotActive
+		}
+
+		return s.release(tx, &reservation, models.ReservationCancelled)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// ExpireReservations süresi dolmuş aktif rezervasyonları 'expired' yapar ve motorları serbest bırakır.
+// Arka plan işi tarafından periyodik olarak çağrılır, serbest bırakılan rezervasyon sayısını döner.
+func (s *ReservationService) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	var reservations []models.Reservation
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// başka bir istek (CreateRide / iptal) tarafından kilitlenmiş satırları atla, sonraki turda bakılır
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", models.ReservationActive, now).
+			Find(&reservations).Error; err != nil {
+			return err
+		}
+
+		for i := range reservations {
+			if err := s.release(tx, &reservations[i], models.ReservationExpired); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(reservations), nil
+}
+
+// release rezervasyonu verilen duruma çeker, motor hâlâ 'reserved' ise 'available' yapar
+func (s *ReservationService) release(tx *gorm.DB, reservation *models.Reservation, status models.ReservationStatus) error {
+	if err := tx.Model(reservation).Update("status", status).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&modelBike.Motorbike{}).
+		Where("id = ? AND status = ?", reservation.MotorbikeID, modelBike.BikeReserved).
+		Update("status", modelBike.BikeAvailable).Error
+}
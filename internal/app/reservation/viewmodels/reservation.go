@@ -0,0 +1,41 @@
+package viewmodels
+
+import (
+	motorViewmodel "motorbike-rental-backend/internal/app/motorbike/viewmodels"
+	"motorbike-rental-backend/internal/app/reservation/models"
+	"time"
+)
+
+type ReservationCreateVM struct {
+	MotorbikeID uint `json:"motorbike_id" validate:"required,numeric"`
+}
+
+func (vm ReservationCreateVM) ToDBModel(userID uint) models.Reservation {
+	return models.Reservation{
+		UserID:      userID,
+		MotorbikeID: vm.MotorbikeID,
+	}
+}
+
+type ReservationDetailVM struct {
+	ID          int64                       `json:"id"`
+	UserID      uint                        `json:"user_id"`
+	MotorbikeID uint                        `json:"motorbike_id"`
+	ExpiresAt   time.Time                   `json:"expires_at"`
+	Status      string                      `json:"status"`
+	RideID      *uint                       `json:"ride_id"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	Motorbike   motorViewmodel.BikeDetailVM `json:"motorbike"`
+}
+
+func (vm ReservationDetailVM) ToViewModel(m models.Reservation) ReservationDetailVM {
+	vm.ID = m.ID
+	vm.UserID = m.UserID
+	vm.MotorbikeID = m.MotorbikeID
+	vm.ExpiresAt = m.ExpiresAt
+	vm.Status = m.Status.String()
+	vm.RideID = m.RideID
+	vm.CreatedAt = m.CreatedAt
+	vm.Motorbike = motorViewmodel.NewBikeDetailVM(m.Motorbike, m.Motorbike.Photos)
+	return vm
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"go.uber.org/zap"
+	"math"
+	"motorbike-rental-backend/internal/app/ride/models"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/internal/app/ride/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/log"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+// (adminler için) sürüşün ücret düzeltmelerini döner -> /rides/:id/adjustments
+func (h RideHandler) GetRideAdjustments(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	adjustments, err := h.rideService.GetAdjustments(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Ücret düzeltmeleri getirilemedi!")
+	}
+
+	var adjustmentDetails []viewmodels.RideAdjustmentVM
+	for _, adjustment := range *adjustments {
+		adjustmentDetails = append(adjustmentDetails, viewmodels.RideAdjustmentVM{}.ToViewModel(adjustment))
+	}
+
+	return ctx.SuccessResponse(adjustmentDetails, len(adjustmentDetails))
+}
+
+// correctCost admin tarafından girilen ücret ile sürüşün güncel ücreti arasındaki farkı düzeltme kaydı olarak yazar.
+// Ücret düştüyse fark tahsil edilen ödemelerden iade edilir, arttıysa ve sürüş ödendiyse fark tahsil edilir.
+// Düzeltme kaydı iadeden önce yazılır; iade hiç yapılamazsa kayıt geri alınır, böylece kaydı olmayan iade oluşmaz.
+func (h RideHandler) correctCost(ctx *app.Ctx, ride *models.Ride, cost float64, reason string) error {
+	diff := math.Round((cost-ride.TotalCost())*100) / 100
+	if diff == 0 {
+		return nil
+	}
+
+	if ride.Status != models.RideFinished && ride.Status != models.RideDisputed {
+		return errorsx.BadRequestError("Bitmemiş sürüşün ücreti değiştirilemez, ücret bitişte hesaplanır!")
+	}
+	if cost < 0 {
+		return errorsx.BadRequestError("Sürüş ücreti eksi olamaz!")
+	}
+
+	adjustment := models.RideAdjustment{
+		RideID:    uint(ride.ID),
+		Type:      models.RideAdjustmentCorrection,
+		Amount:    diff,
+		Reason:    reason,
+		CreatedBy: uint(ctx.GetUserID()),
+	}
+
+	if err := h.rideService.AddAdjustment(ctx.Context(), &adjustment); err != nil {
+		if errorsx.Is(err, rideService.ErrAdjustmentExceedsCost) {
+			return errorsx.BadRequestError("Düzeltme sürüş ücretini aşıyor!")
+		}
+		return errorsx.InternalError(err, "Ücret düzeltmesi kaydedilemedi!")
+	}
+	ride.AdjustmentTotal += diff
+
+	if diff < 0 {
+		refunded, err := h.paymentService.RefundRide(ctx.Context(), uint(ride.ID), -diff)
+		if err != nil && refunded == 0 {
+			if revertErr := h.rideService.RevertAdjustment(ctx.Context(), &adjustment); revertErr != nil {
+				l := log.GetLogger("")
+				l.Error("İadesi yapılamayan düzeltme kaydı geri alınamadı", zap.Int64("adjustment_id", adjustment.ID), zap.Error(revertErr))
+			}
+			ride.AdjustmentTotal -= diff
+			return errorsx.InternalError(err, "İade yapılamadı!")
+		}
+		if err = h.rideService.SetAdjustmentRefund(ctx.Context(), adjustment.ID, refunded); err != nil {
+			return errorsx.InternalError(err, "İade yapıldı fakat iade tutarı kaydedilemedi!")
+		}
+	}
+
+	// ödenmiş sürüşün ücreti arttıysa fark tahsil edilir, alınamazsa sürüş 'unpaid' olur
+	if diff > 0 && ride.PaymentStatus == models.RidePaymentPaid {
+		_ = h.chargeRide(ctx, ride)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	"motorbike-rental-backend/internal/app/ride/models"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/utils"
+	"time"
+)
+
+// Kullanıcı motoru kilitleyip sürüşü bitirmeden mola verir, mola süresince tarifedeki mola ücreti uygulanır
+func (h RideHandler) PauseRide(ctx *app.Ctx) error {
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = h.rideService.PauseRide(ctx.Context(), ride); err != nil {
+		if errorsx.Is(err, rideService.ErrBikeNotLocked) {
+			return errorsx.BadRequestError("Mola vermek için önce motoru kilitleyin!")
+		}
+		return rideTransitionError(err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş molaya alındı!"})
+}
+
+func (h RideHandler) ResumeRide(ctx *app.Ctx) error {
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = h.rideService.ResumeRide(ctx.Context(), ride); err != nil {
+		if errorsx.Is(err, rideService.ErrRideNotPaused) {
+			return errorsx.ConflictError("Sürüş molada değil!")
+		}
+		return errorsx.InternalError(err, "Sürüş devam ettirilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş devam ediyor!"})
+}
+
+// getMyRide :id parametresindeki sürüşü getirir ve giriş yapmış kullanıcıya ait olduğunu kontrol eder
+func (h RideHandler) getMyRide(ctx *app.Ctx) (*models.Ride, error) {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return nil, errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	ride, err := h.rideService.GetRideByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorsx.NotFoundError("Sürüş bulunamadı!")
+		}
+		return nil, errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	if ride.UserID != uint(ctx.GetUserID()) {
+		return nil, errorsx.ForbiddenError("Bu sürüş size ait değil!")
+	}
+
+	return ride, nil
+}
+
+// pauseIntervals molaları ücret hesaplamasında kullanılacak aralıklara çevirir
+func (h RideHandler) pauseIntervals(ctx *app.Ctx, ride *models.Ride, until time.Time) ([]pricingService.Interval, error) {
+	pauses, err := h.rideService.GetBillablePauses(ctx.Context(), int(ride.ID), until)
+	if err != nil {
+		return nil, err
+	}
+
+	var intervals []pricingService.Interval
+	for _, pause := range pauses {
+		intervals = append(intervals, pricingService.Interval{Start: pause.StartedAt, End: *pause.EndedAt})
+	}
+	return intervals, nil
+}
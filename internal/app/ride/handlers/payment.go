@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"motorbike-rental-backend/internal/app/ride/models"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+)
+
+// Ödemesi alınamamış bitmiş sürüşün ücretini tekrar tahsil etmeyi dener -> /ride/:id/pay
+func (h RideHandler) PayRide(ctx *app.Ctx) error {
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	if (ride.Status != models.RideFinished && ride.Status != models.RideDisputed) || ride.PaymentStatus != models.RidePaymentUnpaid {
+		return errorsx.ConflictError("Bu sürüş için bekleyen bir ödeme yok!")
+	}
+
+	if err = h.chargeRide(ctx, ride); err != nil {
+		return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödeme alınamadı, lütfen bakiyenizi kontrol edin!", "cost (TL)": ride.TotalCost()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Ödeme alındı!", "cost (TL)": ride.TotalCost()})
+}
+
+// chargeRide sürüşün güncel ücretini (düzeltmeler dahil) tahsil eder ve sürüşün ödeme durumunu günceller.
+// Tahsilat başarısızsa sürüş 'unpaid' olarak işaretlenir ve kullanıcı yeni sürüş başlatamaz.
+func (h RideHandler) chargeRide(ctx *app.Ctx, ride *models.Ride) error {
+	status := models.RidePaymentPaid
+	_, chargeErr := h.paymentService.ChargeRide(ctx.Context(), ride.UserID, uint(ride.ID), ride.TotalCost())
+	if chargeErr != nil {
+		status = models.RidePaymentUnpaid
+	}
+
+	if err := h.rideService.SetPaymentStatus(ctx.Context(), int(ride.ID), status); err != nil {
+		return err
+	}
+
+	ride.PaymentStatus = status
+	return chargeErr
+}
@@ -2,31 +2,50 @@ package handlers
 
 import (
 	"errors"
-	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	connHandler "motorbike-rental-backend/internal/app/bluetooth-connection/handlers"
+	invoiceService "motorbike-rental-backend/internal/app/invoice/services"
 	motorModel "motorbike-rental-backend/internal/app/motorbike/models"
 	motorService "motorbike-rental-backend/internal/app/motorbike/services"
+	passService "motorbike-rental-backend/internal/app/pass/services"
+	paymentModel "motorbike-rental-backend/internal/app/payment/models"
+	paymentService "motorbike-rental-backend/internal/app/payment/services"
+	penaltyService "motorbike-rental-backend/internal/app/penalty/services"
+	pricingService "motorbike-rental-backend/internal/app/pricing/services"
+	promotionHandler "motorbike-rental-backend/internal/app/promotion/handlers"
+	promotionService "motorbike-rental-backend/internal/app/promotion/services"
 	"motorbike-rental-backend/internal/app/ride/models"
 	rideService "motorbike-rental-backend/internal/app/ride/services"
 	"motorbike-rental-backend/internal/app/ride/viewmodels"
+	zoneService "motorbike-rental-backend/internal/app/zone/services"
 	"motorbike-rental-backend/pkg/app"
 	"motorbike-rental-backend/pkg/errorsx"
-	"motorbike-rental-backend/pkg/utils"
-	"path/filepath"
+	"motorbike-rental-backend/pkg/log"
+	"motorbike-rental-backend/pkg/storage"
 	"strconv"
 	"time"
 )
 
 type RideHandler struct {
-	rideService  rideService.IRideService
-	motorService motorService.IMotorService
-	connHandler  connHandler.ConnHandler
+	rideService      rideService.IRideService
+	motorService     motorService.IMotorService
+	connHandler      connHandler.ConnHandler
+	pricingService   pricingService.IPricingService
+	zoneService      zoneService.IZoneService
+	paymentService   paymentService.IPaymentService
+	invoiceService   invoiceService.IInvoiceService
+	promotionService promotionService.IPromotionService
+	passService      passService.IPassService
+	penaltyService   penaltyService.IPenaltyService
+	uploader         storage.Uploader
+	holdAmount       float64 // sürüş başlarken alınan provizyon tutarı
+	fineThreshold    float64 // ödenmemiş cezalar bu tutarı aşarsa sürüş başlatılamaz
 }
 
-func NewRideHandler(s rideService.IRideService, m motorService.IMotorService, c connHandler.ConnHandler) RideHandler {
-	return RideHandler{rideService: s, motorService: m, connHandler: c}
+func NewRideHandler(s rideService.IRideService, m motorService.IMotorService, c connHandler.ConnHandler, p pricingService.IPricingService, z zoneService.IZoneService, ps paymentService.IPaymentService, i invoiceService.IInvoiceService, pr promotionService.IPromotionService, pa passService.IPassService, pe penaltyService.IPenaltyService, u storage.Uploader, holdAmount, fineThreshold float64) RideHandler {
+	return RideHandler{rideService: s, motorService: m, connHandler: c, pricingService: p, zoneService: z, paymentService: ps, invoiceService: i, promotionService: pr, passService: pa, penaltyService: pe, uploader: u, holdAmount: holdAmount, fineThreshold: fineThreshold}
 }
 
 func (h RideHandler) GetAllRides(ctx *app.Ctx) error {
@@ -74,36 +93,80 @@ func (h RideHandler) CreateRide(ctx *app.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz istek!"})
 	}
 
+	// sürüş, provizyon, borç ve kampanya kontrolleri istekteki user_id'ye değil giriş yapmış kullanıcıya göre yapılır
 	ride := rideCreateVM.ToDBModel()
+	ride.UserID = uint(ctx.GetUserID())
 
-	motor, err := h.motorService.GetMotorByID(ctx.Context(), int(ride.MotorbikeID))
+	// Ödemesi alınamamış sürüşü olan kullanıcı önce borcunu ödemelidir
+	unpaid, err := h.rideService.HasUnpaidRide(ctx.Context(), ride.UserID)
 	if err != nil {
-		if errorsx.Is(err, gorm.ErrRecordNotFound) {
-			return errorsx.InternalError(err, "Böyle bir motorsiklet yok! Hatalı bağlantı isteği!")
-		}
-		return errorsx.InternalError(err, "Bir hata oluştu!")
+		return errorsx.InternalError(err, "Ödeme durumu kontrol edilemedi!")
+	}
+	if unpaid {
+		return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödenmemiş bir sürüşünüz var, yeni sürüş başlatmadan önce ödeme yapın!"})
 	}
 
-	if motor == nil {
-		return errorsx.InternalError(nil, "Motorbisiklet verisi alınamadı!")
+	// Ödenmemiş cezaları eşiği aşan kullanıcı önce cezalarını ödemelidir
+	outstanding, err := h.penaltyService.GetOutstandingAmount(ctx.Context(), ride.UserID)
+	if err != nil {
+		return errorsx.InternalError(err, "Ceza durumu kontrol edilemedi!")
+	}
+	if outstanding > h.fineThreshold {
+		return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Ödenmemiş cezalarınız var, yeni sürüş başlatmadan önce cezalarınızı ödeyin!", "outstanding_amount (TL)": outstanding})
 	}
 
-	// Motorbike'ın durumu 'Available' mı kontrol et
-	if motor.Status != motorModel.BikeAvailable {
-		return errorsx.BadRequestError("Bu Motorbisiklet şu anda müsait değil!")
+	// Kampanya kodu verildiyse şimdi doğrulanır, indirim bitişte uygulanır
+	if rideCreateVM.PromoCode != "" {
+		promotion, err := h.promotionService.ValidateForUser(ctx.Context(), rideCreateVM.PromoCode, ride.UserID)
+		if err != nil {
+			return promotionHandler.PromotionError(err)
+		}
+		promotionID := uint(promotion.ID)
+		ride.PromotionID = &promotionID
+	}
+
+	// Motor 'rented' yapılmadan önce provizyon alınır, alınamazsa sürüş oluşturulmaz
+	var hold *paymentModel.Payment
+	if h.holdAmount > 0 {
+		if hold, err = h.paymentService.AuthorizeRideHold(ctx.Context(), ride.UserID, h.holdAmount); err != nil {
+			if errorsx.Is(err, paymentService.ErrInsufficientFunds) || errorsx.Is(err, paymentService.ErrPaymentDeclined) {
+				return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Provizyon alınamadı, lütfen bakiyenizi kontrol edin!", "hold_amount (TL)": h.holdAmount})
+			}
+			return errorsx.InternalError(err, "Provizyon alınamadı!")
+		}
 	}
 
-	motor.Status = motorModel.BikeRented
+	// Motor kontrolü, 'rented' durumuna geçiş, sürüş kaydı ve provizyonun sürüşe bağlanması tek transaction içinde yapılır
+	var holdID *int64
+	if hold != nil {
+		holdID = &hold.ID
+	}
+	if err = h.rideService.StartRide(ctx.Context(), &ride, holdID); err != nil {
+		// sürüş başlamadıysa provizyon serbest bırakılır
+		if hold != nil {
+			if voidErr := h.paymentService.VoidPayment(ctx.Context(), hold.ID); voidErr != nil {
+				l := log.GetLogger("")
+				l.Error("Başlatılamayan sürüşün provizyonu iptal edilemedi", zap.Int64("payment_id", hold.ID), zap.Error(voidErr))
+			}
+		}
 
-	if err = h.motorService.UpdateMotor(ctx.Context(), motor); err != nil {
-		return errorsx.InternalError(err, "Sürüş oluşturulamadı!")
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir motorsiklet yok! Hatalı bağlantı isteği!")
+		}
+		if errorsx.Is(err, rideService.ErrBikeNotAvailable) {
+			return errorsx.ConflictError("Bu Motorbisiklet şu anda müsait değil!")
+		}
+		return errorsx.InternalError(err, "Sürüş oluşturulurken hata oluştu!")
 	}
 
-	if err := h.rideService.CreateRide(ctx.Context(), &ride); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Sürüş oluşturulurken hata oluştu!"})
+	// çevrimdışı kilit açma token'ı yalnızca başlamış sürüşün sahibine verilir, alınamazsa kilit çevrimiçi komutla açılır
+	unlockToken, err := h.connHandler.IssueRideUnlockToken(ctx, ride.UserID, ride.MotorbikeID)
+	if err != nil {
+		l := log.GetLogger("")
+		l.Error("Kilit açma token'ı oluşturulamadı", zap.Int64("ride_id", ride.ID), zap.Error(err))
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş eklendi!"})
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş eklendi!", "ride_id": ride.ID, "unlock_token": unlockToken})
 }
 
 func (h RideHandler) GetRidesByUserID(ctx *app.Ctx) error {
@@ -187,8 +250,8 @@ func (h RideHandler) GetRidesByBikeID(ctx *app.Ctx) error {
 
 func (h RideHandler) UpdateRideByID(ctx *app.Ctx) error {
 	var rideUpdateVM viewmodels.RideUpdateVM
-	if err := ctx.BodyParser(&rideUpdateVM); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz istek!"})
+	if err := ctx.BodyParseValidate(&rideUpdateVM); err != nil {
+		return errorsx.ValidationError(err)
 	}
 
 	param := ctx.Params("id")
@@ -207,55 +270,140 @@ func (h RideHandler) UpdateRideByID(ctx *app.Ctx) error {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Sürüş güncellenirken bir hata oluştu!"})
 	}
 
+	// Ücret Cost üzerine yazılmaz, fark düzeltme kaydı olarak eklenir. cost verilmezse ücret değişmez
+	if rideUpdateVM.Cost != nil {
+		if err = h.correctCost(ctx, &updatedRide, *rideUpdateVM.Cost, rideUpdateVM.Reason); err != nil {
+			return err
+		}
+	}
+
 	/*var vm viewmodels.RideDetailVM
 	rideDetail := vm.ToDBModel(updatedRide)*/ // eğer güncellediğimiz veriyi listelemek istersek rideDetail i gönder!
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş başarıyla güncellendi!"})
 }
 
+// Sürüş yalnızca awaiting_photo adımından ve fotoğraf yüklenmişse bitirilebilir.
+// Daha önceki bir adımdaysa motorun kilit durumuna göre bir sonraki adıma taşınır ve eksik olan adım döndürülür.
 func (h RideHandler) FinishRide(ctx *app.Ctx) error {
-	id, err := utils.GetMyParamInt(ctx, "id")
+	ride, err := h.getMyRide(ctx)
 	if err != nil {
-		return errorsx.BadRequestError("Hatalı istek!")
+		return err
 	}
 
-	ride, err := h.rideService.GetRideByID(ctx.Context(), id)
+	if ride.Status == models.RideFinished {
+		return errorsx.BadRequestError("Zaten sürüş bitirildi!")
+	}
+
+	if err = h.moveToEndStep(ctx, ride); err != nil {
+		return rideTransitionError(err)
+	}
+
+	if ride.Status == models.RideAwaitingLock {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Motorbike kilitlenmedi! Lütfen önce kilitleyin!"})
+	}
+
+	if ride.EndPhotoURL == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Sürüşü bitirmeden önce motorun fotoğrafını yükleyin!"})
+	}
+
+	// Bitiş konumu ve mesafe GPS izinden hesaplanır, motorun konumu da bu noktaya taşınır
+	if err = h.rideEndLocation(ctx, ride); err != nil {
+		return errorsx.InternalError(err, "Sürüş izi getirilemedi!")
+	}
+
+	// Hizmet alanı dışında veya park yasağı olan bölgede sürüş bitirilemez, ek ücretli bölgede ücret eklenir
+	parking, err := h.zoneService.CheckParking(ctx.Context(), *ride.EndLat, *ride.EndLng)
 	if err != nil {
-		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Sürüş bulunamadı!"})
+		if errorsx.Is(err, zoneService.ErrOutsideOperatingArea) {
+			return errorsx.BadRequestError("Sürüşü hizmet alanı dışında bitiremezsiniz!")
+		}
+		if errorsx.Is(err, zoneService.ErrNoParkingZone) {
+			return errorsx.BadRequestError("Park yasağı olan bir bölgedesiniz, lütfen motoru başka bir yere park edin!")
+		}
+		return errorsx.InternalError(err, "Park bölgesi kontrol edilemedi!")
 	}
 
-	if ride.EndTime == nil {
-		now := time.Now().UTC()
-		ride.EndTime = &now
+	now := time.Now().UTC()
+	ride.EndTime = &now
 
-		// Sürüş süresini hesapla// Sürüş süresini hesapla (StartTime bir pointer değilse)
-		duration := now.Sub(ride.StartTime)
-		ride.Duration = strconv.Itoa(int(duration.Seconds())) // Saniye cinsinden süreyi kaydet
+	// Sürüş süresini hesapla (StartTime bir pointer değilse)
+	duration := now.Sub(ride.StartTime)
+	ride.Duration = strconv.Itoa(int(duration.Seconds())) // Saniye cinsinden süreyi kaydet
 
-		// Süreyi dakika cinsinden hesapla (her dakika için 3 TL)
-		minutes := int(duration.Minutes())
-		costPerMinute := 3
-		ride.Cost = float64(minutes*costPerMinute) + 10
-	} else {
-		return errorsx.BadRequestError("Zaten sürüş bitirildi!")
+	// Mola dakikaları tarifedeki mola ücretinden, azami mola süresini aşan kısım tam ücretten hesaplanır
+	pauses, err := h.pauseIntervals(ctx, ride, now)
+	if err != nil {
+		return errorsx.InternalError(err, "Sürüş molaları getirilemedi!")
+	}
+
+	// Ücreti motor modeline ait tarifeye göre hesapla
+	quote, err := h.pricingService.Quote(ctx.Context(), ride.Motorbike.Model, ride.StartTime, now, pauses)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.InternalError(err, "Bu motor için tanımlı bir tarife yok!")
+		}
+		return errorsx.InternalError(err, "Sürüş ücreti hesaplanamadı!")
 	}
 
-	// Motorbike'in kilitlenmiş olup olmadığını kontrol et
-	motorbike, err := h.motorService.GetMotorByID(ctx.Context(), int(ride.MotorbikeID))
+	// Sürüş başladığında geçerli bir abonelik paketi varsa kilit açma ücreti ve ücretsiz dakikalar düşülür
+	pass, passDiscount, err := h.passService.BestPassFor(ctx.Context(), ride.UserID, ride.StartTime, *quote)
 	if err != nil {
-		return errorsx.InternalError(err, "Motorbike bilgileri alınamadı!")
+		return errorsx.InternalError(err, "Abonelik paketi kontrol edilemedi!")
+	}
+	if pass != nil && passDiscount > 0 {
+		userPassID := uint(pass.ID)
+		ride.UserPassID = &userPassID
+		ride.PassDiscount = passDiscount
 	}
 
-	if motorbike.LockStatus != motorModel.Locked {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Motorbike kilitlenmedi! Lütfen önce kilitleyin!"})
+	// Kampanya indirimi paketten sonra kalan sürüş ücretine uygulanır, park ek ücreti indirime dahil değildir
+	fare := *quote
+	fare.Total -= ride.PassDiscount
+	redemption, err := h.promotionService.RedeemForRide(ctx.Context(), *ride, fare)
+	if err != nil {
+		return errorsx.InternalError(err, "Kampanya kodu uygulanamadı!")
+	}
+	if redemption != nil {
+		ride.PromotionID = &redemption.PromotionID
+		ride.Discount = redemption.Discount
 	}
 
-	err = h.rideService.UpdateRide(ctx.Context(), ride)
+	ride.ParkingSurcharge = parking.Surcharge
+	ride.Cost = quote.Total - ride.PassDiscount - ride.Discount + parking.Surcharge
+
+	// Motorun kilitli olduğu ve sürüşün başka bir istekle bitirilmediği transaction içinde tekrar kontrol edilir
+	err = h.rideService.FinishRide(ctx.Context(), ride)
 	if err != nil {
+		// sürüş bitmediyse kod kullanılmamış sayılır
+		if redemption != nil {
+			_ = h.promotionService.ReleaseRedemption(ctx.Context(), redemption)
+		}
+
+		if errorsx.Is(err, rideService.ErrRideAlreadyFinished) {
+			return errorsx.BadRequestError("Zaten sürüş bitirildi!")
+		}
+		if errorsx.Is(err, rideService.ErrBikeNotLocked) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Motorbike kilitlenmedi! Lütfen önce kilitleyin!"})
+		}
+		if errorsx.Is(err, rideService.ErrIllegalRideTransition) {
+			return rideTransitionError(err)
+		}
 		return errorsx.InternalError(err, "Sürüş bitirilemedi!")
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş bitirildi!", "cost (TL)": ride.Cost})
+	// Fiş ücret dökümüyle birlikte kesilir, hata olursa /rides/:id/receipt isteğinde tek kalemli olarak kesilir
+	if _, err = h.invoiceService.IssueRideInvoice(ctx.Context(), *ride, invoiceService.QuoteLines(*quote, *ride)); err != nil {
+		l := log.GetLogger("")
+		l.Error("Sürüş fişi kesilemedi", zap.Int64("ride_id", ride.ID), zap.Error(err))
+	}
+
+	// Sürüş bitti, ücret tahsil edilir. Tahsilat başarısızsa sürüş 'unpaid' kalır ve /ride/:id/pay ile tekrar denenir.
+	if err = h.chargeRide(ctx, ride); err != nil {
+		return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": "Sürüş bitirildi fakat ödeme alınamadı!", "cost (TL)": ride.Cost, "payment_status": ride.PaymentStatus.String()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Sürüş bitirildi!", "cost (TL)": ride.Cost, "parking_surcharge (TL)": ride.ParkingSurcharge, "pass_discount (TL)": ride.PassDiscount, "discount (TL)": ride.Discount, "payment_status": ride.PaymentStatus.String()})
 }
 
 func (h RideHandler) DeleteRide(ctx *app.Ctx) error {
@@ -366,45 +514,71 @@ func (h RideHandler) GetRidesByUserAndDate(ctx *app.Ctx) error {
 }
 
 // Kullanıcı sürüşü bitirip motoru kilitlediğinde, /ride/:id/photo rotasına bir POST isteğiyle fotoğrafı yükler.
-// API önce fotoğrafı yükler, ardından motorun kilitli olup olmadığını kontrol eder.
-// Eğer motor kilitliyse, Bluetooth bağlantısını keser ve bu bilgiyi yanıt olarak döner.
+// API önce motorun kilitli olup olmadığını kontrol eder (kilitli değilse sürüş awaiting_lock adımına geçer),
+// kilitliyse sürüş awaiting_photo adımına geçer, fotoğraf kaydedilir ve Bluetooth bağlantısı kesilir.
+// Motor sürüş bitene kadar kullanıcıda kalır, 'available' durumuna FinishRide ile geçer.
+// Motorun kilit durumu cihazın heartbeat'i veya kilit komutunun sonucuyla güncellenir (bkz. internal/app/device).
 func (h RideHandler) AddRidePhoto(ctx *app.Ctx) error {
-	rideID, err := utils.GetMyParamInt(ctx, "id")
+	ride, err := h.getMyRide(ctx)
 	if err != nil {
 		return err
 	}
-	// Fotoğrafı yükle
-	photo, err := ctx.FormFile("photo")
-	if err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Photo upload failed"})
+	rideID := int(ride.ID)
+
+	if err = h.moveToEndStep(ctx, ride); err != nil {
+		return rideTransitionError(err)
 	}
 
-	// Dosya kaydedileceği yol
-	fileDir := fmt.Sprintf("uploads/rides")
-	filePath := filepath.Join(fileDir, "ride_id_"+strconv.Itoa(rideID)+"_name_"+photo.Filename)
+	// Motor kilitlenmediyse fotoğraf kabul edilmez
+	if ride.Status == models.RideAwaitingLock {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Please lock the bike!"})
+	}
 
-	// Fotoğrafı kaydet
-	if err = ctx.SaveFile(photo, filePath); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save photo"})
+	photo, err := ctx.FormFile("photo")
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Photo upload failed"})
 	}
 
-	// Motorun kilitlenip kilitlenmediğini kontrol et (örneğin rideService üzerinden)
-	ride, err := h.rideService.GetRideByID(ctx.Context(), rideID)
+	// Fotoğrafı yükle, dosya adı istemciden alınmaz, key UUID'dir
+	photoRef, err := h.uploader.Image(ctx.Context(), "rides/"+strconv.Itoa(rideID), photo)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ride not found"})
+		if storage.IsInvalidUpload(err) {
+			return errorsx.BadRequestError("Fotoğraf JPEG, PNG veya WebP olmalı ve boyut sınırını aşmamalı!")
+		}
+		return errorsx.InternalError(err, "Failed to save photo")
 	}
 
-	// Eğer motor kilitlendiyse bağlantıyı kes
-	if ride.Motorbike.LockStatus != motorModel.Locked {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Please lock the bike!"})
+	if err = h.rideService.SetEndPhoto(ctx.Context(), rideID, photoRef); err != nil {
+		return errorsx.InternalError(err, "Failed to save photo")
 	}
 
-	err = h.connHandler.Disconnect(ctx, int(ride.MotorbikeID))
-	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to disconnect"})
+	if err = h.connHandler.DisconnectForRide(ctx, int(ride.MotorbikeID)); err != nil {
+		return errorsx.InternalError(err, "Failed to disconnect")
 	}
 
 	return ctx.JSON(fiber.Map{
 		"message": "Photo uploaded successfully and motorbike disconnected",
 	})
 }
+
+// moveToEndStep sürüşü bitirme sırasında motorun kilit durumuna göre ilgili adıma taşır:
+// motor kilitli değilse awaiting_lock, kilitliyse awaiting_photo.
+func (h RideHandler) moveToEndStep(ctx *app.Ctx, ride *models.Ride) error {
+	next := models.RideAwaitingPhoto
+	if ride.Motorbike.LockStatus != motorModel.Locked {
+		next = models.RideAwaitingLock
+	}
+
+	if ride.Status == next {
+		return nil
+	}
+
+	return h.rideService.TransitionRide(ctx.Context(), ride, next)
+}
+
+func rideTransitionError(err error) error {
+	if errorsx.Is(err, rideService.ErrIllegalRideTransition) {
+		return errorsx.ConflictError("Sürüş bu işlem için uygun durumda değil!")
+	}
+	return errorsx.InternalError(err, "Sürüş durumu güncellenemedi!")
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/ride/models"
+	rideService "motorbike-rental-backend/internal/app/ride/services"
+	"motorbike-rental-backend/internal/app/ride/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/geo"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+// Sürüş devam ederken uygulama GPS noktalarını toplu olarak /ride/:id/track rotasına gönderir.
+// Yalnızca sürüşün sahibi ve bitmemiş sürüşler için nokta kabul edilir.
+func (h RideHandler) AddTrackPoints(ctx *app.Ctx) error {
+	var vm viewmodels.RideTrackCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	ride, err := h.getMyRide(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !rideService.IsInProgress(ride.Status) {
+		return errorsx.ConflictError("Bitmiş sürüşe konum eklenemez!")
+	}
+
+	if err := h.rideService.AddTrackPoints(ctx.Context(), vm.ToDBModels(uint(ride.ID))); err != nil {
+		return errorsx.InternalError(err, "Konum noktaları kaydedilemedi!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Konum noktaları kaydedildi!", "count": len(vm.Points)})
+}
+
+// (adminler için) sürüşün izlediği yolu GeoJSON LineString olarak döner -> /rides/:id/track
+func (h RideHandler) GetRideTrack(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	ride, err := h.rideService.GetRideByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Sürüş bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Sürüş getirilirken hata oluştu!")
+	}
+
+	points, err := h.rideService.GetTrackPoints(ctx.Context(), id)
+	if err != nil {
+		return errorsx.InternalError(err, "Sürüş izi getirilemedi!")
+	}
+
+	path := trackPath(*points)
+	feature := geo.NewFeature(geo.NewLineString(path), map[string]interface{}{
+		"ride_id":     ride.ID,
+		"status":      ride.Status.String(),
+		"point_count": len(path),
+		"distance_m":  geo.PathLength(path),
+		"start_time":  ride.StartTime,
+		"end_time":    ride.EndTime,
+	})
+
+	return ctx.Status(fiber.StatusOK).JSON(feature)
+}
+
+// rideEndLocation sürüşün bitiş noktasını ve izden hesaplanan mesafeyi ayarlar.
+// Hiç nokta gelmediyse motorun bilinen son konumu bitiş noktası kabul edilir.
+func (h RideHandler) rideEndLocation(ctx *app.Ctx, ride *models.Ride) error {
+	points, err := h.rideService.GetTrackPoints(ctx.Context(), int(ride.ID))
+	if err != nil {
+		return err
+	}
+
+	path := trackPath(*points)
+	if len(path) == 0 {
+		ride.EndLat = &ride.Motorbike.LocationLatitude
+		ride.EndLng = &ride.Motorbike.LocationLongitude
+		return nil
+	}
+
+	last := path[len(path)-1]
+	ride.EndLat = &last.Lat
+	ride.EndLng = &last.Lng
+	ride.DistanceMeters = geo.PathLength(path)
+	return nil
+}
+
+func trackPath(points []models.RideTrackPoint) []geo.Point {
+	path := make([]geo.Point, 0, len(points))
+	for _, p := range points {
+		path = append(path, geo.Point{Lat: p.Latitude, Lng: p.Longitude})
+	}
+	return path
+}
@@ -0,0 +1,36 @@
+package models
+
+type RideAdjustmentType string
+
+const (
+	RideAdjustmentRefund     RideAdjustmentType = "refund"     // itiraz sonucu iade
+	RideAdjustmentCorrection RideAdjustmentType = "correction" // admin ücret düzeltmesi
+)
+
+// RideAdjustment bitmiş sürüşün ücretinde yapılan her değişikliğin kaydı. Ride.Cost değiştirilmez,
+// sürüşün güncel ücreti Cost + düzeltmelerin toplamıdır (Ride.AdjustmentTotal).
+type RideAdjustment struct {
+	BaseModel
+	RideID         uint               `gorm:"not null"`
+	Type           RideAdjustmentType `gorm:"type:varchar(20);not null"`
+	Amount         float64            `gorm:"not null"` // negatifse ücret düşer
+	RefundedAmount float64            `gorm:"not null"` // ödeme sağlayıcısından iade edilen kısım
+	Reason         string             `gorm:"type:varchar(500)"`
+	DisputeID      *uint              // itiraz sonucu yapıldıysa ilgili itiraz
+	CreatedBy      uint               `gorm:"not null"` // işlemi yapan admin
+}
+
+func (RideAdjustment) TableName() string {
+	return "ride_adjustments"
+}
+
+func (t RideAdjustmentType) String() string {
+	switch t {
+	case RideAdjustmentRefund:
+		return "refund"
+	case RideAdjustmentCorrection:
+		return "correction"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RidePause sürüş sırasında motor kilitlenip verilen mola. EndedAt boşsa mola devam ediyor.
+type RidePause struct {
+	BaseModel
+	RideID      uint       `gorm:"not null"`
+	StartedAt   time.Time  `gorm:"not null"`
+	EndedAt     *time.Time // mola bitişi, azami süreyi aşan molalarda StartedAt + azami süre
+	AutoResumed bool       `gorm:"not null"` // azami süre dolduğu için arka plan işi tarafından bitirildi
+}
+
+func (RidePause) TableName() string {
+	return "ride_pauses"
+}
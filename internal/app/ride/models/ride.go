@@ -6,7 +6,27 @@ import (
 	"time"
 )
 
-// todo : buraya ek olarak başlangıç ve bitiş noktası/konumu eklemeliyiz!
+type RideStatus string
+
+const (
+	RideReserved      RideStatus = "reserved"
+	RideActive        RideStatus = "active"
+	RidePaused        RideStatus = "paused"
+	RideAwaitingPhoto RideStatus = "awaiting_photo"
+	RideAwaitingLock  RideStatus = "awaiting_lock"
+	RideFinished      RideStatus = "finished"
+	RideCancelled     RideStatus = "cancelled"
+	RideDisputed      RideStatus = "disputed"
+)
+
+type RidePaymentStatus string
+
+const (
+	RidePaymentPending RidePaymentStatus = "pending" // sürüş bitmedi, henüz tahsilat yapılmadı
+	RidePaymentPaid    RidePaymentStatus = "paid"
+	RidePaymentUnpaid  RidePaymentStatus = "unpaid" // tahsilat başarısız, kullanıcı yeni sürüş başlatamaz
+)
+
 type Ride struct {
 	BaseModel
 	UserID      uint       `gorm:"not null"`
@@ -15,6 +35,25 @@ type Ride struct {
 	EndTime     *time.Time `gorm:"not null"`
 	Duration    string     `gorm:"type:interval;not null"`
 	Cost        float64    `gorm:"not null"`
+	Status      RideStatus `gorm:"type:varchar(20);not null"` // geçişler ride/services/transition.go içindeki tabloya göre yapılır
+	EndPhotoURL string     `gorm:"type:varchar(255)"`         // sürüş sonu yüklenen fotoğraf
+	EndStepAt   *time.Time // sürüşün bitirme adımına (awaiting_lock / awaiting_photo) girdiği zaman, otomatik cezalar buna göre kesilir
+
+	StartLat         *float64 // sürüş başladığında motorun konumu
+	StartLng         *float64
+	EndLat           *float64 // son GPS noktası, nokta gelmediyse motorun bilinen son konumu
+	EndLng           *float64
+	DistanceMeters   float64 `gorm:"not null"` // GPS izinden hesaplanan mesafe
+	ParkingSurcharge float64 `gorm:"not null"` // park yasağı olan bölgede bitirildiyse eklenen ücret (Cost'a dahil)
+
+	UserPassID   *uint   // bitişte uygulanan abonelik paketi
+	PassDiscount float64 `gorm:"not null"` // abonelik paketinin düştüğü tutar (kilit açma, ücretsiz dakikalar)
+	PromotionID  *uint   // sürüş başlatılırken verilen veya bitişte uygulanan kampanya kodu
+	Discount     float64 `gorm:"not null"` // kampanya indirimi (Cost'tan düşülmüş)
+
+	AdjustmentTotal float64 `gorm:"not null"` // bitişten sonra yapılan iade/düzeltmelerin toplamı, Cost değiştirilmez
+
+	PaymentStatus RidePaymentStatus `gorm:"type:varchar(20);not null"`
 
 	User      modelUser.User       `gorm:"foreignKey:UserID"`
 	Motorbike modelMotor.Motorbike `gorm:"foreignKey:MotorbikeID"`
@@ -23,3 +62,44 @@ type Ride struct {
 func (Ride) TableName() string {
 	return "rides"
 }
+
+// TotalCost iade ve düzeltmeler sonrası sürüşün güncel ücreti
+func (r Ride) TotalCost() float64 {
+	return r.Cost + r.AdjustmentTotal
+}
+
+func (r RideStatus) String() string {
+	switch r {
+	case RideReserved:
+		return "reserved"
+	case RideActive:
+		return "active"
+	case RidePaused:
+		return "paused"
+	case RideAwaitingPhoto:
+		return "awaiting_photo"
+	case RideAwaitingLock:
+		return "awaiting_lock"
+	case RideFinished:
+		return "finished"
+	case RideCancelled:
+		return "cancelled"
+	case RideDisputed:
+		return "disputed"
+	default:
+		return "unknown"
+	}
+}
+
+func (r RidePaymentStatus) String() string {
+	switch r {
+	case RidePaymentPending:
+		return "pending"
+	case RidePaymentPaid:
+		return "paid"
+	case RidePaymentUnpaid:
+		return "unpaid"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RideTrackPoint sürüş sırasında uygulamanın topluca gönderdiği GPS noktası
+type RideTrackPoint struct {
+	BaseModel
+	RideID     uint      `gorm:"not null"`
+	Latitude   float64   `gorm:"not null"`
+	Longitude  float64   `gorm:"not null"`
+	RecordedAt time.Time `gorm:"not null"` // cihazda ölçüldüğü zaman
+}
+
+func (RideTrackPoint) TableName() string {
+	return "ride_track_points"
+}
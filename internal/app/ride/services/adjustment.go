@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"motorbike-rental-backend/internal/app/ride/models"
+)
+
+// AddAdjustment düzeltme kaydını yazar ve sürüşün adjustment_total değerini aynı transaction içinde günceller.
+// Sürüşün güncel ücreti eksiye düşemez.
+func (s *RideService) AddAdjustment(ctx context.Context, adjustment *models.RideAdjustment) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ride models.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", adjustment.RideID).First(&ride).Error; err != nil {
+			return err
+		}
+
+		if ride.TotalCost()+adjustment.Amount < -0.005 {
+			return ErrAdjustmentExceedsCost
+		}
+
+		if err := tx.Create(adjustment).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ride).Update("adjustment_total", gorm.Expr("adjustment_total + ?", adjustment.Amount)).Error
+	})
+}
+
+// SetAdjustmentRefund düzeltme kaydı yazıldıktan sonra ödeme sağlayıcısından iade edilen tutarı kaydeder
+func (s *RideService) SetAdjustmentRefund(ctx context.Context, id int64, refunded float64) error {
+	return s.DB.WithContext(ctx).Model(&models.RideAdjustment{}).Where("id = ?", id).Update("refunded_amount", refunded).Error
+}
+
+// RevertAdjustment iadesi hiç yapılamayan düzeltme kaydını siler ve adjustment_total değerini aynı transaction içinde geri alır
+func (s *RideService) RevertAdjustment(ctx context.Context, adjustment *models.RideAdjustment) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ride models.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", adjustment.RideID).First(&ride).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(adjustment).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ride).Update("adjustment_total", gorm.Expr("adjustment_total - ?", adjustment.Amount)).Error
+	})
+}
+
+func (s *RideService) GetAdjustments(ctx context.Context, rideID int) (*[]models.RideAdjustment, error) {
+	var adjustments []models.RideAdjustment
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("id").Find(&adjustments).Error; err != nil {
+		return nil, err
+	}
+
+	return &adjustments, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/ride/models"
+)
+
+// SyncWithLock cihazın bildirdiği kilit değişikliğine göre bitirme adımındaki sürüşü ilerletir:
+// kilit bekleyen sürüş kilitlenince fotoğraf adımına geçer, fotoğraf bekleyen sürüşün kilidi açılırsa kilit adımına döner.
+// Motorun bitirme adımında sürüşü yoksa bir şey yapılmaz.
+func (s *RideService) SyncWithLock(ctx context.Context, motorbikeID uint, locked bool) error {
+	ride, err := s.GetRideInProgressByMotorID(ctx, int(motorbikeID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	switch {
+	case locked && ride.Status == models.RideAwaitingLock:
+		return s.TransitionRide(ctx, ride, models.RideAwaitingPhoto)
+	case !locked && ride.Status == models.RideAwaitingPhoto:
+		return s.TransitionRide(ctx, ride, models.RideAwaitingLock)
+	default:
+		return nil
+	}
+}
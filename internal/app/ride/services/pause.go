@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	modelBike "motorbike-rental-backend/internal/app/motorbike/models"
+	"motorbike-rental-backend/internal/app/ride/models"
+	"time"
+)
+
+// PauseRide sürüşü molaya alır. Motor kilitli olmalıdır, sürüş ve motor satırları kilitlenerek kontrol edilir.
+func (s *RideService) PauseRide(ctx context.Context, ride *models.Ride) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ride.ID).First(&current).Error; err != nil {
+			return err
+		}
+
+		if !CanTransition(current.Status, models.RidePaused) {
+			return ErrIllegalRideTransition
+		}
+
+		var motor modelBike.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", current.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		if motor.LockStatus != modelBike.Locked {
+			return ErrBikeNotLocked
+		}
+
+		if err := tx.Model(&current).Update("status", models.RidePaused).Error; err != nil {
+			return err
+		}
+
+		pause := models.RidePause{RideID: uint(current.ID), StartedAt: time.Now().UTC()}
+		if err := tx.Create(&pause).Error; err != nil {
+			return err
+		}
+
+		ride.Status = models.RidePaused
+		return nil
+	})
+}
+
+// ResumeRide açık molayı kapatır ve sürüşü tekrar aktif yapar
+func (s *RideService) ResumeRide(ctx context.Context, ride *models.Ride) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ride.ID).First(&current).Error; err != nil {
+			return err
+		}
+
+		if current.Status != models.RidePaused {
+			return ErrRideNotPaused
+		}
+
+		if err := tx.Model(&models.RidePause{}).
+			Where("ride_id = ? AND ended_at IS NULL", current.ID).
+			Update("ended_at", time.Now().UTC()).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&current).Update("status", models.RideActive).Error; err != nil {
+			return err
+		}
+
+		ride.Status = models.RideActive
+		return nil
+	})
+}
+
+// GetBillablePauses sürüşün molalarını ücretlendirmeye hazır döner: açık mola until ile kapatılır,
+// azami süreyi aşan kısım moladan sayılmaz (tam ücretten faturalanır).
+func (s *RideService) GetBillablePauses(ctx context.Context, rideID int, until time.Time) ([]models.RidePause, error) {
+	var pauses []models.RidePause
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("started_at").Find(&pauses).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range pauses {
+		end := until
+		if pauses[i].EndedAt != nil {
+			end = *pauses[i].EndedAt
+		}
+		if s.maxPause > 0 && end.Sub(pauses[i].StartedAt) > s.maxPause {
+			end = pauses[i].StartedAt.Add(s.maxPause)
+		}
+		pauses[i].EndedAt = &end
+	}
+
+	return pauses, nil
+}
+
+// AutoResumePauses azami süreyi aşan açık molaları kapatır ve molada bekleyen sürüşleri tekrar aktif yapar.
+// Arka plan işi tarafından çağrılır, kaç mola kapatıldığını döner.
+func (s *RideService) AutoResumePauses(ctx context.Context, now time.Time) (int, error) {
+	if s.maxPause <= 0 {
+		return 0, nil
+	}
+
+	count := 0
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pauses []models.RidePause
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("ended_at IS NULL AND started_at <= ?", now.Add(-s.maxPause)).
+			Find(&pauses).Error; err != nil {
+			return err
+		}
+
+		for _, pause := range pauses {
+			endedAt := pause.StartedAt.Add(s.maxPause)
+			if err := tx.Model(&pause).Updates(map[string]interface{}{
+				"ended_at":     endedAt,
+				"auto_resumed": true,
+			}).Error; err != nil {
+				return err
+			}
+
+			// sürüş bu arada bitirme adımına geçtiyse durumuna dokunulmaz
+			if err := tx.Model(&models.Ride{}).
+				Where("id = ? AND status = ?", pause.RideID, models.RidePaused).
+				Update("status", models.RideActive).Error; err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
@@ -2,31 +2,62 @@ package services
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	modelBike "motorbike-rental-backend/internal/app/motorbike/models"
+	modelPayment "motorbike-rental-backend/internal/app/payment/models"
+	modelReservation "motorbike-rental-backend/internal/app/reservation/models"
 	"motorbike-rental-backend/internal/app/ride/models"
 	modelUser "motorbike-rental-backend/internal/app/user-and-auth/models"
 	"time"
 )
 
+var (
+	ErrBikeNotAvailable      = errors.New("motorbike is not available")
+	ErrBikeNotLocked         = errors.New("motorbike is not locked")
+	ErrRideAlreadyFinished   = errors.New("ride already finished")
+	ErrRideNotPaused         = errors.New("ride is not paused")
+	ErrAdjustmentExceedsCost = errors.New("adjustment exceeds ride cost")
+)
+
 type IRideService interface {
 	GetAllRides(ctx context.Context) (*[]models.Ride, error)
 	GetRideByID(ctx context.Context, id int) (*models.Ride, error)
 	CreateRide(ctx context.Context, ride *models.Ride) error
+	StartRide(ctx context.Context, ride *models.Ride, holdID *int64) error
+	FinishRide(ctx context.Context, ride *models.Ride) error
+	TransitionRide(ctx context.Context, ride *models.Ride, to models.RideStatus) error
+	SyncWithLock(ctx context.Context, motorbikeID uint, locked bool) error
+	SetEndPhoto(ctx context.Context, rideID int, photoURL string) error
+	PauseRide(ctx context.Context, ride *models.Ride) error
+	ResumeRide(ctx context.Context, ride *models.Ride) error
+	GetBillablePauses(ctx context.Context, rideID int, until time.Time) ([]models.RidePause, error)
+	AutoResumePauses(ctx context.Context, now time.Time) (int, error)
+	SetPaymentStatus(ctx context.Context, rideID int, status models.RidePaymentStatus) error
+	HasUnpaidRide(ctx context.Context, userID uint) (bool, error)
+	AddAdjustment(ctx context.Context, adjustment *models.RideAdjustment) error
+	SetAdjustmentRefund(ctx context.Context, id int64, refunded float64) error
+	RevertAdjustment(ctx context.Context, adjustment *models.RideAdjustment) error
+	GetAdjustments(ctx context.Context, rideID int) (*[]models.RideAdjustment, error)
+	AddTrackPoints(ctx context.Context, points []models.RideTrackPoint) error
+	GetTrackPoints(ctx context.Context, rideID int) (*[]models.RideTrackPoint, error)
 	GetRidesByUserID(ctx context.Context, userID int) (*[]models.Ride, error)
 	GetRideByUserID(ctx context.Context, userID int, rideID int) (*models.Ride, error)
 	GetRidesByBikeID(ctx context.Context, bikeID int) (*[]models.Ride, error)
+	GetRideInProgressByMotorID(ctx context.Context, motorbikeID int) (*models.Ride, error)
 	UpdateRide(ctx context.Context, ride *models.Ride) error
 	DeleteRide(ctx context.Context, id int) error
 	GetRidesByDateRange(ctx context.Context, startTime, endTime time.Time) (*[]models.Ride, error)
 }
 
 type RideService struct {
-	DB *gorm.DB
+	DB       *gorm.DB
+	maxPause time.Duration
 }
 
-func NewRideService(db *gorm.DB) IRideService {
-	return &RideService{DB: db}
+func NewRideService(db *gorm.DB, maxPause time.Duration) IRideService {
+	return &RideService{DB: db, maxPause: maxPause}
 }
 
 func (s *RideService) GetAllRides(ctx context.Context) (*[]models.Ride, error) {
@@ -51,6 +82,224 @@ func (s *RideService) CreateRide(ctx context.Context, ride *models.Ride) error {
 	return s.DB.WithContext(ctx).Create(ride).Error
 }
 
+// StartRide motoru satır kilidiyle (SELECT ... FOR UPDATE) okur, müsaitse 'rented' yapar ve sürüşü aynı transaction içinde oluşturur.
+// Böylece iki kullanıcı aynı motoru aynı anda kiralayamaz, insert hata verirse motor 'rented' durumunda kalmaz.
+// Motor aynı kullanıcı adına rezerve edilmişse rezervasyon sürüşe dönüştürülür. holdID verilmişse sürüş başlarken
+// alınan provizyon da aynı transaction içinde sürüşe bağlanır, bitişte ücret bu provizyondan tahsil edilir.
+func (s *RideService) StartRide(ctx context.Context, ride *models.Ride, holdID *int64) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var motor modelBike.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ride.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		// fotoğraf adımında bağlantısı kesilmiş ama henüz bitirilmemiş sürüş varsa motor hâlâ o kullanıcıdadır
+		var inProgress int64
+		if err := tx.Model(&models.Ride{}).
+			Where("motorbike_id = ? AND status IN ?", ride.MotorbikeID, InProgressStatuses).
+			Count(&inProgress).Error; err != nil {
+			return err
+		}
+		if inProgress > 0 {
+			return ErrBikeNotAvailable
+		}
+
+		var reservation *modelReservation.Reservation
+
+		switch motor.Status {
+		case modelBike.BikeAvailable:
+		case modelBike.BikeReserved:
+			var r modelReservation.Reservation
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("user_id = ? AND motorbike_id = ? AND status = ? AND expires_at > ?", ride.UserID, ride.MotorbikeID, modelReservation.ReservationActive, time.Now()).
+				First(&r).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBikeNotAvailable // başka bir kullanıcı adına rezerve
+			}
+			if err != nil {
+				return err
+			}
+			reservation = &r
+		default:
+			return ErrBikeNotAvailable
+		}
+
+		if err := tx.Model(&motor).Update("status", modelBike.BikeRented).Error; err != nil {
+			return err
+		}
+
+		// başlangıç noktası motorun bilinen son konumu
+		ride.StartLat = &motor.LocationLatitude
+		ride.StartLng = &motor.LocationLongitude
+
+		if err := tx.Create(ride).Error; err != nil {
+			// motor başına tek bitmemiş sürüş veritabanında da zorunlu (idx_rides_motorbike_in_progress)
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrBikeNotAvailable
+			}
+			return err
+		}
+
+		if holdID != nil {
+			if err := tx.Model(&modelPayment.Payment{}).Where("id = ?", *holdID).Update("ride_id", ride.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if reservation != nil {
+			rideID := uint(ride.ID)
+			return tx.Model(reservation).Updates(map[string]interface{}{
+				"status":  modelReservation.ReservationConverted,
+				"ride_id": rideID,
+			}).Error
+		}
+
+		return nil
+	})
+}
+
+// FinishRide sürüş ve motor satırlarını kilitleyip sürüşün bitmemiş, motorun kilitli olduğunu tekrar kontrol eder
+// ve bitiş bilgilerini (end_time, duration, cost, bitiş konumu, mesafe) tek transaction içinde yazar.
+// Bitiş konumu verilmişse motorun konumu da bu noktaya güncellenir. Motor ancak sürüş bittiğinde 'available' yapılır,
+// fotoğraf adımında bağlantı kesilse de motor başka bir kullanıcıya kiralanamaz.
+func (s *RideService) FinishRide(ctx context.Context, ride *models.Ride) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Ride
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ride.ID).First(&current).Error; err != nil {
+			return err
+		}
+
+		if current.Status == models.RideFinished {
+			return ErrRideAlreadyFinished
+		}
+		if !CanTransition(current.Status, models.RideFinished) {
+			return ErrIllegalRideTransition
+		}
+
+		var motor modelBike.Motorbike
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", current.MotorbikeID).First(&motor).Error; err != nil {
+			return err
+		}
+
+		if motor.LockStatus != modelBike.Locked {
+			return ErrBikeNotLocked
+		}
+
+		// bitişte açık kalan mola sürüşün bitişiyle kapatılır
+		if err := tx.Model(&models.RidePause{}).
+			Where("ride_id = ? AND ended_at IS NULL", current.ID).
+			Update("ended_at", ride.EndTime).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&current).Updates(map[string]interface{}{
+			"end_time":          ride.EndTime,
+			"duration":          ride.Duration,
+			"cost":              ride.Cost,
+			"status":            models.RideFinished,
+			"end_lat":           ride.EndLat,
+			"end_lng":           ride.EndLng,
+			"distance_meters":   ride.DistanceMeters,
+			"parking_surcharge": ride.ParkingSurcharge,
+			"user_pass_id":      ride.UserPassID,
+			"pass_discount":     ride.PassDiscount,
+			"promotion_id":      ride.PromotionID,
+			"discount":          ride.Discount,
+		}).Error; err != nil {
+			return err
+		}
+
+		// motorun kullanım sayaçları periyodik bakım planları için artırılır
+		motorUpdates := map[string]interface{}{
+			"total_distance_meters": gorm.Expr("total_distance_meters + ?", ride.DistanceMeters),
+			"total_ride_seconds":    gorm.Expr("total_ride_seconds + ?", int64(ride.EndTime.Sub(current.StartTime).Seconds())),
+		}
+		if motor.Status == modelBike.BikeRented {
+			motorUpdates["status"] = motor.ReleaseStatus()
+			motorUpdates["maintenance_pending"] = false
+		}
+		if ride.EndLat != nil && ride.EndLng != nil {
+			motorUpdates["location_latitude"] = *ride.EndLat
+			motorUpdates["location_longitude"] = *ride.EndLng
+		}
+		if err := tx.Model(&motor).Updates(motorUpdates).Error; err != nil {
+			return err
+		}
+
+		ride.Status = models.RideFinished
+		return nil
+	})
+}
+
+// TransitionRide geçiş tablosunu kontrol eder ve durumu yalnızca satır hâlâ eski durumdaysa günceller,
+// araya başka bir istek girdiyse ErrIllegalRideTransition döner. Bitirme adımına girişte end_step_at yazılır,
+// awaiting_lock ile awaiting_photo arasındaki geçişlerde korunur, sürüş devam ederse temizlenir.
+func (s *RideService) TransitionRide(ctx context.Context, ride *models.Ride, to models.RideStatus) error {
+	if !CanTransition(ride.Status, to) {
+		return ErrIllegalRideTransition
+	}
+
+	updates := map[string]interface{}{"status": to}
+	endStepAt := ride.EndStepAt
+	switch {
+	case isEndStep(to) && !isEndStep(ride.Status):
+		now := time.Now().UTC()
+		endStepAt = &now
+		updates["end_step_at"] = endStepAt
+	case to == models.RideActive:
+		endStepAt = nil
+		updates["end_step_at"] = nil
+	}
+
+	result := s.DB.WithContext(ctx).Model(&models.Ride{}).
+		Where("id = ? AND status = ?", ride.ID, ride.Status).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrIllegalRideTransition
+	}
+
+	ride.Status = to
+	ride.EndStepAt = endStepAt
+	return nil
+}
+
+func (s *RideService) SetEndPhoto(ctx context.Context, rideID int, photoURL string) error {
+	return s.DB.WithContext(ctx).Model(&models.Ride{}).Where("id = ?", rideID).Update("end_photo_url", photoURL).Error
+}
+
+func (s *RideService) SetPaymentStatus(ctx context.Context, rideID int, status models.RidePaymentStatus) error {
+	return s.DB.WithContext(ctx).Model(&models.Ride{}).Where("id = ?", rideID).Update("payment_status", status).Error
+}
+
+// HasUnpaidRide kullanıcının ödemesi alınamamış bitmiş bir sürüşü olup olmadığını döner
+func (s *RideService) HasUnpaidRide(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&models.Ride{}).
+		Where("user_id = ? AND payment_status = ?", userID, models.RidePaymentUnpaid).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *RideService) AddTrackPoints(ctx context.Context, points []models.RideTrackPoint) error {
+	return s.DB.WithContext(ctx).CreateInBatches(points, 100).Error
+}
+
+// GetTrackPoints sürüşün GPS noktalarını cihazda ölçüldükleri sırayla döner
+func (s *RideService) GetTrackPoints(ctx context.Context, rideID int) (*[]models.RideTrackPoint, error) {
+	var points []models.RideTrackPoint
+	if err := s.DB.WithContext(ctx).Where("ride_id = ?", rideID).Order("recorded_at, id").Find(&points).Error; err != nil {
+		return nil, err
+	}
+
+	return &points, nil
+}
+
 func (s *RideService) GetRidesByUserID(ctx context.Context, userID int) (*[]models.Ride, error) {
 	var user modelUser.User
 	var rides []models.Ride
@@ -94,8 +343,21 @@ func (s *RideService) GetRidesByBikeID(ctx context.Context, bikeID int) (*[]mode
 	return &rides, nil
 }
 
+// GetRideInProgressByMotorID motorun henüz bitmemiş sürüşünü döner, yoksa gorm.ErrRecordNotFound
+func (s *RideService) GetRideInProgressByMotorID(ctx context.Context, motorbikeID int) (*models.Ride, error) {
+	var ride models.Ride
+	if err := s.DB.WithContext(ctx).
+		Where("motorbike_id = ? AND status IN ?", motorbikeID, InProgressStatuses).
+		First(&ride).Error; err != nil {
+		return nil, err
+	}
+
+	return &ride, nil
+}
+
+// UpdateRide ücret düzeltmelerinin toplamını ezmez, ücret değişiklikleri AddAdjustment ile yapılır
 func (s *RideService) UpdateRide(ctx context.Context, ride *models.Ride) error {
-	return s.DB.WithContext(ctx).Save(ride).Error
+	return s.DB.WithContext(ctx).Omit("adjustment_total").Save(ride).Error
 }
 
 func (s *RideService) DeleteRide(ctx context.Context, id int) error {
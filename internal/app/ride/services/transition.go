@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"motorbike-rental-backend/internal/app/ride/models"
+)
+
+var ErrIllegalRideTransition = errors.New("illegal ride status transition")
+
+// Sürüş durum geçiş tablosu. Sürüş bitirme sırası: kilitle -> fotoğraf yükle (bağlantı kesilir) -> bitir.
+//
+//	reserved       -> active, cancelled
+//	active         -> paused, awaiting_lock, awaiting_photo, cancelled
+//	paused         -> active, awaiting_lock, awaiting_photo
+//	awaiting_lock  -> awaiting_photo, active
+//	awaiting_photo -> awaiting_lock, finished
+//	finished       -> disputed
+//	disputed       -> finished
+var rideTransitions = map[models.RideStatus][]models.RideStatus{
+	models.RideReserved:      {models.RideActive, models.RideCancelled},
+	models.RideActive:        {models.RidePaused, models.RideAwaitingLock, models.RideAwaitingPhoto, models.RideCancelled},
+	models.RidePaused:        {models.RideActive, models.RideAwaitingLock, models.RideAwaitingPhoto},
+	models.RideAwaitingLock:  {models.RideAwaitingPhoto, models.RideActive},
+	models.RideAwaitingPhoto: {models.RideAwaitingLock, models.RideFinished},
+	models.RideFinished:      {models.RideDisputed},
+	models.RideDisputed:      {models.RideFinished},
+}
+
+// CanTransition from durumundan to durumuna geçişin tabloda tanımlı olup olmadığını döner
+func CanTransition(from, to models.RideStatus) bool {
+	for _, next := range rideTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// InProgressStatuses motorun kullanıcıda olduğu, henüz bitmemiş sürüş durumları
+var InProgressStatuses = []models.RideStatus{models.RideActive, models.RidePaused, models.RideAwaitingLock, models.RideAwaitingPhoto}
+
+// isEndStep sürüşün bitirme adımında (kilit veya fotoğraf bekleniyor) olduğunu belirtir
+func isEndStep(status models.RideStatus) bool {
+	return status == models.RideAwaitingLock || status == models.RideAwaitingPhoto
+}
+
+// IsInProgress sürüşün henüz bitmediğini (motorun kullanıcıda olduğunu) belirtir
+func IsInProgress(status models.RideStatus) bool {
+	switch status {
+	case models.RideActive, models.RidePaused, models.RideAwaitingLock, models.RideAwaitingPhoto:
+		return true
+	default:
+		return false
+	}
+}
@@ -4,12 +4,14 @@ import (
 	modelBike "motorbike-rental-backend/internal/app/motorbike/models"
 	"motorbike-rental-backend/internal/app/ride/models"
 	modelUser "motorbike-rental-backend/internal/app/user-and-auth/models"
+	"motorbike-rental-backend/pkg/storage"
 	"time"
 )
 
 type RideCreateVM struct {
-	UserID      uint `json:"user_id" validate:"required,numeric"`
-	MotorbikeID uint `json:"motorbike_id" validate:"required,numeric"`
+	UserID      uint   `json:"user_id" validate:"required,numeric"` // handler'da giriş yapmış kullanıcıyla ezilir
+	MotorbikeID uint   `json:"motorbike_id" validate:"required,numeric"`
+	PromoCode   string `json:"promo_code"` // isteğe bağlı, bitişte bu kodun indirimi uygulanır
 }
 
 // RideCreateVM'den Ride modeline dönüştürme
@@ -20,12 +22,14 @@ func (vm *RideCreateVM) ToDBModel() models.Ride {
 	var endTime *time.Time
 
 	return models.Ride{
-		UserID:      vm.UserID,
-		MotorbikeID: vm.MotorbikeID,
-		StartTime:   now,     // StartTime, o anki zaman olacak şekilde ayarlandı
-		EndTime:     endTime, // EndTime isteğe bağlı olarak null olabilir
-		Duration:    "0 years 0 mons 0 days 0 hours 0 mins 0 secs",
-		Cost:        0,
+		UserID:        vm.UserID,
+		MotorbikeID:   vm.MotorbikeID,
+		StartTime:     now,     // StartTime, o anki zaman olacak şekilde ayarlandı
+		EndTime:       endTime, // EndTime isteğe bağlı olarak null olabilir
+		Duration:      "0 years 0 mons 0 days 0 hours 0 mins 0 secs",
+		Cost:          0,
+		Status:        models.RideActive,
+		PaymentStatus: models.RidePaymentPending,
 	}
 }
 
@@ -35,7 +39,8 @@ type RideUpdateVM struct {
 	StartTime   time.Time  `json:"start_time" validate:"required"`
 	EndTime     *time.Time `json:"end_time,omitempty"` // Zorunlu değil, boş olabilir
 	Duration    string     `json:"duration" validate:"required"`
-	Cost        float64    `json:"cost" validate:"required"`
+	Cost        *float64   `json:"cost" validate:"omitempty,gte=0"` // verilirse ve güncel ücretten farklıysa düzeltme kaydı olarak eklenir
+	Reason      string     `json:"reason"`                          // ücret düzeltmesinin gerekçesi
 }
 
 func (vm *RideUpdateVM) ToDBModel(m models.Ride) models.Ride {
@@ -43,33 +48,86 @@ func (vm *RideUpdateVM) ToDBModel(m models.Ride) models.Ride {
 	m.MotorbikeID = vm.MotorbikeID
 	m.EndTime = vm.EndTime
 	m.Duration = vm.Duration
-	m.Cost = vm.Cost
 
 	return m
 }
 
 type RideDetailVM struct {
-	ID          uint                `json:"id"`
-	UserID      uint                `json:"user_id"`
-	MotorbikeID uint                `json:"motorbike_id"`
-	StartTime   time.Time           `json:"start_time"`
-	EndTime     *time.Time          `json:"end_time"`
-	Duration    string              `json:"duration"`
-	Cost        float64             `json:"cost"`
-	User        modelUser.User      `json:"user"`
-	Motorbike   modelBike.Motorbike `json:"bike"`
+	ID            uint                `json:"id"`
+	UserID        uint                `json:"user_id"`
+	MotorbikeID   uint                `json:"motorbike_id"`
+	StartTime     time.Time           `json:"start_time"`
+	EndTime       *time.Time          `json:"end_time"`
+	Duration      string              `json:"duration"`
+	Cost          float64             `json:"cost"`
+	Status        string              `json:"status"`
+	EndPhotoURL   string              `json:"end_photo_url"`
+	StartLat      *float64            `json:"start_lat"`
+	StartLng      *float64            `json:"start_lng"`
+	EndLat        *float64            `json:"end_lat"`
+	EndLng        *float64            `json:"end_lng"`
+	Distance      float64             `json:"distance_m"`
+	Surcharge     float64             `json:"parking_surcharge"`
+	UserPassID    *uint               `json:"user_pass_id"`
+	PassDiscount  float64             `json:"pass_discount"`
+	PromotionID   *uint               `json:"promotion_id"`
+	Discount      float64             `json:"discount"`
+	Adjustments   float64             `json:"adjustments"` // iade ve düzeltmelerin toplamı
+	TotalCost     float64             `json:"total_cost"`  // cost + adjustments
+	PaymentStatus string              `json:"payment_status"`
+	User          modelUser.User      `json:"user"`
+	Motorbike     modelBike.Motorbike `json:"bike"`
 }
 
 func (vm *RideDetailVM) ToViewModel(ride models.Ride) RideDetailVM {
 	return RideDetailVM{
-		ID:          uint(ride.ID),
-		UserID:      ride.UserID,
-		MotorbikeID: ride.MotorbikeID,
-		StartTime:   ride.StartTime,
-		EndTime:     ride.EndTime,
-		Duration:    ride.Duration,
-		Cost:        ride.Cost,
-		User:        ride.User,
-		Motorbike:   ride.Motorbike,
+		ID:            uint(ride.ID),
+		UserID:        ride.UserID,
+		MotorbikeID:   ride.MotorbikeID,
+		StartTime:     ride.StartTime,
+		EndTime:       ride.EndTime,
+		Duration:      ride.Duration,
+		Cost:          ride.Cost,
+		Status:        ride.Status.String(),
+		EndPhotoURL:   storage.URL(ride.EndPhotoURL),
+		StartLat:      ride.StartLat,
+		StartLng:      ride.StartLng,
+		EndLat:        ride.EndLat,
+		EndLng:        ride.EndLng,
+		Distance:      ride.DistanceMeters,
+		Surcharge:     ride.ParkingSurcharge,
+		UserPassID:    ride.UserPassID,
+		PassDiscount:  ride.PassDiscount,
+		PromotionID:   ride.PromotionID,
+		Discount:      ride.Discount,
+		Adjustments:   ride.AdjustmentTotal,
+		TotalCost:     ride.TotalCost(),
+		PaymentStatus: ride.PaymentStatus.String(),
+		User:          ride.User,
+		Motorbike:     ride.Motorbike,
 	}
 }
+
+// Sürüş ücret düzeltmeleri için view model
+type RideAdjustmentVM struct {
+	ID             int64     `json:"id"`
+	Type           string    `json:"type"`
+	Amount         float64   `json:"amount"`
+	RefundedAmount float64   `json:"refunded_amount"`
+	Reason         string    `json:"reason"`
+	DisputeID      *uint     `json:"dispute_id"`
+	CreatedBy      uint      `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (vm RideAdjustmentVM) ToViewModel(m models.RideAdjustment) RideAdjustmentVM {
+	vm.ID = m.ID
+	vm.Type = m.Type.String()
+	vm.Amount = m.Amount
+	vm.RefundedAmount = m.RefundedAmount
+	vm.Reason = m.Reason
+	vm.DisputeID = m.DisputeID
+	vm.CreatedBy = m.CreatedBy
+	vm.CreatedAt = m.CreatedAt
+	return vm
+}
@@ -0,0 +1,30 @@
+package viewmodels
+
+import (
+	"motorbike-rental-backend/internal/app/ride/models"
+	"time"
+)
+
+type TrackPointVM struct {
+	Latitude   float64   `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude  float64   `json:"longitude" validate:"gte=-180,lte=180"`
+	RecordedAt time.Time `json:"recorded_at" validate:"required"`
+}
+
+// Uygulama sürüş boyunca noktaları toplu halde gönderir
+type RideTrackCreateVM struct {
+	Points []TrackPointVM `json:"points" validate:"required,min=1,max=500,dive"`
+}
+
+func (vm RideTrackCreateVM) ToDBModels(rideID uint) []models.RideTrackPoint {
+	var points []models.RideTrackPoint
+	for _, p := range vm.Points {
+		points = append(points, models.RideTrackPoint{
+			RideID:     rideID,
+			Latitude:   p.Latitude,
+			Longitude:  p.Longitude,
+			RecordedAt: p.RecordedAt.UTC(),
+		})
+	}
+	return points
+}
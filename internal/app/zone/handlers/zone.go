@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	zoneService "motorbike-rental-backend/internal/app/zone/services"
+	"motorbike-rental-backend/internal/app/zone/viewmodels"
+	"motorbike-rental-backend/pkg/app"
+	"motorbike-rental-backend/pkg/errorsx"
+	"motorbike-rental-backend/pkg/geo"
+	"motorbike-rental-backend/pkg/utils"
+)
+
+type ZoneHandler struct {
+	zoneService zoneService.IZoneService
+}
+
+func NewZoneHandler(s zoneService.IZoneService) ZoneHandler {
+	return ZoneHandler{zoneService: s}
+}
+
+func (h ZoneHandler) GetAllZones(ctx *app.Ctx) error {
+	zones, err := h.zoneService.GetAllZones(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Bölgeler getirilemedi!")
+	}
+
+	var zoneDetails []viewmodels.ZoneDetailVM
+	for _, zone := range *zones {
+		zoneDetails = append(zoneDetails, viewmodels.ZoneDetailVM{}.ToViewModel(zone))
+	}
+
+	return ctx.SuccessResponse(zoneDetails, len(zoneDetails))
+}
+
+// aktif bölgeleri harita için GeoJSON FeatureCollection olarak döner -> /zones/geojson
+func (h ZoneHandler) GetZonesGeoJSON(ctx *app.Ctx) error {
+	zones, err := h.zoneService.GetActiveZones(ctx.Context())
+	if err != nil {
+		return errorsx.InternalError(err, "Bölgeler getirilemedi!")
+	}
+
+	var features []geo.Feature
+	for _, zone := range *zones {
+		features = append(features, viewmodels.ToFeature(zone))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(geo.NewFeatureCollection(features))
+}
+
+func (h ZoneHandler) GetZoneByID(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	zone, err := h.zoneService.GetZoneByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Bölge bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Bölge getirilirken hata oluştu!")
+	}
+
+	return ctx.SuccessResponse(viewmodels.ZoneDetailVM{}.ToViewModel(*zone), 1)
+}
+
+func (h ZoneHandler) CreateZone(ctx *app.Ctx) error {
+	var vm viewmodels.ZoneCreateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	if err := vm.Geometry.Validate(); err != nil {
+		return errorsx.BadRequestError("Geçersiz GeoJSON poligon!")
+	}
+
+	zone, err := vm.ToDBModel()
+	if err != nil {
+		return errorsx.InternalError(err, "Bölge oluşturulurken hata oluştu!")
+	}
+
+	if err = h.zoneService.CreateZone(ctx.Context(), &zone); err != nil {
+		return errorsx.InternalError(err, "Bölge oluşturulurken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"info": "Bölge eklendi!", "id": zone.ID})
+}
+
+func (h ZoneHandler) UpdateZone(ctx *app.Ctx) error {
+	var vm viewmodels.ZoneUpdateVM
+	if err := ctx.BodyParseValidate(&vm); err != nil {
+		return errorsx.ValidationError(err)
+	}
+
+	if err := vm.Geometry.Validate(); err != nil {
+		return errorsx.BadRequestError("Geçersiz GeoJSON poligon!")
+	}
+
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	zone, err := h.zoneService.GetZoneByID(ctx.Context(), id)
+	if err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Bölge bulunamadı!")
+		}
+		return errorsx.InternalError(err, "Bölge getirilirken hata oluştu!")
+	}
+
+	updatedZone, err := vm.ToDBModel(*zone)
+	if err != nil {
+		return errorsx.InternalError(err, "Bölge güncellenirken hata oluştu!")
+	}
+
+	if err = h.zoneService.UpdateZone(ctx.Context(), &updatedZone); err != nil {
+		return errorsx.InternalError(err, "Bölge güncellenirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bölge güncellendi!"})
+}
+
+func (h ZoneHandler) DeleteZone(ctx *app.Ctx) error {
+	id, err := utils.GetMyParamInt(ctx, "id")
+	if err != nil {
+		return errorsx.BadRequestError("Hatalı istek!")
+	}
+
+	if err = h.zoneService.DeleteZone(ctx.Context(), id); err != nil {
+		if errorsx.Is(err, gorm.ErrRecordNotFound) {
+			return errorsx.NotFoundError("Böyle bir bölge zaten yok!")
+		}
+		return errorsx.InternalError(err, "Bölge silinirken hata oluştu!")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"info": "Bölge silindi!"})
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"motorbike-rental-backend/pkg/geo"
+)
+
+type ZoneType string
+
+const (
+	ZoneOperatingArea    ZoneType = "operating_area"    // sürüşün bitirilebileceği hizmet alanı
+	ZoneNoParking        ZoneType = "no_parking"        // park yasak, Surcharge > 0 ise ek ücretle izin verilir
+	ZoneSlowZone         ZoneType = "slow_zone"         // hız sınırlı bölge
+	ZonePreferredParking ZoneType = "preferred_parking" // önerilen park noktası
+)
+
+// Zone poligon olarak tanımlanan bölge. Geometri GeoJSON olarak saklanır, PostGIS gerekmez;
+// sorgularda bbox kolonlarıyla ön filtreleme yapılır, nokta-poligon kontrolü uygulamada yapılır.
+type Zone struct {
+	BaseModel
+	Name          string   `gorm:"type:varchar(100);not null"`
+	Type          ZoneType `gorm:"type:varchar(30);not null"`
+	Geometry      string   `gorm:"type:jsonb;not null"` // GeoJSON Polygon
+	MinLat        float64  `gorm:"not null"`
+	MinLng        float64  `gorm:"not null"`
+	MaxLat        float64  `gorm:"not null"`
+	MaxLng        float64  `gorm:"not null"`
+	Surcharge     float64  `gorm:"not null"` // no_parking için ek ücret (TL), 0 ise sürüş bitirilemez
+	SpeedLimitKmh int      `gorm:"not null"` // slow_zone için hız sınırı, 0 ise yok
+	IsActive      bool     `gorm:"not null"`
+}
+
+func (Zone) TableName() string {
+	return "zones"
+}
+
+// SetPolygon geometriyi GeoJSON olarak yazar ve bbox kolonlarını günceller
+func (z *Zone) SetPolygon(p geo.Polygon) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	box := p.BBox()
+	z.Geometry = string(data)
+	z.MinLat, z.MinLng, z.MaxLat, z.MaxLng = box.MinLat, box.MinLng, box.MaxLat, box.MaxLng
+	return nil
+}
+
+func (z Zone) Polygon() (geo.Polygon, error) {
+	var p geo.Polygon
+	err := json.Unmarshal([]byte(z.Geometry), &p)
+	return p, err
+}
+
+func (t ZoneType) String() string {
+	switch t {
+	case ZoneOperatingArea:
+		return "operating_area"
+	case ZoneNoParking:
+		return "no_parking"
+	case ZoneSlowZone:
+		return "slow_zone"
+	case ZonePreferredParking:
+		return "preferred_parking"
+	default:
+		return "unknown"
+	}
+}
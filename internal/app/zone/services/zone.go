@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"motorbike-rental-backend/internal/app/zone/models"
+	"motorbike-rental-backend/pkg/geo"
+)
+
+var (
+	ErrOutsideOperatingArea = errors.New("point is outside of operating areas")
+	ErrNoParkingZone        = errors.New("point is inside a no-parking zone")
+)
+
+// ParkingCheck sürüşün verilen noktada bitirilmesinin sonucudur
+type ParkingCheck struct {
+	Surcharge float64      // park yasağı olan ama ek ücretle izin verilen bölgelerin en yükseği
+	Zone      *models.Zone // ek ücreti belirleyen bölge
+}
+
+type IZoneService interface {
+	GetAllZones(ctx context.Context) (*[]models.Zone, error)
+	GetActiveZones(ctx context.Context) (*[]models.Zone, error)
+	GetZoneByID(ctx context.Context, id int) (*models.Zone, error)
+	CreateZone(ctx context.Context, zone *models.Zone) error
+	UpdateZone(ctx context.Context, zone *models.Zone) error
+	DeleteZone(ctx context.Context, id int) error
+	GetZonesAt(ctx context.Context, lat, lng float64) ([]models.Zone, error)
+	CheckParking(ctx context.Context, lat, lng float64) (*ParkingCheck, error)
+}
+
+type ZoneService struct {
+	DB *gorm.DB
+}
+
+func NewZoneService(db *gorm.DB) IZoneService {
+	return &ZoneService{DB: db}
+}
+
+func (s *ZoneService) GetAllZones(ctx context.Context) (*[]models.Zone, error) {
+	var zones []models.Zone
+	if err := s.DB.WithContext(ctx).Order("id").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+
+	return &zones, nil
+}
+
+func (s *ZoneService) GetActiveZones(ctx context.Context) (*[]models.Zone, error) {
+	var zones []models.Zone
+	if err := s.DB.WithContext(ctx).Where("is_active = ?", true).Order("id").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+
+	return &zones, nil
+}
+
+func (s *ZoneService) GetZoneByID(ctx context.Context, id int) (*models.Zone, error) {
+	var zone models.Zone
+	if err := s.DB.WithContext(ctx).Where("id = ?", id).First(&zone).Error; err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+func (s *ZoneService) CreateZone(ctx context.Context, zone *models.Zone) error {
+	return s.DB.WithContext(ctx).Create(zone).Error
+}
+
+func (s *ZoneService) UpdateZone(ctx context.Context, zone *models.Zone) error {
+	return s.DB.WithContext(ctx).Save(zone).Error
+}
+
+func (s *ZoneService) DeleteZone(ctx context.Context, id int) error {
+	var zone models.Zone
+	if err := s.DB.WithContext(ctx).First(&zone, id).Error; err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Delete(&zone).Error
+}
+
+// GetZonesAt noktayı içeren aktif bölgeleri döner. Önce bbox ile veritabanında aday bölgeler seçilir,
+// ardından poligon kontrolü yapılır.
+func (s *ZoneService) GetZonesAt(ctx context.Context, lat, lng float64) ([]models.Zone, error) {
+	var candidates []models.Zone
+	if err := s.DB.WithContext(ctx).
+		Where("is_active = ? AND min_lat <= ? AND max_lat >= ? AND min_lng <= ? AND max_lng >= ?", true, lat, lat, lng, lng).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	point := geo.Point{Lat: lat, Lng: lng}
+	var zones []models.Zone
+	for _, zone := range candidates {
+		polygon, err := zone.Polygon()
+		if err != nil {
+			return nil, err
+		}
+		if polygon.Contains(point) {
+			zones = append(zones, zone)
+		}
+	}
+
+	return zones, nil
+}
+
+// CheckParking sürüşün verilen noktada bitirilip bitirilemeyeceğini kontrol eder:
+// hiç hizmet alanı tanımlı değilse her yer hizmet alanı sayılır, tanımlıysa nokta birinin içinde olmalıdır.
+// Park yasağı olan bölgelerden biri ek ücretsizse sürüş bitirilemez, hepsi ek ücretliyse en yüksek ek ücret uygulanır.
+func (s *ZoneService) CheckParking(ctx context.Context, lat, lng float64) (*ParkingCheck, error) {
+	zones, err := s.GetZonesAt(ctx, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+
+	var operatingAreas int64
+	if err = s.DB.WithContext(ctx).Model(&models.Zone{}).
+		Where("is_active = ? AND type = ?", true, models.ZoneOperatingArea).
+		Count(&operatingAreas).Error; err != nil {
+		return nil, err
+	}
+
+	result := &ParkingCheck{}
+	inOperatingArea := operatingAreas == 0
+
+	for i, zone := range zones {
+		switch zone.Type {
+		case models.ZoneOperatingArea:
+			inOperatingArea = true
+		case models.ZoneNoParking:
+			if zone.Surcharge <= 0 {
+				return nil, ErrNoParkingZone
+			}
+			if zone.Surcharge > result.Surcharge {
+				result.Surcharge = zone.Surcharge
+				result.Zone = &zones[i]
+			}
+		}
+	}
+
+	if !inOperatingArea {
+		return nil, ErrOutsideOperatingArea
+	}
+
+	return result, nil
+}
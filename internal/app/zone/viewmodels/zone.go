@@ -0,0 +1,87 @@
+package viewmodels
+
+import (
+	"encoding/json"
+	"motorbike-rental-backend/internal/app/zone/models"
+	"motorbike-rental-backend/pkg/geo"
+	"time"
+)
+
+// Bölge oluşturma için view model, geometry alanı GeoJSON Polygon olarak gönderilir
+type ZoneCreateVM struct {
+	Name          string      `json:"name" validate:"required,max=100"`
+	Type          string      `json:"type" validate:"required,oneof=operating_area no_parking slow_zone preferred_parking"`
+	Geometry      geo.Polygon `json:"geometry" validate:"required"`
+	Surcharge     float64     `json:"surcharge" validate:"gte=0"`
+	SpeedLimitKmh int         `json:"speed_limit_kmh" validate:"gte=0"`
+	IsActive      bool        `json:"is_active"`
+}
+
+func (vm ZoneCreateVM) ToDBModel() (models.Zone, error) {
+	zone := models.Zone{
+		Name:          vm.Name,
+		Type:          models.ZoneType(vm.Type),
+		Surcharge:     vm.Surcharge,
+		SpeedLimitKmh: vm.SpeedLimitKmh,
+		IsActive:      vm.IsActive,
+	}
+	err := zone.SetPolygon(vm.Geometry)
+	return zone, err
+}
+
+// Bölge güncelleme için view model
+type ZoneUpdateVM struct {
+	Name          string      `json:"name" validate:"required,max=100"`
+	Type          string      `json:"type" validate:"required,oneof=operating_area no_parking slow_zone preferred_parking"`
+	Geometry      geo.Polygon `json:"geometry" validate:"required"`
+	Surcharge     float64     `json:"surcharge" validate:"gte=0"`
+	SpeedLimitKmh int         `json:"speed_limit_kmh" validate:"gte=0"`
+	IsActive      bool        `json:"is_active"`
+}
+
+func (vm ZoneUpdateVM) ToDBModel(m models.Zone) (models.Zone, error) {
+	m.Name = vm.Name
+	m.Type = models.ZoneType(vm.Type)
+	m.Surcharge = vm.Surcharge
+	m.SpeedLimitKmh = vm.SpeedLimitKmh
+	m.IsActive = vm.IsActive
+	err := m.SetPolygon(vm.Geometry)
+	return m, err
+}
+
+// Bölge detayları için view model, geometry GeoJSON olarak aynen döner
+type ZoneDetailVM struct {
+	ID            int64           `json:"id"`
+	Name          string          `json:"name"`
+	Type          string          `json:"type"`
+	Geometry      json.RawMessage `json:"geometry"`
+	Surcharge     float64         `json:"surcharge"`
+	SpeedLimitKmh int             `json:"speed_limit_kmh"`
+	IsActive      bool            `json:"is_active"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func (vm ZoneDetailVM) ToViewModel(m models.Zone) ZoneDetailVM {
+	vm.ID = m.ID
+	vm.Name = m.Name
+	vm.Type = m.Type.String()
+	vm.Geometry = json.RawMessage(m.Geometry)
+	vm.Surcharge = m.Surcharge
+	vm.SpeedLimitKmh = m.SpeedLimitKmh
+	vm.IsActive = m.IsActive
+	vm.CreatedAt = m.CreatedAt
+	vm.UpdatedAt = m.UpdatedAt
+	return vm
+}
+
+// ToFeature bölgeyi harita istemcileri için GeoJSON Feature olarak döner
+func ToFeature(m models.Zone) geo.Feature {
+	return geo.NewFeature(json.RawMessage(m.Geometry), map[string]interface{}{
+		"id":              m.ID,
+		"name":            m.Name,
+		"type":            m.Type.String(),
+		"surcharge":       m.Surcharge,
+		"speed_limit_kmh": m.SpeedLimitKmh,
+	})
+}
@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS tariff_multipliers;
+
+DROP TABLE IF EXISTS tariffs;
@@ -0,0 +1,43 @@
+-- Add up migration script here
+
+-- Tariffs Table (motorbike_model boş ise varsayılan tarife)
+CREATE TABLE IF NOT EXISTS tariffs (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    motorbike_model VARCHAR(100) NOT NULL DEFAULT '',
+    unlock_fee NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    per_minute_rate NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    minimum_charge NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    daily_cap NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_tariffs_motorbike_model ON tariffs(motorbike_model);
+
+-- Tariff_multipliers Table (saat aralığı / hafta sonu çarpanları)
+CREATE TABLE IF NOT EXISTS tariff_multipliers (
+    id SERIAL PRIMARY KEY,
+    tariff_id INT NOT NULL REFERENCES tariffs(id) ON DELETE CASCADE,
+    day_type VARCHAR(10) NOT NULL CHECK (day_type IN ('all', 'weekday', 'weekend')),
+    start_hour INT NOT NULL CHECK (start_hour BETWEEN 0 AND 23),
+    end_hour INT NOT NULL CHECK (end_hour BETWEEN 0 AND 24),
+    multiplier NUMERIC(6, 3) NOT NULL CHECK (multiplier > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_tariff_multipliers_tariff_id ON tariff_multipliers(tariff_id);
+
+-- Insert default tariff (FinishRide'daki eski sabit ücret: 10 TL açılış + dakikası 3 TL)
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM tariffs WHERE motorbike_model = '') THEN
+        INSERT INTO tariffs (name, motorbike_model, unlock_fee, per_minute_rate, is_active)
+        VALUES ('Varsayılan Tarife', '', 10, 3, TRUE);
+END IF;
+END
+$$;
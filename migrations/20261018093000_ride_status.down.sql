@@ -0,0 +1,7 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_rides_status;
+
+ALTER TABLE rides
+    DROP COLUMN IF EXISTS end_photo_url,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- Add up migration script here
+
+ALTER TABLE rides
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('reserved', 'active', 'paused', 'awaiting_photo', 'awaiting_lock', 'finished', 'cancelled', 'disputed')),
+    ADD COLUMN IF NOT EXISTS end_photo_url VARCHAR(255);
+
+-- Bitiş zamanı olan eski sürüşler tamamlanmış sayılır
+UPDATE rides SET status = 'finished' WHERE end_time IS NOT NULL;
+
+CREATE INDEX idx_rides_status ON rides(status);
@@ -0,0 +1,9 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS reservations;
+
+UPDATE motorbike SET status = 'available' WHERE status = 'reserved';
+
+ALTER TABLE motorbike DROP CONSTRAINT IF EXISTS motorbike_status_check;
+ALTER TABLE motorbike ADD CONSTRAINT motorbike_status_check
+    CHECK (status IN ('available', 'maintenance', 'rented'));
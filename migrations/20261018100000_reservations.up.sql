@@ -0,0 +1,24 @@
+-- Add up migration script here
+
+-- Motorbike status'a 'reserved' eklendi
+ALTER TABLE motorbike DROP CONSTRAINT IF EXISTS motorbike_status_check;
+ALTER TABLE motorbike ADD CONSTRAINT motorbike_status_check
+    CHECK (status IN ('available', 'maintenance', 'rented', 'reserved'));
+
+-- Reservations Table
+CREATE TABLE IF NOT EXISTS reservations (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('active', 'converted', 'cancelled', 'expired')),
+    ride_id INT REFERENCES rides(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_reservations_user_id ON reservations(user_id);
+CREATE INDEX idx_reservations_motorbike_id ON reservations(motorbike_id);
+-- süresi dolan rezervasyonları tarayan iş için
+CREATE INDEX idx_reservations_active_expires_at ON reservations(expires_at) WHERE status = 'active';
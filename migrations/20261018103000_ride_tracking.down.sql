@@ -0,0 +1,10 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS ride_track_points;
+
+ALTER TABLE rides
+    DROP COLUMN IF EXISTS distance_meters,
+    DROP COLUMN IF EXISTS end_lng,
+    DROP COLUMN IF EXISTS end_lat,
+    DROP COLUMN IF EXISTS start_lng,
+    DROP COLUMN IF EXISTS start_lat;
@@ -0,0 +1,22 @@
+-- Add up migration script here
+
+ALTER TABLE rides
+    ADD COLUMN IF NOT EXISTS start_lat DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS start_lng DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS end_lat DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS end_lng DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS distance_meters DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Ride Track Points Table
+CREATE TABLE IF NOT EXISTS ride_track_points (
+    id SERIAL PRIMARY KEY,
+    ride_id INT NOT NULL REFERENCES rides(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION NOT NULL CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+    recorded_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_ride_track_points_ride_id_recorded_at ON ride_track_points(ride_id, recorded_at);
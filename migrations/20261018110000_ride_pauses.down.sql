@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS ride_pauses;
+
+ALTER TABLE tariffs DROP COLUMN IF EXISTS paused_rate;
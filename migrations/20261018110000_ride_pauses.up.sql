@@ -0,0 +1,22 @@
+-- Add up migration script here
+
+ALTER TABLE tariffs ADD COLUMN IF NOT EXISTS paused_rate DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Varsayılan tarifede mola dakikası normal dakikanın üçte biri
+UPDATE tariffs SET paused_rate = 1 WHERE motorbike_model = '' AND paused_rate = 0;
+
+-- Ride Pauses Table
+CREATE TABLE IF NOT EXISTS ride_pauses (
+    id SERIAL PRIMARY KEY,
+    ride_id INT NOT NULL REFERENCES rides(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    auto_resumed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_ride_pauses_ride_id ON ride_pauses(ride_id);
+-- açık molaları tarayan iş için
+CREATE INDEX idx_ride_pauses_open_started_at ON ride_pauses(started_at) WHERE ended_at IS NULL;
@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+ALTER TABLE rides DROP COLUMN IF EXISTS parking_surcharge;
+
+DROP TABLE IF EXISTS zones;
@@ -0,0 +1,23 @@
+-- Add up migration script here
+
+-- Zones Table (PostGIS gerekmez: geometri GeoJSON olarak saklanır, bbox kolonları ön filtre içindir)
+CREATE TABLE IF NOT EXISTS zones (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('operating_area', 'no_parking', 'slow_zone', 'preferred_parking')),
+    geometry JSONB NOT NULL,
+    min_lat DOUBLE PRECISION NOT NULL,
+    min_lng DOUBLE PRECISION NOT NULL,
+    max_lat DOUBLE PRECISION NOT NULL,
+    max_lng DOUBLE PRECISION NOT NULL,
+    surcharge DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (surcharge >= 0),
+    speed_limit_kmh INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_zones_bbox ON zones(min_lat, max_lat, min_lng, max_lng) WHERE is_active;
+
+ALTER TABLE rides ADD COLUMN IF NOT EXISTS parking_surcharge DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_motorbike_status_grid_cell;
+
+ALTER TABLE motorbike DROP COLUMN IF EXISTS grid_cell;
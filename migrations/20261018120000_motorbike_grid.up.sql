@@ -0,0 +1,9 @@
+-- Add up migration script here
+
+-- Yakındaki motor sorguları için 0.01 derecelik (~1.1 km) grid hücresi, formül pkg/geo/grid.go ile aynıdır
+ALTER TABLE motorbike
+    ADD COLUMN IF NOT EXISTS grid_cell BIGINT GENERATED ALWAYS AS (
+        ((floor(location_latitude * 100) + 9000) * 100000 + (floor(location_longitude * 100) + 18000))::BIGINT
+    ) STORED;
+
+CREATE INDEX idx_motorbike_status_grid_cell ON motorbike(status, grid_cell);
@@ -0,0 +1,9 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_rides_unpaid_user_id;
+ALTER TABLE rides DROP COLUMN IF EXISTS payment_status;
+
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS wallet_holds;
+DROP TABLE IF EXISTS wallet_entries;
+DROP TABLE IF EXISTS wallets;
@@ -0,0 +1,73 @@
+-- Add up migration script here
+
+-- Wallets Table
+CREATE TABLE IF NOT EXISTS wallets (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    balance DOUBLE PRECISION NOT NULL DEFAULT 0,
+    held DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (held >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- Wallet Entries Table (hareket defteri)
+CREATE TABLE IF NOT EXISTS wallet_entries (
+    id SERIAL PRIMARY KEY,
+    wallet_id INT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('topup', 'hold', 'release', 'capture', 'refund')),
+    amount DOUBLE PRECISION NOT NULL,
+    balance_after DOUBLE PRECISION NOT NULL,
+    held_after DOUBLE PRECISION NOT NULL,
+    reference VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_wallet_entries_wallet_id ON wallet_entries(wallet_id);
+
+-- Wallet Holds Table (cüzdan provizyonları)
+CREATE TABLE IF NOT EXISTS wallet_holds (
+    id SERIAL PRIMARY KEY,
+    wallet_id INT NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    amount DOUBLE PRECISION NOT NULL,
+    captured_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    refunded_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('authorized', 'captured', 'voided')),
+    reference VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_wallet_holds_wallet_id ON wallet_holds(wallet_id);
+
+-- Payments Table
+CREATE TABLE IF NOT EXISTS payments (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ride_id INT REFERENCES rides(id) ON DELETE SET NULL,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('ride', 'topup')),
+    provider VARCHAR(20) NOT NULL,
+    authorization_id VARCHAR(64),
+    amount DOUBLE PRECISION NOT NULL,
+    captured_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    refunded_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('authorized', 'captured', 'failed', 'voided', 'refunded')),
+    failure_reason VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_payments_user_id ON payments(user_id);
+CREATE INDEX idx_payments_ride_id ON payments(ride_id);
+
+ALTER TABLE rides ADD COLUMN IF NOT EXISTS payment_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (payment_status IN ('pending', 'paid', 'unpaid'));
+
+-- Ödeme sisteminden önce bitmiş sürüşler ödenmiş sayılır
+UPDATE rides SET payment_status = 'paid' WHERE status = 'finished';
+
+CREATE INDEX idx_rides_unpaid_user_id ON rides(user_id) WHERE payment_status = 'unpaid';
@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
@@ -0,0 +1,48 @@
+-- Add up migration script here
+
+-- Invoice Sequences Table (yıl bazında boşluksuz fiş numarası)
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    year INT PRIMARY KEY,
+    last_number INT NOT NULL DEFAULT 0
+);
+
+-- Invoices Table
+CREATE TABLE IF NOT EXISTS invoices (
+    id SERIAL PRIMARY KEY,
+    number VARCHAR(20) NOT NULL UNIQUE,
+    year INT NOT NULL,
+    sequence INT NOT NULL,
+    ride_id INT NOT NULL UNIQUE REFERENCES rides(id) ON DELETE RESTRICT,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    issued_at TIMESTAMPTZ NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    duration_seconds INT NOT NULL,
+    distance_meters DOUBLE PRECISION NOT NULL DEFAULT 0,
+    subtotal DOUBLE PRECISION NOT NULL,
+    vat_rate DOUBLE PRECISION NOT NULL,
+    vat_amount DOUBLE PRECISION NOT NULL,
+    total DOUBLE PRECISION NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'TRY',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    UNIQUE (year, sequence)
+);
+
+CREATE INDEX idx_invoices_user_id ON invoices(user_id);
+
+-- Invoice Lines Table
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id SERIAL PRIMARY KEY,
+    invoice_id INT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    description VARCHAR(255) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    unit_price DOUBLE PRECISION NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_invoice_lines_invoice_id ON invoice_lines(invoice_id);
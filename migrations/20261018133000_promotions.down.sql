@@ -0,0 +1,8 @@
+-- Add down migration script here
+
+ALTER TABLE rides DROP COLUMN IF EXISTS discount;
+ALTER TABLE rides DROP COLUMN IF EXISTS promotion_id;
+
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS user_promotions;
+DROP TABLE IF EXISTS promotions;
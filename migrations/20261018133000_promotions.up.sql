@@ -0,0 +1,49 @@
+-- Add up migration script here
+
+-- Promotions Table (kampanya kodları)
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255),
+    type VARCHAR(30) NOT NULL CHECK (type IN ('percentage', 'fixed_amount', 'free_minutes', 'first_ride_free')),
+    value DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (value >= 0),
+    max_discount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    max_uses INT NOT NULL DEFAULT 0,
+    max_uses_per_user INT NOT NULL DEFAULT 0,
+    used_count INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- User Promotions Table (kullanıcının hesabına eklediği kodlar)
+CREATE TABLE IF NOT EXISTS user_promotions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    promotion_id INT NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_user_promotions_user_promotion ON user_promotions(user_id, promotion_id);
+
+-- Promotion Redemptions Table (kodun kullanıldığı sürüşler)
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    id SERIAL PRIMARY KEY,
+    promotion_id INT NOT NULL REFERENCES promotions(id) ON DELETE RESTRICT,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ride_id INT NOT NULL UNIQUE REFERENCES rides(id) ON DELETE CASCADE,
+    discount DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_promotion_redemptions_promotion_user ON promotion_redemptions(promotion_id, user_id);
+
+ALTER TABLE rides ADD COLUMN promotion_id INT REFERENCES promotions(id) ON DELETE SET NULL;
+ALTER TABLE rides ADD COLUMN discount DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Add down migration script here
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_purpose_check;
+ALTER TABLE payments ADD CONSTRAINT payments_purpose_check CHECK (purpose IN ('ride', 'topup'));
+
+ALTER TABLE rides DROP COLUMN IF EXISTS pass_discount;
+ALTER TABLE rides DROP COLUMN IF EXISTS user_pass_id;
+
+DROP TABLE IF EXISTS user_passes;
+DROP TABLE IF EXISTS pass_products;
@@ -0,0 +1,40 @@
+-- Add up migration script here
+
+-- Pass Products Table (abonelik paketleri)
+CREATE TABLE IF NOT EXISTS pass_products (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    duration_days INT NOT NULL CHECK (duration_days > 0),
+    price DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (price >= 0),
+    free_unlock BOOLEAN NOT NULL DEFAULT FALSE,
+    free_minutes_per_ride INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- User Passes Table (kullanıcıların satın aldığı paketler)
+CREATE TABLE IF NOT EXISTS user_passes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pass_product_id INT NOT NULL REFERENCES pass_products(id) ON DELETE RESTRICT,
+    starts_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    price_paid DOUBLE PRECISION NOT NULL,
+    payment_id INT REFERENCES payments(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    CHECK (expires_at > starts_at)
+);
+
+CREATE INDEX idx_user_passes_user_expires ON user_passes(user_id, expires_at);
+CREATE INDEX idx_user_passes_product_id ON user_passes(pass_product_id);
+
+ALTER TABLE rides ADD COLUMN user_pass_id INT REFERENCES user_passes(id) ON DELETE SET NULL;
+ALTER TABLE rides ADD COLUMN pass_discount DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_purpose_check;
+ALTER TABLE payments ADD CONSTRAINT payments_purpose_check CHECK (purpose IN ('ride', 'topup', 'pass'));
@@ -0,0 +1,6 @@
+-- Add down migration script here
+
+ALTER TABLE rides DROP COLUMN IF EXISTS adjustment_total;
+
+DROP TABLE IF EXISTS ride_adjustments;
+DROP TABLE IF EXISTS disputes;
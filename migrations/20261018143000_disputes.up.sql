@@ -0,0 +1,42 @@
+-- Add up migration script here
+
+-- Disputes Table (sürüş itirazları)
+CREATE TABLE IF NOT EXISTS disputes (
+    id SERIAL PRIMARY KEY,
+    ride_id INT NOT NULL REFERENCES rides(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(1000) NOT NULL,
+    photo_url VARCHAR(255),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'approved', 'rejected')),
+    refund_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    admin_note VARCHAR(500),
+    resolved_by INT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_disputes_ride_id ON disputes(ride_id);
+CREATE INDEX idx_disputes_status ON disputes(status);
+-- sürüşün aynı anda yalnızca bir açık itirazı olabilir
+CREATE UNIQUE INDEX idx_disputes_open_ride ON disputes(ride_id) WHERE status = 'open';
+
+-- Ride Adjustments Table (bitmiş sürüşlerin ücret düzeltmeleri)
+CREATE TABLE IF NOT EXISTS ride_adjustments (
+    id SERIAL PRIMARY KEY,
+    ride_id INT NOT NULL REFERENCES rides(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('refund', 'correction')),
+    amount DOUBLE PRECISION NOT NULL,
+    refunded_amount DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reason VARCHAR(500),
+    dispute_id INT REFERENCES disputes(id) ON DELETE SET NULL,
+    created_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_ride_adjustments_ride_id ON ride_adjustments(ride_id);
+
+ALTER TABLE rides ADD COLUMN adjustment_total DOUBLE PRECISION NOT NULL DEFAULT 0;
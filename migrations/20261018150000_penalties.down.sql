@@ -0,0 +1,7 @@
+-- Add down migration script here
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_purpose_check;
+ALTER TABLE payments ADD CONSTRAINT payments_purpose_check CHECK (purpose IN ('ride', 'topup', 'pass'));
+
+DROP TABLE IF EXISTS fines;
+DROP TABLE IF EXISTS penalty_types;
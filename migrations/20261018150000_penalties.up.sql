@@ -0,0 +1,44 @@
+-- Add up migration script here
+
+-- Penalty Types Table (ceza türleri ve tutarları)
+CREATE TABLE IF NOT EXISTS penalty_types (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- otomatik kesilen cezaların türleri
+INSERT INTO penalty_types (code, name, description, amount) VALUES
+    ('unlocked_bike', 'Kilitlenmeden bırakılan motor', 'Sürüş sonunda motor kilitlenmedi', 100),
+    ('no_parking', 'Yasak bölgeye park', 'Motor park yasağı olan bölgede veya hizmet alanı dışında bırakıldı', 150),
+    ('missing_photo', 'Eksik sürüş sonu fotoğrafı', 'Sürüş sonu fotoğrafı yüklenmedi', 25);
+
+-- Fines Table (kullanıcılara kesilen cezalar)
+CREATE TABLE IF NOT EXISTS fines (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ride_id INT REFERENCES rides(id) ON DELETE SET NULL,
+    penalty_type_id INT NOT NULL REFERENCES penalty_types(id),
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    reason VARCHAR(500),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('unpaid', 'paid', 'waived')),
+    issued_by INT REFERENCES users(id) ON DELETE SET NULL, -- boşsa otomatik kesilmiştir
+    payment_id INT REFERENCES payments(id),
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_fines_user_status ON fines(user_id, status);
+-- otomatik cezalar sürüş ve tür başına bir kez kesilir
+CREATE UNIQUE INDEX idx_fines_auto_ride_type ON fines(ride_id, penalty_type_id) WHERE issued_by IS NULL;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_purpose_check;
+ALTER TABLE payments ADD CONSTRAINT payments_purpose_check CHECK (purpose IN ('ride', 'topup', 'pass', 'fine'));
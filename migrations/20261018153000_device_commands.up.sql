@@ -0,0 +1,22 @@
+-- Add up migration script here
+
+-- Device Commands Table (motorun kilit modülüne gönderilen komutlar)
+CREATE TABLE IF NOT EXISTS device_commands (
+    id SERIAL PRIMARY KEY,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('lock', 'unlock')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'acked', 'failed', 'timed_out')),
+    transport VARCHAR(20) NOT NULL,
+    requested_by INT NOT NULL REFERENCES users(id),
+    error VARCHAR(255),
+    expires_at TIMESTAMPTZ NOT NULL,
+    acked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_device_commands_motorbike_id ON device_commands(motorbike_id);
+-- motorun aynı anda yalnızca bir bekleyen komutu olabilir
+CREATE UNIQUE INDEX idx_device_commands_pending_motorbike ON device_commands(motorbike_id) WHERE status = 'pending';
+CREATE INDEX idx_device_commands_pending_expires ON device_commands(expires_at) WHERE status = 'pending';
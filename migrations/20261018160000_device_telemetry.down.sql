@@ -0,0 +1,7 @@
+-- Add down migration script here
+
+ALTER TABLE motorbike DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE motorbike DROP COLUMN IF EXISTS battery_level;
+
+DROP TABLE IF EXISTS motorbike_telemetry;
+DROP TABLE IF EXISTS devices;
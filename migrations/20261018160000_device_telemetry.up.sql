@@ -0,0 +1,37 @@
+-- Add up migration script here
+
+-- Devices Table (motorlara takılı kilit/IoT modülleri)
+CREATE TABLE IF NOT EXISTS devices (
+    id SERIAL PRIMARY KEY,
+    serial VARCHAR(64) NOT NULL UNIQUE,
+    motorbike_id INT REFERENCES motorbike(id) ON DELETE SET NULL,
+    api_key_hash VARCHAR(64) NOT NULL, -- API anahtarının SHA-256 özeti
+    last_seen_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- bir motora aynı anda yalnızca bir cihaz takılı olabilir
+CREATE UNIQUE INDEX idx_devices_motorbike_id ON devices(motorbike_id) WHERE motorbike_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Motorbike Telemetry Table (cihazlardan gelen heartbeat geçmişi)
+CREATE TABLE IF NOT EXISTS motorbike_telemetry (
+    id SERIAL PRIMARY KEY,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    device_id INT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    lock_status VARCHAR(10) CHECK (lock_status IN ('locked', 'unlocked')),
+    battery_level DOUBLE PRECISION CHECK (battery_level BETWEEN 0 AND 100),
+    odometer_km DOUBLE PRECISION,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_motorbike_telemetry_motorbike_recorded ON motorbike_telemetry(motorbike_id, recorded_at DESC);
+
+ALTER TABLE motorbike ADD COLUMN battery_level DOUBLE PRECISION;
+ALTER TABLE motorbike ADD COLUMN last_seen_at TIMESTAMPTZ;
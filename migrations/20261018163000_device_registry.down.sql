@@ -0,0 +1,13 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS device_installations;
+
+DROP INDEX IF EXISTS idx_devices_state;
+
+ALTER TABLE devices
+    DROP CONSTRAINT IF EXISTS devices_installed_check,
+    DROP CONSTRAINT IF EXISTS devices_state_check,
+    DROP COLUMN IF EXISTS state,
+    DROP COLUMN IF EXISTS pairing_key,
+    DROP COLUMN IF EXISTS firmware_version,
+    DROP COLUMN IF EXISTS mac_address;
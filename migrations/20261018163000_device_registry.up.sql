@@ -0,0 +1,45 @@
+-- Add up migration script here
+
+-- Devices: donanım bilgileri, eşleştirme anahtarı ve kayıt durumu
+ALTER TABLE devices
+    ADD COLUMN mac_address VARCHAR(17),
+    ADD COLUMN firmware_version VARCHAR(32),
+    ADD COLUMN pairing_key VARCHAR(64),
+    ADD COLUMN state VARCHAR(20);
+
+-- mevcut cihazlara rastgele eşleştirme anahtarı verilir, bu cihazların yeniden kaydedilmesi gerekir
+UPDATE devices SET pairing_key = md5(random()::text || id::text) || md5(clock_timestamp()::text || random()::text);
+UPDATE devices SET state = CASE WHEN motorbike_id IS NULL THEN 'registered' ELSE 'installed' END;
+
+ALTER TABLE devices
+    ALTER COLUMN pairing_key SET NOT NULL,
+    ALTER COLUMN state SET NOT NULL,
+    ADD CONSTRAINT devices_state_check CHECK (state IN ('registered', 'installed', 'retired')),
+    ADD CONSTRAINT devices_installed_check CHECK ((state = 'installed') = (motorbike_id IS NOT NULL));
+
+CREATE INDEX idx_devices_state ON devices(state);
+
+-- Device Installations Table (hangi cihazın hangi motora ne zaman takılıp söküldüğü)
+CREATE TABLE IF NOT EXISTS device_installations (
+    id SERIAL PRIMARY KEY,
+    device_id INT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    installed_at TIMESTAMPTZ NOT NULL,
+    installed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    removed_at TIMESTAMPTZ,
+    removed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_device_installations_device_id ON device_installations(device_id);
+CREATE INDEX idx_device_installations_motorbike_id ON device_installations(motorbike_id);
+
+-- bir cihazın aynı anda yalnızca bir açık kurulum kaydı olabilir
+CREATE UNIQUE INDEX idx_device_installations_open ON device_installations(device_id) WHERE removed_at IS NULL;
+
+-- takılı cihazlar için geçmiş kaydı oluşturulur (installed_by boş)
+INSERT INTO device_installations (device_id, motorbike_id, installed_at)
+SELECT id, motorbike_id, created_at FROM devices WHERE motorbike_id IS NOT NULL;
@@ -0,0 +1,19 @@
+-- Add up migration script here
+
+-- Unlock Tokens Table (bağlantı kurulurken verilen çevrimdışı kilit açma token'ları, token'ın kendisi saklanmaz)
+CREATE TABLE IF NOT EXISTS unlock_tokens (
+    id SERIAL PRIMARY KEY,
+    token_id VARCHAR(32) NOT NULL UNIQUE, -- token içindeki 16 baytlık ID'nin hex gösterimi
+    connection_id INT NOT NULL REFERENCES bluetooth_connection(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    device_id INT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_unlock_tokens_connection_id ON unlock_tokens(connection_id);
+CREATE INDEX idx_unlock_tokens_motorbike_revoked ON unlock_tokens(motorbike_id, expires_at) WHERE revoked_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS connection_sweep_findings;
+
+DROP INDEX IF EXISTS idx_bluetooth_connection_open;
+
+ALTER TABLE bluetooth_connection DROP COLUMN IF EXISTS last_heartbeat_at;
@@ -0,0 +1,23 @@
+-- Add up migration script here
+
+ALTER TABLE bluetooth_connection ADD COLUMN last_heartbeat_at TIMESTAMPTZ;
+
+-- bayat bağlantı taraması açık bağlantılar üzerinde çalışır
+CREATE INDEX idx_bluetooth_connection_open ON bluetooth_connection(connected_at) WHERE disconnected_at IS NULL;
+
+-- Connection Sweep Findings Table (heartbeat gelmediği için otomatik kapatılan bağlantılar)
+CREATE TABLE IF NOT EXISTS connection_sweep_findings (
+    id SERIAL PRIMARY KEY,
+    connection_id INT NOT NULL UNIQUE REFERENCES bluetooth_connection(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    last_heartbeat_at TIMESTAMPTZ NOT NULL,
+    swept_at TIMESTAMPTZ NOT NULL,
+    motor_status_before VARCHAR(20) NOT NULL,
+    motor_status_after VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_connection_sweep_findings_swept_at ON connection_sweep_findings(swept_at);
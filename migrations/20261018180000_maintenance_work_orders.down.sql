@@ -0,0 +1,4 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS work_order_parts;
+DROP TABLE IF EXISTS work_orders;
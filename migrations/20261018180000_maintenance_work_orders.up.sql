@@ -0,0 +1,41 @@
+-- Add up migration script here
+
+-- Work Orders Table (motorun neden servis dışı olduğu, kimin onardığı ve ne zaman döneceği)
+CREATE TABLE IF NOT EXISTS work_orders (
+    id SERIAL PRIMARY KEY,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    issue_type VARCHAR(20) NOT NULL CHECK (issue_type IN ('mechanical', 'electrical', 'battery', 'tyre', 'brake', 'lock', 'body', 'other')),
+    priority VARCHAR(10) NOT NULL CHECK (priority IN ('low', 'medium', 'high', 'critical')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'in_progress', 'waiting_parts', 'completed', 'cancelled')),
+    description VARCHAR(1000) NOT NULL,
+    reported_by INT REFERENCES users(id) ON DELETE SET NULL,
+    technician_id INT REFERENCES users(id) ON DELETE SET NULL,
+    labour_minutes INT NOT NULL DEFAULT 0 CHECK (labour_minutes >= 0),
+    resolution_note VARCHAR(1000),
+    expected_return_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    closed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_work_orders_motorbike_id ON work_orders(motorbike_id);
+CREATE INDEX idx_work_orders_technician_id ON work_orders(technician_id);
+CREATE INDEX idx_work_orders_open ON work_orders(motorbike_id) WHERE status IN ('open', 'in_progress', 'waiting_parts');
+
+-- Work Order Parts Table (iş emrinde kullanılan parçalar)
+CREATE TABLE IF NOT EXISTS work_order_parts (
+    id SERIAL PRIMARY KEY,
+    work_order_id INT NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    part_number VARCHAR(64),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(10, 2) NOT NULL CHECK (unit_cost >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_work_order_parts_work_order_id ON work_order_parts(work_order_id);
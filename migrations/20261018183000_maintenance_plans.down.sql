@@ -0,0 +1,15 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_work_orders_plan_completed;
+DROP INDEX IF EXISTS idx_work_orders_plan_open;
+
+ALTER TABLE work_orders
+    DROP COLUMN IF EXISTS ride_seconds,
+    DROP COLUMN IF EXISTS odometer_meters,
+    DROP COLUMN IF EXISTS plan_id;
+
+DROP TABLE IF EXISTS maintenance_plans;
+
+ALTER TABLE motorbike
+    DROP COLUMN IF EXISTS total_ride_seconds,
+    DROP COLUMN IF EXISTS total_distance_meters;
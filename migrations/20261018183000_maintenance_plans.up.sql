@@ -0,0 +1,48 @@
+-- Add up migration script here
+
+-- motorun kullanım sayaçları, biten sürüşlerle artar
+ALTER TABLE motorbike
+    ADD COLUMN IF NOT EXISTS total_distance_meters DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total_ride_seconds BIGINT NOT NULL DEFAULT 0;
+
+-- mevcut motorların sayaçları geçmiş sürüşlerden doldurulur
+UPDATE motorbike m
+SET total_distance_meters = r.distance_meters,
+    total_ride_seconds = r.ride_seconds
+FROM (
+    SELECT motorbike_id,
+           SUM(distance_meters) AS distance_meters,
+           SUM(EXTRACT(EPOCH FROM (end_time - start_time)))::BIGINT AS ride_seconds
+    FROM rides
+    WHERE status = 'finished' AND end_time IS NOT NULL
+    GROUP BY motorbike_id
+) r
+WHERE r.motorbike_id = m.id;
+
+-- Maintenance Plans Table (motor modeline göre periyodik bakım kuralları)
+CREATE TABLE IF NOT EXISTS maintenance_plans (
+    id SERIAL PRIMARY KEY,
+    model VARCHAR(100) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    issue_type VARCHAR(20) NOT NULL CHECK (issue_type IN ('mechanical', 'electrical', 'battery', 'tyre', 'brake', 'lock', 'body', 'other')),
+    priority VARCHAR(10) NOT NULL CHECK (priority IN ('low', 'medium', 'high', 'critical')),
+    interval_km DOUBLE PRECISION CHECK (interval_km > 0),
+    interval_hours DOUBLE PRECISION CHECK (interval_hours > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    CHECK (interval_km IS NOT NULL OR interval_hours IS NOT NULL)
+);
+
+CREATE INDEX idx_maintenance_plans_model ON maintenance_plans(model) WHERE active AND deleted_at IS NULL;
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS plan_id INT REFERENCES maintenance_plans(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS odometer_meters DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS ride_seconds BIGINT;
+
+-- bir plan aynı motor için aynı anda tek iş emri açabilir
+CREATE UNIQUE INDEX idx_work_orders_plan_open ON work_orders(plan_id, motorbike_id)
+    WHERE plan_id IS NOT NULL AND status IN ('open', 'in_progress', 'waiting_parts') AND deleted_at IS NULL;
+CREATE INDEX idx_work_orders_plan_completed ON work_orders(plan_id, motorbike_id, closed_at DESC) WHERE status = 'completed';
@@ -0,0 +1,4 @@
+-- Add down migration script here
+
+DROP TABLE IF EXISTS damage_report_photos;
+DROP TABLE IF EXISTS damage_reports;
@@ -0,0 +1,36 @@
+-- Add up migration script here
+
+-- Damage Reports Table (kullanıcıların motorda gördüğü hasar bildirimleri)
+CREATE TABLE IF NOT EXISTS damage_reports (
+    id SERIAL PRIMARY KEY,
+    motorbike_id INT NOT NULL REFERENCES motorbike(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ride_id INT REFERENCES rides(id) ON DELETE SET NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('mirror', 'tyre', 'brake', 'light', 'lock', 'battery', 'body', 'other')),
+    description VARCHAR(1000) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('new', 'triaged', 'dismissed')),
+    duplicate_of_id INT REFERENCES damage_reports(id) ON DELETE CASCADE,
+    work_order_id INT REFERENCES work_orders(id) ON DELETE SET NULL,
+    admin_note VARCHAR(500),
+    reviewed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_damage_reports_motorbike_id ON damage_reports(motorbike_id);
+CREATE INDEX idx_damage_reports_duplicate_of_id ON damage_reports(duplicate_of_id);
+CREATE INDEX idx_damage_reports_new ON damage_reports(motorbike_id) WHERE status = 'new' AND duplicate_of_id IS NULL;
+
+-- Damage Report Photos Table (bildirime eklenen fotoğraflar)
+CREATE TABLE IF NOT EXISTS damage_report_photos (
+    id SERIAL PRIMARY KEY,
+    damage_report_id INT NOT NULL REFERENCES damage_reports(id) ON DELETE CASCADE,
+    photo_url VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX idx_damage_report_photos_damage_report_id ON damage_report_photos(damage_report_id);
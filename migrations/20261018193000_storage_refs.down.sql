@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+UPDATE rides SET end_photo_url = 'uploads/' || substring(end_photo_url from 11) WHERE end_photo_url LIKE 'storage://%';
+UPDATE disputes SET photo_url = 'uploads/' || substring(photo_url from 11) WHERE photo_url LIKE 'storage://%';
+UPDATE damage_report_photos SET photo_url = 'uploads/' || substring(photo_url from 11) WHERE photo_url LIKE 'storage://%';
@@ -0,0 +1,6 @@
+-- Add up migration script here
+
+-- yerel diske yazılmış eski yüklemeler depolama referansına çevrilir, dosyalar aynı anahtarla STORAGE_BACKEND'e taşınmalıdır
+UPDATE rides SET end_photo_url = 'storage://' || substring(end_photo_url from 9) WHERE end_photo_url LIKE 'uploads/%';
+UPDATE disputes SET photo_url = 'storage://' || substring(photo_url from 9) WHERE photo_url LIKE 'uploads/%';
+UPDATE damage_report_photos SET photo_url = 'storage://' || substring(photo_url from 9) WHERE photo_url LIKE 'uploads/%';
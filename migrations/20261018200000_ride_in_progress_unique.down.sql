@@ -0,0 +1,3 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_rides_motorbike_in_progress;
@@ -0,0 +1,5 @@
+-- Add up migration script here
+
+-- bir motorun aynı anda yalnızca bir bitmemiş sürüşü olabilir
+CREATE UNIQUE INDEX idx_rides_motorbike_in_progress ON rides(motorbike_id)
+    WHERE status IN ('active', 'paused', 'awaiting_lock', 'awaiting_photo') AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_reservations_active_user;
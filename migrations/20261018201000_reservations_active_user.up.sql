@@ -0,0 +1,4 @@
+-- Add up migration script here
+
+-- kullanıcının aynı anda yalnızca bir aktif rezervasyonu olabilir
+CREATE UNIQUE INDEX idx_reservations_active_user ON reservations(user_id) WHERE status = 'active';
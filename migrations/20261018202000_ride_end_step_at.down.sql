@@ -0,0 +1,5 @@
+-- Add down migration script here
+
+DROP INDEX IF EXISTS idx_rides_end_step_at;
+
+ALTER TABLE rides DROP COLUMN IF EXISTS end_step_at;
@@ -0,0 +1,8 @@
+-- Add up migration script here
+
+-- bitirme adımına giriş zamanı, otomatik cezalar updated_at yerine buna göre kesilir
+ALTER TABLE rides ADD COLUMN IF NOT EXISTS end_step_at TIMESTAMPTZ;
+
+UPDATE rides SET end_step_at = updated_at WHERE status IN ('awaiting_lock', 'awaiting_photo');
+
+CREATE INDEX idx_rides_end_step_at ON rides(end_step_at) WHERE status IN ('awaiting_lock', 'awaiting_photo');
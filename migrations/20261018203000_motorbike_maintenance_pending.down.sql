@@ -0,0 +1,3 @@
+-- Add down migration script here
+
+ALTER TABLE motorbike DROP COLUMN IF EXISTS maintenance_pending;
@@ -0,0 +1,4 @@
+-- Add up migration script here
+
+-- kiradayken iş emri açılan motor, kiralama bitince 'available' yerine 'maintenance' durumuna geçer
+ALTER TABLE motorbike ADD COLUMN IF NOT EXISTS maintenance_pending BOOLEAN NOT NULL DEFAULT FALSE;
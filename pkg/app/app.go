@@ -7,7 +7,9 @@ import (
 	"motorbike-rental-backend/pkg/config"
 
 	"motorbike-rental-backend/pkg/database"
+	"motorbike-rental-backend/pkg/events"
 	"motorbike-rental-backend/pkg/log"
+	"motorbike-rental-backend/pkg/storage"
 	"motorbike-rental-backend/pkg/viewmodel"
 
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -20,6 +22,7 @@ import (
 	"gorm.io/gorm"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -33,6 +36,11 @@ type App struct {
 	DB       *gorm.DB
 	Cfg      *config.Config
 	Ctx      context.Context
+	Events   *events.Bus       // cihaz olayları (kilit, telemetri) bu dağıtıcı üzerinden yayınlanır
+	Storage  storage.BlobStore // yüklenen dosyalar (STORAGE_BACKEND: local veya s3)
+
+	jobs   []Job
+	jobsWG sync.WaitGroup
 }
 
 func New(router IRouter, Version, BuildTime string) *App {
@@ -78,6 +86,12 @@ func New(router IRouter, Version, BuildTime string) *App {
 		return nil
 	})
 
+	store, err := newBlobStore(cfg)
+	if err != nil {
+		panic(err)
+	}
+	storage.SetDefault(store, cfg.Storage.URLTTL)
+
 	db := database.ConnectDB(cfg.Database)
 
 	app := &App{
@@ -85,6 +99,8 @@ func New(router IRouter, Version, BuildTime string) *App {
 		DB:       db,
 		Cfg:      cfg,
 		Ctx:      context.Background(),
+		Events:   events.NewBus(),
+		Storage:  store,
 	}
 
 	router.RegisterRoutes(app)
@@ -92,6 +108,24 @@ func New(router IRouter, Version, BuildTime string) *App {
 	return app
 }
 
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	switch cfg.Storage.Backend {
+	case "local":
+		return storage.NewLocalStore(cfg.Server.UploadDir, cfg.Storage.PublicURL, []byte(cfg.Storage.SigningKey))
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			PathStyle: cfg.Storage.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", cfg.Storage.Backend)
+	}
+}
+
 var l = log.GetLogger("") // loggerımızı tanımladık
 
 func (a *App) MigrateDB() {
@@ -106,6 +140,9 @@ func (a *App) Start() {
 	l.SetOptions(zap.AddCallerSkip(-2))
 	l.Info("http server başlatılıyor...")
 
+	jobsCtx, stopJobs := context.WithCancel(a.Ctx)
+	a.startJobs(jobsCtx)
+
 	go func() {
 		err := a.FiberApp.Listen(fmt.Sprintf(":%v", a.Cfg.Server.Port))
 		if err != nil {
@@ -123,6 +160,10 @@ func (a *App) Start() {
 		l.Error("FiberApp shutdown", zap.Error(err))
 	}
 
+	// Arka plan işlerini durdur, DB kapanmadan önce bitmelerini bekle
+	stopJobs()
+	a.jobsWG.Wait()
+
 	// Veritabanı bağlantısını kapatma
 	sqlDB, _ := a.DB.DB()
 	if sqlDB != nil {
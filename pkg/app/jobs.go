@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Job App.Start ile başlatılan, kapanışta durdurulan periyodik arka plan işidir (rezervasyon süresi dolumu vb.)
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// AddJob işi kaydeder, işler App.Start çağrılana kadar çalışmaz (migrate komutunda çalışmazlar)
+func (a *App) AddJob(name string, interval time.Duration, run func(ctx context.Context) error) {
+	a.jobs = append(a.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+func (a *App) startJobs(ctx context.Context) {
+	for _, job := range a.jobs {
+		// hatalı/boş aralık verilmişse ticker panic atmasın, iş çalıştırılmaz
+		if job.Interval <= 0 {
+			l.Error("Arka plan işi için geçersiz aralık, iş başlatılmadı", zap.String("job", job.Name))
+			continue
+		}
+
+		a.jobsWG.Add(1)
+
+		go func(job Job) {
+			defer a.jobsWG.Done()
+
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := job.Run(ctx); err != nil {
+						l.Error("Arka plan işi başarısız", zap.String("job", job.Name), zap.Error(err))
+					}
+				}
+			}
+		}(job)
+
+		l.Info("Arka plan işi başlatıldı", zap.String("job", job.Name), zap.Duration("interval", job.Interval))
+	}
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -11,6 +13,16 @@ type Config struct {
 	IsDevelopment bool
 	Server        ServerConfig
 	Database      DbConfig
+	Pricing       PricingConfig
+	Reservation   ReservationConfig
+	Ride          RideConfig
+	Payment       PaymentConfig
+	Invoice       InvoiceConfig
+	Penalty       PenaltyConfig
+	Device        DeviceConfig
+	Connection    ConnectionConfig
+	Maintenance   MaintenanceConfig
+	Storage       StorageConfig
 }
 
 type ServerConfig struct {
@@ -38,6 +50,68 @@ type DbConfig struct {
 	MaxLifetime string
 }
 
+type PricingConfig struct {
+	Timezone string // saat/gün çarpanlarının uygulanacağı saat dilimi
+}
+
+type ReservationConfig struct {
+	HoldDuration   time.Duration // motorun rezerve tutulacağı süre
+	ExpireInterval time.Duration // süresi dolan rezervasyonları serbest bırakan işin çalışma aralığı
+}
+
+type RideConfig struct {
+	MaxPause           time.Duration // bu süreyi aşan molalar tam ücretle devam eder
+	PauseCheckInterval time.Duration // azami süreyi aşan molaları bitiren işin çalışma aralığı
+}
+
+type PaymentConfig struct {
+	Provider       string  // sürüş ödemelerinin tahsil edileceği sağlayıcı: wallet | fake
+	CardGateway    string  // cüzdan yüklemelerinde karttan çekim yapan sağlayıcı, boşsa yükleme kapalı. fake yalnızca geliştirme içindir
+	RideHoldAmount float64 // sürüş başlarken alınan provizyon tutarı (TL)
+}
+
+type InvoiceConfig struct {
+	VATRate float64 // fişlerde uygulanan KDV oranı, fiyatlar KDV dahildir
+}
+
+type PenaltyConfig struct {
+	GracePeriod    time.Duration // bitirme adımında bu süreden uzun bekleyen sürüşlere otomatik ceza kesilir
+	CheckInterval  time.Duration // otomatik ceza kesen işin çalışma aralığı
+	BlockThreshold float64       // ödenmemiş cezalar bu tutarı aşarsa yeni sürüş başlatılamaz (TL)
+}
+
+type DeviceConfig struct {
+	Transport            string        // kilit komutlarının gönderileceği kanal: simulated
+	CommandTimeout       time.Duration // cihazın komuta yanıt vermesi için beklenen süre
+	CommandCheckInterval time.Duration // yanıtsız komutları zaman aşımına uğratan işin çalışma aralığı
+	SimulatedDelay       time.Duration // simüle cihazın komuta yanıt verme gecikmesi
+	UnlockTokenTTL       time.Duration // çevrimdışı kilit açma token'larının geçerlilik süresi
+}
+
+type ConnectionConfig struct {
+	HeartbeatTimeout time.Duration // bu süre boyunca heartbeat gelmeyen bluetooth bağlantıları kapatılır
+	SweepInterval    time.Duration // bayat bağlantıları kapatan işin çalışma aralığı
+}
+
+type MaintenanceConfig struct {
+	CheckInterval    time.Duration // bakım zamanı gelen motorlar için iş emri açan işin çalışma aralığı
+	DueSoonThreshold float64       // bakımı yaklaşan motorlar raporunun varsayılan kullanım oranı
+}
+
+type StorageConfig struct {
+	Backend       string        // local veya s3
+	PublicURL     string        // local: imzalı indirme adreslerinin ön eki (/api/files)
+	SigningKey    string        // local: indirme adreslerinin imza anahtarı
+	URLTTL        time.Duration // indirme adreslerinin geçerlilik süresi
+	MaxUploadSize int64         // bayt
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3PathStyle   bool // MinIO için true
+}
+
 func Load() (*Config, error) {
 	err := godotenv.Load(".env")
 	if err != nil {
@@ -50,6 +124,7 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Port:                       getEnv("SERVER_PORT", "3003"),
 			JwtSecret:                  getEnv("SERVER_SECRET", ""),
+			UploadDir:                  getEnv("SERVER_UPLOAD_DIR", "uploads"),
 			JwtAccessTokenExpireMinute: getEnvDuration("JWT_ACCESS_TOKEN_EXPIRE_MINUTE", "15m"),
 			JwtRefreshTokenExpireHour:  getEnvDuration("JWT_REFRESH_TOKEN_EXPIRE_HOUR", "24h"),
 		},
@@ -63,6 +138,63 @@ func Load() (*Config, error) {
 			MaxPoolSize: getEnv("MAX_POOL_SIZE", "5"),
 			MaxLifetime: getEnv("MAX_LIFE_TIME", "1800"),
 		},
+		Pricing: PricingConfig{
+			Timezone: getEnv("PRICING_TIMEZONE", "Europe/Istanbul"),
+		},
+		Reservation: ReservationConfig{
+			HoldDuration:   getEnvDuration("RESERVATION_HOLD_DURATION", "10m"),
+			ExpireInterval: getEnvDuration("RESERVATION_EXPIRE_INTERVAL", "30s"),
+		},
+		Ride: RideConfig{
+			MaxPause:           getEnvDuration("RIDE_MAX_PAUSE", "30m"),
+			PauseCheckInterval: getEnvDuration("RIDE_PAUSE_CHECK_INTERVAL", "1m"),
+		},
+		Payment: PaymentConfig{
+			Provider:       getEnv("PAYMENT_PROVIDER", "wallet"),
+			CardGateway:    getEnv("PAYMENT_CARD_GATEWAY", ""),
+			RideHoldAmount: getEnvFloat("PAYMENT_RIDE_HOLD_AMOUNT", "50"),
+		},
+		Invoice: InvoiceConfig{
+			VATRate: getEnvFloat("INVOICE_VAT_RATE", "0.20"),
+		},
+		Penalty: PenaltyConfig{
+			GracePeriod:    getEnvDuration("PENALTY_GRACE_PERIOD", "15m"),
+			CheckInterval:  getEnvDuration("PENALTY_CHECK_INTERVAL", "1m"),
+			BlockThreshold: getEnvFloat("PENALTY_BLOCK_THRESHOLD", "50"),
+		},
+		Device: DeviceConfig{
+			Transport:            getEnv("DEVICE_TRANSPORT", "simulated"),
+			CommandTimeout:       getEnvDuration("DEVICE_COMMAND_TIMEOUT", "30s"),
+			CommandCheckInterval: getEnvDuration("DEVICE_COMMAND_CHECK_INTERVAL", "10s"),
+			SimulatedDelay:       getEnvDuration("DEVICE_SIMULATED_DELAY", "2s"),
+			UnlockTokenTTL:       getEnvDuration("DEVICE_UNLOCK_TOKEN_TTL", "5m"),
+		},
+		Connection: ConnectionConfig{
+			HeartbeatTimeout: getEnvDuration("CONNECTION_HEARTBEAT_TIMEOUT", "5m"),
+			SweepInterval:    getEnvDuration("CONNECTION_SWEEP_INTERVAL", "1m"),
+		},
+		Maintenance: MaintenanceConfig{
+			CheckInterval:    getEnvDuration("MAINTENANCE_CHECK_INTERVAL", "1h"),
+			DueSoonThreshold: getEnvFloat("MAINTENANCE_DUE_SOON_THRESHOLD", "0.9"),
+		},
+		Storage: StorageConfig{
+			Backend:       getEnv("STORAGE_BACKEND", "local"),
+			PublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:3003/api/files"),
+			SigningKey:    getEnv("STORAGE_SIGNING_KEY", getEnv("SERVER_SECRET", "")),
+			URLTTL:        getEnvDuration("STORAGE_URL_TTL", "15m"),
+			MaxUploadSize: int64(getEnvFloat("STORAGE_MAX_UPLOAD_MB", "10") * 1024 * 1024),
+			S3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", "http://localhost:9000"),
+			S3Region:      getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:      getEnv("STORAGE_S3_BUCKET", ""),
+			S3AccessKey:   getEnv("STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("STORAGE_S3_SECRET_KEY", ""),
+			S3PathStyle:   getEnv("STORAGE_S3_PATH_STYLE", "true") == "true",
+		},
+	}
+
+	// cüzdandan tahsilatta cüzdanlar yalnızca kart sağlayıcısıyla yüklenebilir, sağlayıcı yoksa sürüş provizyonları hep reddedilir
+	if config.Payment.Provider == "wallet" && config.Payment.CardGateway == "" {
+		return nil, errors.New("PAYMENT_PROVIDER=wallet iken PAYMENT_CARD_GATEWAY ayarlanmalı, aksi halde cüzdanlara bakiye yüklenemez")
 	}
 
 	return config, nil
@@ -83,3 +215,11 @@ func getEnvDuration(key, fallback string) time.Duration {
 	}
 	return duration
 }
+
+func getEnvFloat(key, fallback string) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, fallback), 64)
+	if err != nil {
+		value, _ = strconv.ParseFloat(fallback, 64)
+	}
+	return value
+}
@@ -20,6 +20,8 @@ func ConnectDB(cfg config.DbConfig) *gorm.DB {
 	// PostgreSQL veritabanına bağlantı aç
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info), // Gerekirse log seviyesini ayarlayın
+		// unique index ihlalleri gorm.ErrDuplicatedKey olarak döner
+		TranslateError: true,
 	})
 	if err != nil {
 		log.Errorf("error opening database connection: %v", err)
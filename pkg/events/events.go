@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"motorbike-rental-backend/pkg/log"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Type string
+
+// Motor cihazlarından gelen bilgilerle yayınlanan olaylar
+const (
+	BikeLocked    Type = "bike.locked"    // cihaz motorun kilitlendiğini bildirdi
+	BikeUnlocked  Type = "bike.unlocked"  // cihaz motorun kilidinin açıldığını bildirdi
+	BikeTelemetry Type = "bike.telemetry" // cihazdan heartbeat geldi
+)
+
+type Event struct {
+	Type        Type
+	MotorbikeID uint
+	At          time.Time
+	Data        interface{} // olaya göre ek bilgi, ör. telemetri kaydı
+}
+
+// Handler olayı işleyen fonksiyon, dönen hata yalnızca loglanır
+type Handler func(ctx context.Context, event Event) error
+
+// Bus uygulama içi olay dağıtıcısı. Olaylar aboneliğe göre sırayla ve yayınlayanın goroutine'inde işlenir;
+// bir abonenin hatası diğer aboneleri ve yayınlayanı etkilemez.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[Type][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[Type][]Handler{}}
+}
+
+func (b *Bus) Subscribe(eventType Type, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			l := log.GetLogger("")
+			l.Error("Olay işlenemedi", zap.String("event", string(event.Type)), zap.Uint("motorbike_id", event.MotorbikeID), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package geo
+
+import "math"
+
+// earthRadiusMeters ortalama dünya yarıçapı (metre)
+const earthRadiusMeters = 6371008.8
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Haversine iki koordinat arasındaki büyük daire mesafesini metre cinsinden döner
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
+
+// PathLength ardışık noktalar arasındaki mesafelerin toplamını metre cinsinden döner
+func PathLength(points []Point) float64 {
+	var total float64
+	for i := 1; i < len(points); i++ {
+		total += Haversine(points[i-1].Lat, points[i-1].Lng, points[i].Lat, points[i].Lng)
+	}
+	return total
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,38 @@
+package geo
+
+// GeoJSON tipleri (RFC 7946). Koordinatlar [boylam, enlem] sırasıyla tutulur.
+
+type LineString struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   interface{}            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func NewLineString(points []Point) LineString {
+	coordinates := make([][]float64, 0, len(points))
+	for _, p := range points {
+		coordinates = append(coordinates, []float64{p.Lng, p.Lat})
+	}
+	return LineString{Type: "LineString", Coordinates: coordinates}
+}
+
+func NewFeature(geometry interface{}, properties map[string]interface{}) Feature {
+	return Feature{Type: "Feature", Geometry: geometry, Properties: properties}
+}
+
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
@@ -0,0 +1,64 @@
+package geo
+
+import "math"
+
+// Konum sorguları için dünya 0.01 derecelik (~1.1 km) hücrelere bölünür. Hücre numarası veritabanında
+// motorbike.grid_cell üretilmiş kolonuyla aynı formülle hesaplanır (bkz. migrations/*_motorbike_grid).
+const (
+	gridCellsPerDegree = 100
+	gridLatOffset      = 90 * gridCellsPerDegree
+	gridLngOffset      = 180 * gridCellsPerDegree
+	gridRowSize        = 100000
+)
+
+const metersPerDegreeLat = 111320.0
+
+// GridCell noktanın bulunduğu hücrenin numarasını döner
+func GridCell(lat, lng float64) int64 {
+	row := int64(math.Floor(lat*gridCellsPerDegree)) + gridLatOffset
+	col := int64(math.Floor(lng*gridCellsPerDegree)) + gridLngOffset
+	return row*gridRowSize + col
+}
+
+// GridCells bbox ile kesişen hücreleri döner. Hücre sayısı maxCells değerini aşarsa false döner,
+// bu durumda sorgu hücre filtresi olmadan yalnızca enlem/boylam aralığıyla yapılmalıdır.
+func GridCells(box BBox, maxCells int) ([]int64, bool) {
+	minRow := int64(math.Floor(box.MinLat * gridCellsPerDegree))
+	maxRow := int64(math.Floor(box.MaxLat * gridCellsPerDegree))
+	minCol := int64(math.Floor(box.MinLng * gridCellsPerDegree))
+	maxCol := int64(math.Floor(box.MaxLng * gridCellsPerDegree))
+
+	count := (maxRow - minRow + 1) * (maxCol - minCol + 1)
+	if count <= 0 || count > int64(maxCells) {
+		return nil, false
+	}
+
+	cells := make([]int64, 0, count)
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			cells = append(cells, (row+gridLatOffset)*gridRowSize+col+gridLngOffset)
+		}
+	}
+	return cells, true
+}
+
+// BBoxAround merkez etrafında radiusMeters yarıçaplı daireyi çevreleyen dikdörtgeni döner
+func BBoxAround(lat, lng, radiusMeters float64) BBox {
+	dLat := radiusMeters / metersPerDegreeLat
+	dLng := 180.0
+	if cos := math.Cos(toRadians(lat)); cos > 1e-6 {
+		dLng = math.Min(radiusMeters/(metersPerDegreeLat*cos), 180)
+	}
+
+	return BBox{
+		MinLat: math.Max(lat-dLat, -90),
+		MaxLat: math.Min(lat+dLat, 90),
+		MinLng: math.Max(lng-dLng, -180),
+		MaxLng: math.Min(lng+dLng, 180),
+	}
+}
+
+// Center dikdörtgenin merkez noktasını döner
+func (b BBox) Center() Point {
+	return Point{Lat: (b.MinLat + b.MaxLat) / 2, Lng: (b.MinLng + b.MaxLng) / 2}
+}
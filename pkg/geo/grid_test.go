@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGridCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lng float64
+		want     int64
+	}{
+		{"başlangıç noktası", 0, 0, 900018000},
+		{"negatif koordinat alttaki hücreye düşer", -0.001, -0.001, 899917999},
+		{"hücre sınırı sonraki hücreye ait", 41.01, 29.01, 1310120901},
+		{"hücre içi", 41.0199, 29.0199, 1310120901},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GridCell(tt.lat, tt.lng); got != tt.want {
+				t.Errorf("GridCell(%v, %v) = %d, want %d", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridCells(t *testing.T) {
+	t.Run("tek hücre", func(t *testing.T) {
+		box := BBox{MinLat: 41.011, MinLng: 29.011, MaxLat: 41.019, MaxLng: 29.019}
+		cells, ok := GridCells(box, 10)
+		if !ok || len(cells) != 1 || cells[0] != GridCell(41.015, 29.015) {
+			t.Errorf("GridCells() = %v, %v, want [%d], true", cells, ok, GridCell(41.015, 29.015))
+		}
+	})
+
+	t.Run("köşeleri içeren hücreler", func(t *testing.T) {
+		box := BBox{MinLat: 41.005, MinLng: 29.005, MaxLat: 41.015, MaxLng: 29.015}
+		cells, ok := GridCells(box, 10)
+		if !ok || len(cells) != 4 {
+			t.Fatalf("GridCells() = %v, %v, want 4 hücre", cells, ok)
+		}
+
+		set := map[int64]bool{}
+		for _, cell := range cells {
+			set[cell] = true
+		}
+		for _, corner := range [][2]float64{{41.005, 29.005}, {41.005, 29.015}, {41.015, 29.005}, {41.015, 29.015}} {
+			if !set[GridCell(corner[0], corner[1])] {
+				t.Errorf("köşe %v hücrelerde yok", corner)
+			}
+		}
+	})
+
+	t.Run("sınırı aşan hücre sayısı", func(t *testing.T) {
+		box := BBox{MinLat: 41, MinLng: 29, MaxLat: 41.1, MaxLng: 29.1}
+		if cells, ok := GridCells(box, 50); ok {
+			t.Errorf("GridCells() = %d hücre, true, want false", len(cells))
+		}
+	})
+}
+
+func TestBBoxAround(t *testing.T) {
+	box := BBoxAround(0, 0, metersPerDegreeLat/100)
+	if math.Abs(box.MaxLat-0.01) > 1e-9 || math.Abs(box.MinLng+0.01) > 1e-9 {
+		t.Errorf("BBoxAround() = %+v, want ±0.01 derece", box)
+	}
+
+	// kutupta boylam aralığı tüm dünyayı kapsar
+	polar := BBoxAround(90, 0, 1000)
+	if polar.MinLng != -180 || polar.MaxLng != 180 || polar.MaxLat != 90 {
+		t.Errorf("BBoxAround(90, 0) = %+v, want boylam -180..180, enlem en fazla 90", polar)
+	}
+}
@@ -0,0 +1,127 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrInvalidPolygon = errors.New("invalid GeoJSON polygon")
+
+// Polygon GeoJSON Polygon geometrisi. İlk halka dış sınır, sonrakiler deliklerdir;
+// her halka kapalıdır (ilk ve son nokta aynı) ve koordinatlar [boylam, enlem] sırasındadır.
+type Polygon struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+type BBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+// Validate poligonun RFC 7946'ya uygun, kapalı halkalardan oluştuğunu kontrol eder
+func (p Polygon) Validate() error {
+	if p.Type != "Polygon" || len(p.Coordinates) == 0 {
+		return ErrInvalidPolygon
+	}
+
+	for _, ring := range p.Coordinates {
+		if len(ring) < 4 {
+			return ErrInvalidPolygon
+		}
+		for _, position := range ring {
+			if len(position) < 2 ||
+				position[0] < -180 || position[0] > 180 ||
+				position[1] < -90 || position[1] > 90 {
+				return ErrInvalidPolygon
+			}
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return ErrInvalidPolygon
+		}
+	}
+
+	return nil
+}
+
+// Contains noktanın dış halkanın içinde ve deliklerin dışında olup olmadığını döner (ray casting).
+// Sınır poligona dahildir: dış halkanın veya bir deliğin kenarındaki nokta poligonun içinde sayılır,
+// veritabanındaki bbox ön filtresi de sınırı dahil eder.
+func (p Polygon) Contains(pt Point) bool {
+	if len(p.Coordinates) == 0 {
+		return false
+	}
+	if onRing(p.Coordinates[0], pt) {
+		return true
+	}
+	if !ringContains(p.Coordinates[0], pt) {
+		return false
+	}
+
+	for _, hole := range p.Coordinates[1:] {
+		if onRing(hole, pt) {
+			return true
+		}
+		if ringContains(hole, pt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BBox dış halkayı çevreleyen dikdörtgeni döner, veritabanında ön filtre olarak kullanılır
+func (p Polygon) BBox() BBox {
+	var box BBox
+	if len(p.Coordinates) == 0 || len(p.Coordinates[0]) == 0 {
+		return box
+	}
+
+	first := p.Coordinates[0][0]
+	box = BBox{MinLat: first[1], MinLng: first[0], MaxLat: first[1], MaxLng: first[0]}
+	for _, position := range p.Coordinates[0] {
+		box.MinLng = min(box.MinLng, position[0])
+		box.MaxLng = max(box.MaxLng, position[0])
+		box.MinLat = min(box.MinLat, position[1])
+		box.MaxLat = max(box.MaxLat, position[1])
+	}
+
+	return box
+}
+
+func ringContains(ring [][]float64, pt Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+
+		if (yi > pt.Lat) != (yj > pt.Lat) &&
+			pt.Lng < (xj-xi)*(pt.Lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// onRing nokta halkanın kenarlarından birinin üzerindeyse true döner. Ray casting sınırdaki noktalar için
+// kenarın yönüne göre farklı sonuç verdiğinden sınır ayrıca kontrol edilir.
+func onRing(ring [][]float64, pt Point) bool {
+	const epsilon = 1e-12
+
+	for i := 1; i < len(ring); i++ {
+		x1, y1 := ring[i-1][0], ring[i-1][1]
+		x2, y2 := ring[i][0], ring[i][1]
+
+		cross := (x2-x1)*(pt.Lat-y1) - (y2-y1)*(pt.Lng-x1)
+		if math.Abs(cross) > epsilon {
+			continue
+		}
+		if pt.Lng >= min(x1, x2) && pt.Lng <= max(x1, x2) && pt.Lat >= min(y1, y2) && pt.Lat <= max(y1, y2) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+)
+
+// 10x10 kare, ortasında 4-6 arası delik. Koordinatlar [boylam, enlem].
+var squareWithHole = Polygon{
+	Type: "Polygon",
+	Coordinates: [][][]float64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+	},
+}
+
+func TestPolygonContains(t *testing.T) {
+	triangle := Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}}}
+	// U biçiminde içbükey poligon, 3-7 boylamları arasında 5 enleminin üstü boş
+	concave := Polygon{Type: "Polygon", Coordinates: [][][]float64{
+		{{0, 0}, {10, 0}, {10, 10}, {7, 10}, {7, 5}, {3, 5}, {3, 10}, {0, 10}, {0, 0}},
+	}}
+
+	tests := []struct {
+		name    string
+		polygon Polygon
+		point   Point
+		want    bool
+	}{
+		{"içeride", squareWithHole, Point{Lat: 2, Lng: 5}, true},
+		{"dışarıda", squareWithHole, Point{Lat: 5, Lng: 11}, false},
+		{"dışarıda, enlem aralığında değil", squareWithHole, Point{Lat: -1, Lng: 5}, false},
+		{"sol kenar", squareWithHole, Point{Lat: 5, Lng: 0}, true},
+		{"sağ kenar", squareWithHole, Point{Lat: 5, Lng: 10}, true},
+		{"alt kenar", squareWithHole, Point{Lat: 0, Lng: 5}, true},
+		{"üst kenar", squareWithHole, Point{Lat: 10, Lng: 5}, true},
+		{"köşe", squareWithHole, Point{Lat: 0, Lng: 0}, true},
+		{"karşı köşe", squareWithHole, Point{Lat: 10, Lng: 10}, true},
+		{"delikte", squareWithHole, Point{Lat: 5, Lng: 5}, false},
+		{"delik kenarı poligona dahil", squareWithHole, Point{Lat: 5, Lng: 4}, true},
+		{"delik köşesi poligona dahil", squareWithHole, Point{Lat: 6, Lng: 6}, true},
+		{"delik ile dış sınır arasında", squareWithHole, Point{Lat: 5, Lng: 2}, true},
+		{"çapraz kenar üzerinde", triangle, Point{Lat: 5, Lng: 5}, true},
+		{"çapraz kenarın dışında", triangle, Point{Lat: 6, Lng: 4}, false},
+		{"çapraz kenarın içinde", triangle, Point{Lat: 4, Lng: 6}, true},
+		{"içbükey poligonun girintisinde", concave, Point{Lat: 8, Lng: 5}, false},
+		{"içbükey poligonun kolunda", concave, Point{Lat: 8, Lng: 1}, true},
+		{"içbükey poligonun girinti tabanında", concave, Point{Lat: 5, Lng: 5}, true},
+		{"boş poligon", Polygon{Type: "Polygon"}, Point{Lat: 0, Lng: 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygonValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon Polygon
+		wantErr bool
+	}{
+		{"geçerli", squareWithHole, false},
+		{"tür Polygon değil", Polygon{Type: "MultiPolygon", Coordinates: squareWithHole.Coordinates}, true},
+		{"halka yok", Polygon{Type: "Polygon"}, true},
+		{"halka kapalı değil", Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}}, true},
+		{"dörtten az nokta", Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {10, 0}, {0, 0}}}}, true},
+		{"boylam sınır dışında", Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {181, 0}, {10, 10}, {0, 0}}}}, true},
+		{"enlem sınır dışında", Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {10, 0}, {10, 91}, {0, 0}}}}, true},
+		{"eksik koordinat", Polygon{Type: "Polygon", Coordinates: [][][]float64{{{0, 0}, {10}, {10, 10}, {0, 0}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidPolygon) {
+				t.Errorf("Validate() = %v, want ErrInvalidPolygon", err)
+			}
+		})
+	}
+}
+
+func TestPolygonBBox(t *testing.T) {
+	want := BBox{MinLat: 0, MinLng: 0, MaxLat: 10, MaxLng: 10}
+	if got := squareWithHole.BBox(); got != want {
+		t.Errorf("BBox() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+// Package storage yüklenen dosyaları (sürüş sonu fotoğrafı, motor fotoğrafları vb.) saklar.
+//
+// Dosyalar BlobStore arayüzü üzerinden yerel dosya sisteminde (LocalStore) veya S3 uyumlu bir serviste
+// (S3Store, yerelde MinIO) tutulur. Veritabanına dosya yolu yerine "storage://<key>" biçiminde bir referans
+// yazılır, istemciye bu referanstan üretilen süreli imzalı indirme adresi döner.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheme veritabanında saklanan dosya referanslarının öneki
+const Scheme = "storage://"
+
+var (
+	ErrNotFound           = errors.New("object not found")
+	ErrInvalidKey         = errors.New("invalid object key")
+	ErrFileTooLarge       = errors.New("file is too large")
+	ErrUnsupportedContent = errors.New("unsupported content type")
+	ErrInvalidSignature   = errors.New("invalid download url signature")
+	ErrURLExpired         = errors.New("download url expired")
+)
+
+// Object saklanan dosyanın bilgileri
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL dosyanın expiresAt zamanına kadar geçerli indirme adresini döner, ağ isteği yapmaz
+	SignedURL(key string, expiresAt time.Time) (string, error)
+}
+
+// Ref key'in veritabanında saklanan referansı
+func Ref(key string) string {
+	return Scheme + key
+}
+
+// KeyFromRef referanstan key'i çıkarır, ref bir storage referansı değilse (eski dosya yolu veya dış adres) false döner
+func KeyFromRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, Scheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, Scheme), true
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultStore BlobStore
+	defaultTTL   time.Duration
+)
+
+// SetDefault view modellerin referansları indirme adresine çevirirken kullandığı store'u ayarlar
+func SetDefault(store BlobStore, urlTTL time.Duration) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = store
+	defaultTTL = urlTTL
+}
+
+// URL referansın imzalı indirme adresini döner. Storage referansı olmayan değerler (dış adresler, eski
+// dosya yolları) olduğu gibi döner; adres üretilemezse boş döner.
+func URL(ref string) string {
+	key, ok := KeyFromRef(ref)
+	if !ok {
+		return ref
+	}
+
+	defaultMu.RLock()
+	store, ttl := defaultStore, defaultTTL
+	defaultMu.RUnlock()
+	if store == nil {
+		return ""
+	}
+
+	url, err := store.SignedURL(key, time.Now().Add(ttl))
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+// validKey key'in göreli, boş olmayan ve ".." içermeyen bir yol olduğunu kontrol eder
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
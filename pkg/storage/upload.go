@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+)
+
+// sniffLen içerik tipinin tespiti için okunan bayt sayısı (http.DetectContentType)
+const sniffLen = 512
+
+// imageTypes yüklemede kabul edilen içerik tipleri ve key uzantıları
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Uploader istemciden gelen dosyaları doğrulayıp store'a yazar
+type Uploader struct {
+	store   BlobStore
+	maxSize int64
+}
+
+func NewUploader(store BlobStore, maxSize int64) Uploader {
+	return Uploader{store: store, maxSize: maxSize}
+}
+
+// Image yüklenen fotoğrafı boyut ve içerik tipine göre doğrulayıp prefix altında rastgele (UUID) bir key'le
+// saklar ve veritabanına yazılacak referansı döner. İçerik tipi istemcinin bildirdiğinden değil dosyanın
+// ilk baytlarından tespit edilir, istemcinin dosya adı kullanılmaz.
+func (u Uploader) Image(ctx context.Context, prefix string, file *multipart.FileHeader) (string, error) {
+	if file.Size <= 0 || file.Size > u.maxSize {
+		return "", ErrFileTooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := imageTypes[contentType]
+	if !ok {
+		return "", ErrUnsupportedContent
+	}
+
+	key := path.Join(prefix, uuid.NewString()+ext)
+	body := io.MultiReader(bytes.NewReader(head), f)
+	if err = u.store.Put(ctx, key, body, file.Size, contentType); err != nil {
+		return "", err
+	}
+
+	return Ref(key), nil
+}
+
+// IsInvalidUpload hata istemcinin yüklediği dosyadan kaynaklanıyorsa (boyut, içerik tipi) true döner
+func IsInvalidUpload(err error) bool {
+	return errors.Is(err, ErrFileTooLarge) || errors.Is(err, ErrUnsupportedContent)
+}
+
+// Delete referansı verilen dosyayı siler, storage referansı olmayan değerler için bir şey yapmaz
+func (u Uploader) Delete(ctx context.Context, ref string) error {
+	key, ok := KeyFromRef(ref)
+	if !ok {
+		return nil
+	}
+	return u.store.Delete(ctx, key)
+}
@@ -0,0 +1,151 @@
+// Package unlocktoken çevrimdışı kilit açma token'larını üretir ve doğrular.
+//
+// Token, sunucunun cihazın eşleştirme anahtarıyla (PairingKey) imzaladığı kısa ömürlü bir yetkidir.
+// Telefon token'ı BLE üzerinden motora iletir, motor internet bağlantısı olmadan Verify ile doğrular.
+// Firmware tarafı aşağıdaki düzeni birebir uygulamalıdır.
+//
+// Token metni: base64url(payload) + "." + base64url(HMAC-SHA256(key, payload)), dolgu (=) yoktur.
+// key, cihazın 64 karakterlik hex eşleştirme anahtarının çözülmüş 32 baytıdır.
+//
+// Payload (57 bayt, tüm tamsayılar big-endian, işaretsiz):
+//
+//	0      1   sürüm (1)
+//	1     16   token ID (rastgele)
+//	17     8   kullanıcı ID
+//	25     8   motor ID
+//	33     8   bağlantı ID
+//	41     8   oluşturulma zamanı (unix saniye)
+//	49     8   son geçerlilik zamanı (unix saniye)
+//
+// Motor; imzayı sabit zamanlı karşılaştırmalı, motor ID'nin kendisiyle aynı olduğunu, token'ın süresinin
+// dolmadığını ve token ID'nin iptal listesinde (/api/device-api/revoked-tokens) olmadığını kontrol etmelidir.
+// Aynı token'ın tekrar kullanılmaması için kullanılan token ID'leri süreleri dolana kadar saklanmalıdır.
+package unlocktoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	Version     = 1
+	payloadSize = 57
+	keySize     = 32
+)
+
+var (
+	ErrInvalidKey       = errors.New("unlock token key must be 32 bytes")
+	ErrMalformedToken   = errors.New("malformed unlock token")
+	ErrInvalidSignature = errors.New("invalid unlock token signature")
+	ErrWrongMotorbike   = errors.New("unlock token is for another motorbike")
+	ErrExpiredToken     = errors.New("unlock token expired")
+)
+
+// Claims token'ın bağladığı bilgiler
+type Claims struct {
+	TokenID      [16]byte
+	UserID       uint64
+	MotorbikeID  uint64
+	ConnectionID uint64
+	IssuedAt     time.Time
+	ExpiresAt    time.Time
+}
+
+// TokenIDHex token ID'sinin iptal listesinde ve veritabanında kullanılan hex gösterimi
+func (c Claims) TokenIDHex() string {
+	return hex.EncodeToString(c.TokenID[:])
+}
+
+// DecodeKey cihazın hex eşleştirme anahtarını imzalama anahtarına çevirir
+func DecodeKey(pairingKey string) ([]byte, error) {
+	key, err := hex.DecodeString(pairingKey)
+	if err != nil || len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// Sign claims'i verilen anahtarla imzalar ve token metnini döner
+func Sign(key []byte, claims Claims) (string, error) {
+	if len(key) != keySize {
+		return "", ErrInvalidKey
+	}
+
+	payload := encodePayload(claims)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac(key, payload)), nil
+}
+
+// Verify token'ı çevrimdışı doğrular: imza, sürüm, motor ID ve son geçerlilik zamanı kontrol edilir.
+// İptal listesi ve tekrar kullanım kontrolü çağıranın sorumluluğundadır.
+func Verify(key []byte, token string, motorbikeID uint64, now time.Time) (*Claims, error) {
+	if len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrMalformedToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != payloadSize {
+		return nil, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	// imza, payload okunmadan önce kontrol edilir
+	if !hmac.Equal(signature, mac(key, payload)) {
+		return nil, ErrInvalidSignature
+	}
+	if payload[0] != Version {
+		return nil, ErrMalformedToken
+	}
+
+	claims := decodePayload(payload)
+	if claims.MotorbikeID != motorbikeID {
+		return nil, ErrWrongMotorbike
+	}
+	if !now.Before(claims.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func encodePayload(c Claims) []byte {
+	payload := make([]byte, payloadSize)
+	payload[0] = Version
+	copy(payload[1:17], c.TokenID[:])
+	binary.BigEndian.PutUint64(payload[17:25], c.UserID)
+	binary.BigEndian.PutUint64(payload[25:33], c.MotorbikeID)
+	binary.BigEndian.PutUint64(payload[33:41], c.ConnectionID)
+	binary.BigEndian.PutUint64(payload[41:49], uint64(c.IssuedAt.Unix()))
+	binary.BigEndian.PutUint64(payload[49:57], uint64(c.ExpiresAt.Unix()))
+	return payload
+}
+
+func decodePayload(payload []byte) Claims {
+	var c Claims
+	copy(c.TokenID[:], payload[1:17])
+	c.UserID = binary.BigEndian.Uint64(payload[17:25])
+	c.MotorbikeID = binary.BigEndian.Uint64(payload[25:33])
+	c.ConnectionID = binary.BigEndian.Uint64(payload[33:41])
+	c.IssuedAt = time.Unix(int64(binary.BigEndian.Uint64(payload[41:49])), 0).UTC()
+	c.ExpiresAt = time.Unix(int64(binary.BigEndian.Uint64(payload[49:57])), 0).UTC()
+	return c
+}
+
+func mac(key, payload []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,150 @@
+package unlocktoken
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const pairingKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+var issuedAt = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := DecodeKey(pairingKey)
+	if err != nil {
+		t.Fatalf("DecodeKey() = %v", err)
+	}
+	return key
+}
+
+func testClaims() Claims {
+	return Claims{
+		TokenID:      [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		UserID:       42,
+		MotorbikeID:  7,
+		ConnectionID: 99,
+		IssuedAt:     issuedAt,
+		ExpiresAt:    issuedAt.Add(5 * time.Minute),
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	key := testKey(t)
+	claims := testClaims()
+
+	token, err := Sign(key, claims)
+	if err != nil {
+		t.Fatalf("Sign() = %v", err)
+	}
+
+	got, err := Verify(key, token, claims.MotorbikeID, issuedAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if *got != claims {
+		t.Errorf("Verify() = %+v, want %+v", *got, claims)
+	}
+	if got.TokenIDHex() != "0102030405060708090a0b0c0d0e0f10" {
+		t.Errorf("TokenIDHex() = %s", got.TokenIDHex())
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	key := testKey(t)
+	claims := testClaims()
+	token, err := Sign(key, claims)
+	if err != nil {
+		t.Fatalf("Sign() = %v", err)
+	}
+	encodedPayload, encodedSignature, _ := strings.Cut(token, ".")
+
+	// payload'ın bir baytı değiştirilip eski imzayla gönderilir
+	payload, _ := base64.RawURLEncoding.DecodeString(encodedPayload)
+	payload[20] ^= 0x01
+	tamperedPayload := base64.RawURLEncoding.EncodeToString(payload) + "." + encodedSignature
+
+	signature, _ := base64.RawURLEncoding.DecodeString(encodedSignature)
+	signature[0] ^= 0x01
+	tamperedSignature := encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	otherKey := testKey(t)
+	otherKey[0] ^= 0xff
+
+	tests := []struct {
+		name        string
+		key         []byte
+		token       string
+		motorbikeID uint64
+		now         time.Time
+		want        error
+	}{
+		{"payload baytı değişmiş", key, tamperedPayload, claims.MotorbikeID, issuedAt, ErrInvalidSignature},
+		{"imza baytı değişmiş", key, tamperedSignature, claims.MotorbikeID, issuedAt, ErrInvalidSignature},
+		{"başka anahtar", otherKey, token, claims.MotorbikeID, issuedAt, ErrInvalidSignature},
+		{"başka motor", key, token, claims.MotorbikeID + 1, issuedAt, ErrWrongMotorbike},
+		{"süresi tam dolmuş", key, token, claims.MotorbikeID, claims.ExpiresAt, ErrExpiredToken},
+		{"süresi geçmiş", key, token, claims.MotorbikeID, claims.ExpiresAt.Add(time.Hour), ErrExpiredToken},
+		{"nokta yok", key, encodedPayload, claims.MotorbikeID, issuedAt, ErrMalformedToken},
+		{"kısa payload", key, "AAAA." + encodedSignature, claims.MotorbikeID, issuedAt, ErrMalformedToken},
+		{"geçersiz base64", key, "!!!." + encodedSignature, claims.MotorbikeID, issuedAt, ErrMalformedToken},
+		{"geçersiz anahtar", key[:16], token, claims.MotorbikeID, issuedAt, ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(tt.key, tt.token, tt.motorbikeID, tt.now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() = %v, want %v", err, tt.want)
+			}
+			if got != nil {
+				t.Errorf("Verify() claims = %+v, want nil", *got)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsUnknownVersion(t *testing.T) {
+	key := testKey(t)
+
+	// imzası geçerli ama sürümü farklı payload
+	payload := encodePayload(testClaims())
+	payload[0] = Version + 1
+	token := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac(key, payload))
+
+	if _, err := Verify(key, token, 7, issuedAt); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("Verify() = %v, want ErrMalformedToken", err)
+	}
+}
+
+func TestDecodeKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"geçerli", pairingKey, false},
+		{"kısa", pairingKey[:62], true},
+		{"hex değil", strings.Repeat("zz", 32), true},
+		{"boş", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := DecodeKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeKey() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(key) != keySize {
+				t.Errorf("len(key) = %d, want %d", len(key), keySize)
+			}
+		})
+	}
+
+	if _, err := Sign([]byte("kısa"), testClaims()); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Sign() = %v, want ErrInvalidKey", err)
+	}
+}